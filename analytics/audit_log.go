@@ -0,0 +1,111 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"red-duck/internal/core/domain"
+)
+
+// defaultAuditLimit caps a page of the audit log when the query doesn't.
+const defaultAuditLimit = 100
+
+const auditColumns = `id, business_id, queue_id, actor_id, actor_role, action, target_user_id,
+	before, after, request_id, created_at, prev_hash, hash`
+
+// AppendAuditEntry chains entry after the last entry of its business and
+// stores it under key. Appends for a business are serialised by an advisory
+// lock, so concurrent queues can't fork its chain.
+func (r *PostgresRepository) AppendAuditEntry(ctx context.Context, key string, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.AuditEntry{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('audit_log:' || $1))`, entry.BusinessID); err != nil {
+		return domain.AuditEntry{}, err
+	}
+
+	stored, err := scanAuditEntry(tx.QueryRow(ctx, `SELECT `+auditColumns+` FROM audit_log WHERE entry_key = $1`, key))
+	if err == nil {
+		return stored, nil
+	}
+	if err != pgx.ErrNoRows {
+		return domain.AuditEntry{}, err
+	}
+
+	var prevHash string
+	err = tx.QueryRow(ctx, `SELECT hash FROM audit_log WHERE business_id = $1 ORDER BY id DESC LIMIT 1`, entry.BusinessID).Scan(&prevHash)
+	if err != nil && err != pgx.ErrNoRows {
+		return domain.AuditEntry{}, err
+	}
+	if err := entry.Chain(prevHash); err != nil {
+		return domain.AuditEntry{}, err
+	}
+
+	query := `
+		INSERT INTO audit_log (entry_key, business_id, queue_id, actor_id, actor_role, action, target_user_id,
+			before, after, request_id, created_at, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, query, key, entry.BusinessID, entry.QueueID, entry.ActorID, entry.ActorRole, entry.Action,
+		entry.TargetUserID, entry.Before, entry.After, entry.RequestID, entry.At, entry.PrevHash, entry.Hash).Scan(&entry.ID)
+	if err != nil {
+		return domain.AuditEntry{}, err
+	}
+	return entry, tx.Commit(ctx)
+}
+
+// ListAuditEntries returns a page of the business's audit log, oldest first.
+func (r *PostgresRepository) ListAuditEntries(ctx context.Context, q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	query := `
+		SELECT ` + auditColumns + `
+		FROM audit_log
+		WHERE business_id = $1
+			AND ($2 = '' OR queue_id = $2)
+			AND ($3 = '' OR actor_id = $3)
+			AND ($4::timestamptz IS NULL OR created_at >= $4)
+			AND ($5::timestamptz IS NULL OR created_at < $5)
+			AND id > $6
+		ORDER BY id
+		LIMIT $7
+	`
+	rows, err := r.pool.Query(ctx, query, q.BusinessID, q.QueueID, q.ActorID, nullTime(q.From), nullTime(q.To), q.AfterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		e, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func scanAuditEntry(row pgx.Row) (domain.AuditEntry, error) {
+	var e domain.AuditEntry
+	err := row.Scan(&e.ID, &e.BusinessID, &e.QueueID, &e.ActorID, &e.ActorRole, &e.Action, &e.TargetUserID,
+		&e.Before, &e.After, &e.RequestID, &e.At, &e.PrevHash, &e.Hash)
+	return e, err
+}
+
+// nullTime is t, or NULL when t is zero.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
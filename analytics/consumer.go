@@ -4,36 +4,53 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"red-duck/internal/pkg/requestid"
 )
 
-// StartIngest listens to NATS and writes to Postgres
-func StartIngest(nc *nats.Conn, repo EventRepository) {
+// StartIngest listens to NATS and writes to Postgres. Draining the connection stops it.
+func StartIngest(nc *nats.Conn, repo EventRepository) error {
 	_, err := nc.Subscribe("events.>", func(msg *nats.Msg) {
-		if err := ProcessMessage(msg.Data, repo); err != nil {
-			log.Printf("Error processing message: %v", err)
+		ctx := extractTraceContext(context.Background(), msg)
+		if id := msg.Header.Get(requestid.Header); id != "" {
+			ctx = requestid.With(ctx, id)
+		}
+		ctx, span := startMessagingSpan(ctx, "process", msg.Subject, trace.SpanKindConsumer)
+		defer span.End()
+
+		if err := ProcessMessage(ctx, msg.Data, repo); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "process failed")
+			slog.ErrorContext(ctx, "Failed to process analytics event", "subject", msg.Subject, "error", err)
 		}
 	})
 
 	if err != nil {
-		log.Printf("Error subscribing to NATS: %v", err)
-		return
+		return fmt.Errorf("failed to subscribe to NATS: %w", err)
 	}
 
-	log.Println("Analytics consumer started, listening on 'events.>'")
+	slog.Info("Analytics consumer started", "subject", "events.>")
+	return nil
 }
 
 // ProcessMessage handles a single message payload and inserts it into the repository
-func ProcessMessage(data []byte, repo EventRepository) error {
+func ProcessMessage(ctx context.Context, data []byte, repo EventRepository) error {
 	var payload EventPayload
 	if err := json.Unmarshal(data, &payload); err != nil {
+		insertErrors.Inc()
 		return fmt.Errorf("error unmarshaling event payload: %w", err)
 	}
+	ingestLag.Observe(time.Since(payload.Timestamp).Seconds())
 
 	// Insert into Repository
-	if err := repo.InsertEvent(context.Background(), payload); err != nil {
+	if err := repo.InsertEvent(ctx, payload); err != nil {
+		insertErrors.Inc()
 		return fmt.Errorf("error inserting event into database: %w", err)
 	}
 
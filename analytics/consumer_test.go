@@ -39,7 +39,7 @@ func TestProcessMessage_InsertsToDB(t *testing.T) {
 		return p.UserID == "123" && p.Type == "queue.joined"
 	})).Return(nil)
 
-	err = ProcessMessage(jsonBytes, mockRepo)
+	err = ProcessMessage(context.Background(), jsonBytes, mockRepo)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
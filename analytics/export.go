@@ -0,0 +1,218 @@
+package analytics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// Valid reports whether the format is supported.
+func (f ExportFormat) Valid() bool {
+	return f == ExportFormatCSV || f == ExportFormatNDJSON
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// TicketHistory is one ticket, from join to call or abandonment, rebuilt from analytics_events.
+type TicketHistory struct {
+	QueueID   string     `json:"queue_id"`
+	UserID    string     `json:"user_id,omitempty"`
+	TicketRef string     `json:"ticket_ref"`
+	JoinedAt  time.Time  `json:"joined_at"`
+	CalledAt  *time.Time `json:"called_at,omitempty"`
+	CounterID string     `json:"counter_id,omitempty"`
+	// LeftAt is when the ticket ended without being called: the guest left,
+	// or it was cancelled, removed or expired.
+	LeftAt  *time.Time `json:"left_at,omitempty"`
+	Outcome string     `json:"outcome"`
+	// PartySize is how many guests the ticket stood for; 1 for tickets
+	// joined before parties were recorded.
+	PartySize int `json:"party_size"`
+}
+
+const (
+	OutcomeCalled    = "called"
+	OutcomeLeft      = "left"
+	OutcomeCancelled = "cancelled"
+	OutcomeRemoved   = "removed"
+	OutcomeExpired   = "expired"
+	OutcomeWaiting   = "waiting"
+)
+
+// endOutcomes are the events that end a ticket before its call, by the
+// outcome each gives it.
+var endOutcomes = map[string]string{
+	"queue.left":           OutcomeLeft,
+	"queue.cancelled":      OutcomeCancelled,
+	"queue.ticket_removed": OutcomeRemoved,
+	"queue.ticket_expired": OutcomeExpired,
+}
+
+// ExportRepository streams ticket history without loading it all in memory.
+type ExportRepository interface {
+	StreamTicketHistory(ctx context.Context, q ReportQuery, fn func(TicketHistory) error) error
+}
+
+// ensure PostgresRepository implements ExportRepository
+var _ ExportRepository = (*PostgresRepository)(nil)
+
+func (r *PostgresRepository) StreamTicketHistory(ctx context.Context, q ReportQuery, fn func(TicketHistory) error) error {
+	// A ticket's call and end must happen before the same user joins the same queue again.
+	query := `
+		WITH joins AS (
+			SELECT business_id, user_id, properties->>'queue_id' AS queue_id, timestamp AS joined_at,
+				COALESCE((properties->>'party_size')::int, 1) AS party_size,
+				LEAD(timestamp) OVER (
+					PARTITION BY business_id, user_id, properties->>'queue_id' ORDER BY timestamp
+				) AS next_join_at
+			FROM analytics_events
+			WHERE event_type = 'queue.joined' AND` + reportScope + `
+		)
+		SELECT COALESCE(j.queue_id, ''), COALESCE(j.user_id, ''), j.joined_at, c.timestamp, COALESCE(c.counter_id, ''), l.timestamp, COALESCE(l.event_type, ''), j.party_size
+		FROM joins j
+		LEFT JOIN LATERAL (
+			SELECT e.timestamp, e.properties->>'counter_id' AS counter_id
+			FROM analytics_events e
+			WHERE e.event_type = 'queue.called'
+				AND e.business_id = j.business_id AND e.user_id = j.user_id
+				AND e.properties->>'queue_id' = j.queue_id
+				AND e.timestamp >= j.joined_at AND e.timestamp < COALESCE(j.next_join_at, 'infinity')
+			ORDER BY e.timestamp
+			LIMIT 1
+		) c ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT e.timestamp, e.event_type
+			FROM analytics_events e
+			WHERE e.event_type IN ('queue.left', 'queue.cancelled', 'queue.ticket_removed', 'queue.ticket_expired')
+				AND e.business_id = j.business_id AND e.user_id = j.user_id
+				AND e.properties->>'queue_id' = j.queue_id
+				AND e.timestamp >= j.joined_at AND e.timestamp < COALESCE(j.next_join_at, 'infinity')
+			ORDER BY e.timestamp
+			LIMIT 1
+		) l ON TRUE
+		ORDER BY j.joined_at
+	`
+	rows, err := r.pool.Query(ctx, query, q.args()...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t TicketHistory
+		var endedBy string
+		if err := rows.Scan(&t.QueueID, &t.UserID, &t.JoinedAt, &t.CalledAt, &t.CounterID, &t.LeftAt, &endedBy, &t.PartySize); err != nil {
+			return err
+		}
+		t.TicketRef = TicketRef(q.BusinessID, t.QueueID, t.UserID, t.JoinedAt)
+		t.Outcome = ticketOutcome(t, endedBy)
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ticketOutcome is how the ticket ended; endedBy is the event type that
+// set its LeftAt, if any.
+func ticketOutcome(t TicketHistory, endedBy string) string {
+	switch {
+	case t.CalledAt != nil && (t.LeftAt == nil || !t.LeftAt.Before(*t.CalledAt)):
+		return OutcomeCalled
+	case t.LeftAt != nil:
+		if outcome, ok := endOutcomes[endedBy]; ok {
+			return outcome
+		}
+		return OutcomeLeft
+	default:
+		return OutcomeWaiting
+	}
+}
+
+// TicketRef is a stable pseudonymous reference for a ticket, so rows can be
+// correlated without exposing the user ID.
+func TicketRef(businessID, queueID, userID string, joinedAt time.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d", businessID, queueID, userID, joinedAt.UnixNano())))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ExportWriter serializes ticket history rows in one of the export formats.
+type ExportWriter struct {
+	includePII bool
+	csv        *csv.Writer
+	json       *json.Encoder
+	rows       int64
+}
+
+func NewExportWriter(w io.Writer, format ExportFormat, includePII bool) (*ExportWriter, error) {
+	ew := &ExportWriter{includePII: includePII}
+	switch format {
+	case ExportFormatCSV:
+		ew.csv = csv.NewWriter(w)
+		header := []string{"queue_id", "ticket_ref"}
+		if includePII {
+			header = append(header, "user_id")
+		}
+		header = append(header, "joined_at", "called_at", "counter_id", "left_at", "outcome", "party_size")
+		if err := ew.csv.Write(header); err != nil {
+			return nil, err
+		}
+	case ExportFormatNDJSON:
+		ew.json = json.NewEncoder(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return ew, nil
+}
+
+func (ew *ExportWriter) Write(t TicketHistory) error {
+	if !ew.includePII {
+		t.UserID = ""
+	}
+	ew.rows++
+
+	if ew.json != nil {
+		return ew.json.Encode(t)
+	}
+
+	record := []string{t.QueueID, t.TicketRef}
+	if ew.includePII {
+		record = append(record, t.UserID)
+	}
+	record = append(record, formatExportTime(&t.JoinedAt), formatExportTime(t.CalledAt), t.CounterID, formatExportTime(t.LeftAt), t.Outcome, strconv.Itoa(t.PartySize))
+	return ew.csv.Write(record)
+}
+
+// Close flushes buffered output and returns the number of rows written.
+func (ew *ExportWriter) Close() (int64, error) {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		return ew.rows, ew.csv.Error()
+	}
+	return ew.rows, nil
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
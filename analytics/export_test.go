@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+
+	"red-duck/internal/adapters/objectstore"
+)
+
+type MockExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockExportRepository) StreamTicketHistory(ctx context.Context, q ReportQuery, fn func(TicketHistory) error) error {
+	args := m.Called(ctx, q, fn)
+	for _, t := range args.Get(0).([]TicketHistory) {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func sampleTickets() []TicketHistory {
+	joined := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	called := joined.Add(12 * time.Minute)
+	return []TicketHistory{
+		{QueueID: "q1", UserID: "alice@example.com", TicketRef: "ref1", JoinedAt: joined, CalledAt: &called, CounterID: "Counter 3", Outcome: OutcomeCalled, PartySize: 4},
+		{QueueID: "q1", UserID: "bob@example.com", TicketRef: "ref2", JoinedAt: joined.Add(time.Minute), Outcome: OutcomeWaiting, PartySize: 1},
+	}
+}
+
+func TestExportWriter_CSV(t *testing.T) {
+	t.Run("Without PII", func(t *testing.T) {
+		var buf bytes.Buffer
+		ew, err := NewExportWriter(&buf, ExportFormatCSV, false)
+		assert.NoError(t, err)
+		for _, ticket := range sampleTickets() {
+			assert.NoError(t, ew.Write(ticket))
+		}
+		rows, err := ew.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), rows)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, "queue_id,ticket_ref,joined_at,called_at,counter_id,left_at,outcome,party_size", lines[0])
+		assert.Equal(t, "q1,ref1,2026-03-01T09:00:00Z,2026-03-01T09:12:00Z,Counter 3,,called,4", lines[1])
+		assert.NotContains(t, buf.String(), "alice@example.com")
+	})
+
+	t.Run("With PII", func(t *testing.T) {
+		var buf bytes.Buffer
+		ew, err := NewExportWriter(&buf, ExportFormatCSV, true)
+		assert.NoError(t, err)
+		assert.NoError(t, ew.Write(sampleTickets()[0]))
+		_, err = ew.Close()
+		assert.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "queue_id,ticket_ref,user_id,")
+		assert.Contains(t, buf.String(), "alice@example.com")
+	})
+}
+
+func TestExportWriter_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	ew, err := NewExportWriter(&buf, ExportFormatNDJSON, false)
+	assert.NoError(t, err)
+	for _, ticket := range sampleTickets() {
+		assert.NoError(t, ew.Write(ticket))
+	}
+	_, err = ew.Close()
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	var first map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "ref1", first["ticket_ref"])
+	assert.EqualValues(t, 4, first["party_size"])
+	assert.NotContains(t, first, "user_id")
+}
+
+func TestTicketOutcome(t *testing.T) {
+	joined := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	called := joined.Add(5 * time.Minute)
+	left := joined.Add(2 * time.Minute)
+
+	assert.Equal(t, OutcomeCalled, ticketOutcome(TicketHistory{JoinedAt: joined, CalledAt: &called}, ""))
+	assert.Equal(t, OutcomeLeft, ticketOutcome(TicketHistory{JoinedAt: joined, CalledAt: &called, LeftAt: &left}, "queue.left"))
+	assert.Equal(t, OutcomeWaiting, ticketOutcome(TicketHistory{JoinedAt: joined}, ""))
+
+	// Tickets that ended otherwise say how
+	assert.Equal(t, OutcomeCancelled, ticketOutcome(TicketHistory{JoinedAt: joined, LeftAt: &left}, "queue.cancelled"))
+	assert.Equal(t, OutcomeRemoved, ticketOutcome(TicketHistory{JoinedAt: joined, LeftAt: &left}, "queue.ticket_removed"))
+	assert.Equal(t, OutcomeExpired, ticketOutcome(TicketHistory{JoinedAt: joined, LeftAt: &left}, "queue.ticket_expired"))
+}
+
+func TestExportTicketHistory_WritesToStore(t *testing.T) {
+	root := t.TempDir()
+	repo := new(MockExportRepository)
+	activities := &ExportActivities{Repo: repo, Store: objectstore.NewLocalStore(root)}
+
+	req := ExportRequest{
+		JobID:      "job-1",
+		BusinessID: "biz_123",
+		From:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Format:     ExportFormatNDJSON,
+	}
+	repo.On("StreamTicketHistory", mock.Anything, ReportQuery{BusinessID: "biz_123", From: req.From, To: req.To}, mock.Anything).
+		Return(sampleTickets(), nil)
+
+	env := new(testsuite.WorkflowTestSuite).NewTestActivityEnvironment()
+	env.RegisterActivity(activities)
+	val, err := env.ExecuteActivity(activities.ExportTicketHistory, req)
+	assert.NoError(t, err)
+
+	var result ExportResult
+	assert.NoError(t, val.Get(&result))
+	assert.Equal(t, "exports/biz_123/job-1.ndjson", result.Key)
+	assert.Equal(t, int64(2), result.Rows)
+
+	data, err := os.ReadFile(filepath.Join(root, "exports", "biz_123", "job-1.ndjson"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "\n"))
+	repo.AssertExpectations(t)
+}
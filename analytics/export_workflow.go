@@ -0,0 +1,117 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"red-duck/internal/adapters/objectstore"
+)
+
+// ExportRequest describes one queue history export job.
+type ExportRequest struct {
+	JobID      string
+	BusinessID string
+	QueueID    string
+	From       time.Time
+	To         time.Time
+	Format     ExportFormat
+	IncludePII bool
+}
+
+type ExportResult struct {
+	Key         string
+	ContentType string
+	Rows        int64
+}
+
+// ExportWorkflowID is the workflow ID of an export job. It embeds the business
+// so a job can only be looked up by the business that started it. Business IDs
+// may contain "-", so the ID only names one job when jobID is a ValidJobID.
+func ExportWorkflowID(businessID, jobID string) string {
+	return fmt.Sprintf("export-%s-%s", businessID, jobID)
+}
+
+// ValidJobID reports whether jobID is a UUID in the canonical form jobs are
+// given. Its fixed length keeps another business's job from being named by
+// moving part of the business ID into the job ID.
+func ValidJobID(jobID string) bool {
+	id, err := uuid.Parse(jobID)
+	return err == nil && id.String() == jobID
+}
+
+// ExportKey is the object key an export job is written to.
+func ExportKey(req ExportRequest) string {
+	return fmt.Sprintf("exports/%s/%s.%s", req.BusinessID, req.JobID, req.Format)
+}
+
+// ExportWorkflow writes the ticket history of a business to object storage and
+// returns where it was written.
+func ExportWorkflow(ctx workflow.Context, req ExportRequest) (ExportResult, error) {
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *ExportActivities
+	var result ExportResult
+	if err := workflow.ExecuteActivity(ctx, a.ExportTicketHistory, req).Get(ctx, &result); err != nil {
+		return ExportResult{}, err
+	}
+
+	workflow.GetLogger(ctx).Info("ExportWorkflow completed", "JobID", req.JobID, "Rows", result.Rows)
+	return result, nil
+}
+
+type ExportActivities struct {
+	Repo  ExportRepository
+	Store objectstore.Store
+}
+
+// ExportTicketHistory streams the ticket history straight into the object store.
+// Retrying overwrites the same key, so a partial upload never survives.
+func (a *ExportActivities) ExportTicketHistory(ctx context.Context, req ExportRequest) (ExportResult, error) {
+	if a.Store == nil {
+		return ExportResult{}, fmt.Errorf("no object store is configured")
+	}
+
+	pr, pw := io.Pipe()
+	written := make(chan int64, 1)
+	go func() {
+		n, err := a.writeExport(ctx, req, pw)
+		written <- n
+		pw.CloseWithError(err)
+	}()
+
+	key := ExportKey(req)
+	err := a.Store.Put(ctx, key, pr, req.Format.ContentType())
+	pr.CloseWithError(err)
+	rows := <-written
+	if err != nil {
+		return ExportResult{}, fmt.Errorf("failed to write export %s: %w", req.JobID, err)
+	}
+
+	activity.GetLogger(ctx).Info("Exported ticket history", "JobID", req.JobID, "Key", key, "Rows", rows)
+	return ExportResult{Key: key, ContentType: req.Format.ContentType(), Rows: rows}, nil
+}
+
+func (a *ExportActivities) writeExport(ctx context.Context, req ExportRequest, w io.Writer) (int64, error) {
+	ew, err := NewExportWriter(w, req.Format, req.IncludePII)
+	if err != nil {
+		return 0, err
+	}
+	q := ReportQuery{BusinessID: req.BusinessID, QueueID: req.QueueID, From: req.From, To: req.To}
+	if err := a.Repo.StreamTicketHistory(ctx, q, ew.Write); err != nil {
+		return 0, err
+	}
+	return ew.Close()
+}
@@ -0,0 +1,100 @@
+package analytics
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"red-duck/internal/adapters/objectstore"
+)
+
+type MaintenanceActivities struct {
+	Repo  PartitionRepository
+	Store objectstore.Store
+}
+
+// ArchiveResult describes one archived partition.
+type ArchiveResult struct {
+	Key    string
+	Events int64
+}
+
+// ArchiveKey is the object key a partition is archived under.
+func ArchiveKey(p Partition) string {
+	return fmt.Sprintf("analytics_events/%04d/%s.jsonl.gz", p.Month.Year(), p.Name)
+}
+
+// EnsurePartitions creates the partitions for the month containing from and the monthsAhead months after it.
+func (a *MaintenanceActivities) EnsurePartitions(ctx context.Context, from time.Time, monthsAhead int) error {
+	start := time.Date(from.UTC().Year(), from.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= monthsAhead; i++ {
+		if err := a.Repo.EnsurePartition(ctx, start.AddDate(0, i, 0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrainDefaultPartition moves events out of the default partition into the
+// partitions of their months, from since onward, and returns the partitions it
+// created for them.
+func (a *MaintenanceActivities) DrainDefaultPartition(ctx context.Context, since time.Time) ([]string, error) {
+	created, err := a.Repo.DrainDefaultPartition(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) > 0 {
+		activity.GetLogger(ctx).Info("Moved analytics events out of the default partition", "Partitions", created)
+	}
+	return created, nil
+}
+
+func (a *MaintenanceActivities) ListPartitions(ctx context.Context) ([]Partition, error) {
+	return a.Repo.ListPartitions(ctx)
+}
+
+// ArchivePartition detaches the partition and streams it to object storage as gzipped JSON lines.
+// It is safe to retry: the partition stays in place (detached) until DropPartition runs.
+func (a *MaintenanceActivities) ArchivePartition(ctx context.Context, p Partition) (ArchiveResult, error) {
+	if a.Store == nil {
+		return ArchiveResult{}, fmt.Errorf("archival requested but no object store is configured")
+	}
+	if err := a.Repo.DetachPartition(ctx, p.Name); err != nil {
+		return ArchiveResult{}, err
+	}
+
+	pr, pw := io.Pipe()
+	exported := make(chan int64, 1)
+	go func() {
+		gz := gzip.NewWriter(pw)
+		n, err := a.Repo.ExportPartition(ctx, p.Name, gz)
+		if err == nil {
+			err = gz.Close()
+		}
+		exported <- n
+		pw.CloseWithError(err)
+	}()
+
+	key := ArchiveKey(p)
+	err := a.Store.Put(ctx, key, pr, "application/gzip")
+	// Unblock the exporter if the upload gave up early.
+	pr.CloseWithError(err)
+	n := <-exported
+	if err != nil {
+		return ArchiveResult{}, fmt.Errorf("failed to archive %s: %w", p.Name, err)
+	}
+
+	activity.GetLogger(ctx).Info("Archived analytics partition", "Partition", p.Name, "Key", key, "Events", n)
+	return ArchiveResult{Key: key, Events: n}, nil
+}
+
+func (a *MaintenanceActivities) DropPartition(ctx context.Context, p Partition) error {
+	if err := a.Repo.DetachPartition(ctx, p.Name); err != nil {
+		return err
+	}
+	return a.Repo.DropPartition(ctx, p.Name)
+}
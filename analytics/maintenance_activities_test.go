@@ -0,0 +1,111 @@
+package analytics
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+
+	"red-duck/internal/adapters/objectstore"
+)
+
+type MockPartitionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartitionRepository) EnsurePartition(ctx context.Context, month time.Time) error {
+	return m.Called(ctx, month).Error(0)
+}
+
+func (m *MockPartitionRepository) ListPartitions(ctx context.Context) ([]Partition, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]Partition), args.Error(1)
+}
+
+func (m *MockPartitionRepository) DetachPartition(ctx context.Context, name string) error {
+	return m.Called(ctx, name).Error(0)
+}
+
+func (m *MockPartitionRepository) ExportPartition(ctx context.Context, name string, w io.Writer) (int64, error) {
+	args := m.Called(ctx, name, w)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPartitionRepository) DropPartition(ctx context.Context, name string) error {
+	return m.Called(ctx, name).Error(0)
+}
+
+func (m *MockPartitionRepository) DrainDefaultPartition(ctx context.Context, since time.Time) ([]string, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestArchivePartition_WritesGzippedJSONLines(t *testing.T) {
+	root := t.TempDir()
+	repo := new(MockPartitionRepository)
+	activities := &MaintenanceActivities{Repo: repo, Store: objectstore.NewLocalStore(root)}
+
+	p := Partition{Name: "analytics_events_2025_01", Month: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	repo.On("DetachPartition", mock.Anything, p.Name).Return(nil)
+	repo.On("ExportPartition", mock.Anything, p.Name, mock.Anything).Return(int64(2), nil).Run(func(args mock.Arguments) {
+		enc := json.NewEncoder(args.Get(2).(io.Writer))
+		enc.Encode(EventPayload{Type: "queue.joined", BusinessID: "biz_123", UserID: "u1"})
+		enc.Encode(EventPayload{Type: "queue.called", BusinessID: "biz_123", UserID: "u1"})
+	})
+
+	env := new(testsuite.WorkflowTestSuite).NewTestActivityEnvironment()
+	env.RegisterActivity(activities)
+
+	val, err := env.ExecuteActivity(activities.ArchivePartition, p)
+	assert.NoError(t, err)
+	var result ArchiveResult
+	assert.NoError(t, val.Get(&result))
+	assert.Equal(t, "analytics_events/2025/analytics_events_2025_01.jsonl.gz", result.Key)
+	assert.Equal(t, int64(2), result.Events)
+
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(result.Key)))
+	assert.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.NoError(t, err)
+
+	var types []string
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var e EventPayload
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []string{"queue.joined", "queue.called"}, types)
+	repo.AssertExpectations(t)
+}
+
+func TestArchivePartition_RequiresStore(t *testing.T) {
+	repo := new(MockPartitionRepository)
+	activities := &MaintenanceActivities{Repo: repo}
+
+	_, err := activities.ArchivePartition(context.Background(), Partition{Name: "analytics_events_2025_01"})
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "DetachPartition", mock.Anything, mock.Anything)
+}
+
+func TestParsePartitionMonth(t *testing.T) {
+	month, ok := ParsePartitionMonth("analytics_events_2025_11")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), month)
+
+	_, ok = ParsePartitionMonth("analytics_events")
+	assert.False(t, ok)
+	_, ok = ParsePartitionMonth("analytics_events_2025_13")
+	assert.False(t, ok)
+}
@@ -0,0 +1,97 @@
+package analytics
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// MaintenanceScheduleID identifies both the schedule and the workflows it starts.
+const MaintenanceScheduleID = "analytics-partition-maintenance"
+
+// MaintenanceInput configures a partition maintenance run.
+type MaintenanceInput struct {
+	// MonthsAhead is how many future monthly partitions to keep created.
+	MonthsAhead int
+	// RetentionMonths is how many whole months to keep besides the current one. Zero keeps everything.
+	RetentionMonths int
+	// Archive uploads expired partitions to object storage before dropping them.
+	Archive bool
+}
+
+type MaintenanceResult struct {
+	// Created lists the partitions made for events found in the default partition.
+	Created  []string
+	Archived []ArchiveResult
+	Dropped  []string
+}
+
+// PartitionMaintenanceWorkflow creates upcoming analytics_events partitions and
+// archives and drops the ones that fell out of the retention window.
+func PartitionMaintenanceWorkflow(ctx workflow.Context, input MaintenanceInput) (MaintenanceResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	// Archiving a month of events can take a while. A run that keeps failing is
+	// left to the next scheduled run rather than retried indefinitely.
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *MaintenanceActivities
+	var result MaintenanceResult
+
+	now := workflow.Now(ctx).UTC()
+	if err := workflow.ExecuteActivity(ctx, a.EnsurePartitions, now, input.MonthsAhead).Get(ctx, nil); err != nil {
+		return result, err
+	}
+
+	// Events with no partition for their month land in the default partition;
+	// they move into one of their own here, unless their month is past
+	// retention and would only be archived again. Runs from before this step
+	// replay without it.
+	var cutoff time.Time
+	if input.RetentionMonths > 0 {
+		cutoff = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -input.RetentionMonths, 0)
+	}
+	if workflow.GetVersion(ctx, "drain-default-partition", workflow.DefaultVersion, 1) == 1 {
+		if err := workflow.ExecuteActivity(ctx, a.DrainDefaultPartition, cutoff).Get(ctx, &result.Created); err != nil {
+			return result, err
+		}
+	}
+
+	if input.RetentionMonths <= 0 {
+		return result, nil
+	}
+
+	var partitions []Partition
+	if err := workflow.ExecuteActivity(ctx, a.ListPartitions).Get(ctx, &partitions); err != nil {
+		return result, err
+	}
+
+	for _, p := range partitions {
+		if !p.Month.Before(cutoff) {
+			continue
+		}
+
+		if input.Archive {
+			var archived ArchiveResult
+			if err := workflow.ExecuteActivity(ctx, a.ArchivePartition, p).Get(ctx, &archived); err != nil {
+				return result, err
+			}
+			result.Archived = append(result.Archived, archived)
+		}
+
+		if err := workflow.ExecuteActivity(ctx, a.DropPartition, p).Get(ctx, nil); err != nil {
+			return result, err
+		}
+		result.Dropped = append(result.Dropped, p.Name)
+		logger.Info("Dropped analytics partition", "Partition", p.Name)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type MaintenanceWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestMaintenanceWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceWorkflowTestSuite))
+}
+
+func (s *MaintenanceWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&MaintenanceActivities{})
+	s.env.SetStartTime(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *MaintenanceWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func month(year int, m time.Month) Partition {
+	t := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	return Partition{Name: "analytics_events_" + t.Format("2006_01"), Month: t, Attached: true}
+}
+
+func (s *MaintenanceWorkflowTestSuite) TestArchivesAndDropsExpiredPartitions() {
+	var a *MaintenanceActivities
+	partitions := []Partition{month(2025, 8), month(2025, 9), month(2025, 10), month(2026, 10)}
+
+	s.env.OnActivity(a.EnsurePartitions, mock.Anything, mock.Anything, 3).Return(nil).Once()
+	s.env.OnActivity(a.DrainDefaultPartition, mock.Anything, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)).Return([]string{}, nil).Once()
+	s.env.OnActivity(a.ListPartitions, mock.Anything).Return(partitions, nil)
+	// Retention of 12 months keeps October 2025 onwards.
+	s.env.OnActivity(a.ArchivePartition, mock.Anything, partitions[0]).Return(ArchiveResult{Key: ArchiveKey(partitions[0]), Events: 10}, nil).Once()
+	s.env.OnActivity(a.ArchivePartition, mock.Anything, partitions[1]).Return(ArchiveResult{Key: ArchiveKey(partitions[1]), Events: 20}, nil).Once()
+	s.env.OnActivity(a.DropPartition, mock.Anything, partitions[0]).Return(nil).Once()
+	s.env.OnActivity(a.DropPartition, mock.Anything, partitions[1]).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, MaintenanceInput{MonthsAhead: 3, RetentionMonths: 12, Archive: true})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	var result MaintenanceResult
+	s.NoError(s.env.GetWorkflowResult(&result))
+	s.Equal([]string{"analytics_events_2025_08", "analytics_events_2025_09"}, result.Dropped)
+	s.Len(result.Archived, 2)
+}
+
+func (s *MaintenanceWorkflowTestSuite) TestDoesNotDropWhenArchivalFails() {
+	var a *MaintenanceActivities
+	partitions := []Partition{month(2024, 1)}
+
+	s.env.OnActivity(a.EnsurePartitions, mock.Anything, mock.Anything, 3).Return(nil)
+	s.env.OnActivity(a.DrainDefaultPartition, mock.Anything, mock.Anything).Return([]string{}, nil)
+	s.env.OnActivity(a.ListPartitions, mock.Anything).Return(partitions, nil)
+	s.env.OnActivity(a.ArchivePartition, mock.Anything, partitions[0]).Return(ArchiveResult{}, errors.New("bucket unavailable"))
+
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, MaintenanceInput{MonthsAhead: 3, RetentionMonths: 12, Archive: true})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+	s.env.AssertActivityNotCalled(s.T(), "DropPartition", mock.Anything, mock.Anything)
+}
+
+func (s *MaintenanceWorkflowTestSuite) TestZeroRetentionOnlyCreatesPartitions() {
+	var a *MaintenanceActivities
+	s.env.OnActivity(a.EnsurePartitions, mock.Anything, mock.Anything, 2).Return(nil).Once()
+	// With nothing expiring, every month in the default partition gets its own
+	s.env.OnActivity(a.DrainDefaultPartition, mock.Anything, time.Time{}).Return([]string{"analytics_events_2019_06"}, nil).Once()
+
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, MaintenanceInput{MonthsAhead: 2})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	var result MaintenanceResult
+	s.NoError(s.env.GetWorkflowResult(&result))
+	s.Equal([]string{"analytics_events_2019_06"}, result.Created)
+	s.env.AssertActivityNotCalled(s.T(), "ListPartitions", mock.Anything)
+}
@@ -0,0 +1,24 @@
+package analytics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	publishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "redduck_analytics_publish_failures_total",
+		Help: "Analytics events that could not be published to NATS, by event type.",
+	}, []string{"event_type"})
+
+	ingestLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "redduck_analytics_ingest_lag_seconds",
+		Help:    "Time between an event being tracked and it reaching the ingest consumer.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 15, 60, 300},
+	})
+
+	insertErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "redduck_analytics_insert_errors_total",
+		Help: "Analytics events the ingest consumer failed to decode or insert.",
+	})
+)
@@ -0,0 +1,183 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Partition is one monthly partition of analytics_events.
+type Partition struct {
+	Name  string
+	Month time.Time // first instant of the month, UTC
+	// Attached is false for partitions detached by an interrupted archival run.
+	Attached bool
+}
+
+var partitionNamePattern = regexp.MustCompile(`^analytics_events_(\d{4})_(\d{2})$`)
+
+// ParsePartitionMonth returns the month a partition name covers.
+func ParsePartitionMonth(name string) (time.Time, bool) {
+	m := partitionNamePattern.FindStringSubmatch(name)
+	if m == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01", m[1]+"-"+m[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// PartitionRepository manages the monthly partitions of analytics_events.
+type PartitionRepository interface {
+	EnsurePartition(ctx context.Context, month time.Time) error
+	ListPartitions(ctx context.Context) ([]Partition, error)
+	// DetachPartition stops new events landing in the partition. Detaching an
+	// already detached partition is a no-op.
+	DetachPartition(ctx context.Context, name string) error
+	// ExportPartition writes every event of the partition to w as JSON lines.
+	ExportPartition(ctx context.Context, name string, w io.Writer) (int64, error)
+	DropPartition(ctx context.Context, name string) error
+	// DrainDefaultPartition moves the events that landed in the default
+	// partition, for want of one for their month, into partitions of their
+	// own, creating them. Months before since are left in place. It returns
+	// the partitions it created.
+	DrainDefaultPartition(ctx context.Context, since time.Time) ([]string, error)
+}
+
+// ensure PostgresRepository implements PartitionRepository
+var _ PartitionRepository = (*PostgresRepository)(nil)
+
+func (r *PostgresRepository) EnsurePartition(ctx context.Context, month time.Time) error {
+	_, err := r.pool.Exec(ctx, `SELECT analytics_events_ensure_partition($1::date)`, month.UTC().Format("2006-01-02"))
+	return err
+}
+
+func (r *PostgresRepository) ListPartitions(ctx context.Context) ([]Partition, error) {
+	query := `
+		SELECT c.relname, EXISTS (
+			SELECT 1 FROM pg_inherits i
+			WHERE i.inhrelid = c.oid AND i.inhparent = 'analytics_events'::regclass
+		)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'r' AND n.nspname = current_schema() AND c.relname ~ '^analytics_events_\d{4}_\d{2}$'
+	`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	partitions := make([]Partition, 0)
+	for rows.Next() {
+		var p Partition
+		if err := rows.Scan(&p.Name, &p.Attached); err != nil {
+			return nil, err
+		}
+		month, ok := ParsePartitionMonth(p.Name)
+		if !ok {
+			continue
+		}
+		p.Month = month
+		partitions = append(partitions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].Month.Before(partitions[j].Month) })
+	return partitions, nil
+}
+
+func (r *PostgresRepository) DetachPartition(ctx context.Context, name string) error {
+	if _, ok := ParsePartitionMonth(name); !ok {
+		return fmt.Errorf("not an analytics partition: %q", name)
+	}
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_inherits
+			WHERE inhrelid = $1::regclass AND inhparent = 'analytics_events'::regclass
+		)
+	`
+	var attached bool
+	if err := r.pool.QueryRow(ctx, query, name).Scan(&attached); err != nil {
+		return err
+	}
+	if !attached {
+		return nil
+	}
+	_, err := r.pool.Exec(ctx, fmt.Sprintf("ALTER TABLE analytics_events DETACH PARTITION %s", pgx.Identifier{name}.Sanitize()))
+	return err
+}
+
+// archivedEvent is the JSON line written for every archived event.
+type archivedEvent struct {
+	ID         string    `json:"id"`
+	IngestedAt time.Time `json:"ingested_at"`
+	EventPayload
+}
+
+func (r *PostgresRepository) ExportPartition(ctx context.Context, name string, w io.Writer) (int64, error) {
+	if _, ok := ParsePartitionMonth(name); !ok {
+		return 0, fmt.Errorf("not an analytics partition: %q", name)
+	}
+	query := fmt.Sprintf(`
+		SELECT id::text, event_type, COALESCE(business_id, ''), COALESCE(user_id, ''), timestamp, properties, ingested_at
+		FROM %s
+		ORDER BY timestamp
+	`, pgx.Identifier{name}.Sanitize())
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	var n int64
+	for rows.Next() {
+		var e archivedEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.BusinessID, &e.UserID, &e.Timestamp, &e.Properties, &e.IngestedAt); err != nil {
+			return n, err
+		}
+		if err := enc.Encode(e); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+func (r *PostgresRepository) DropPartition(ctx context.Context, name string) error {
+	if _, ok := ParsePartitionMonth(name); !ok {
+		return fmt.Errorf("not an analytics partition: %q", name)
+	}
+	_, err := r.pool.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", pgx.Identifier{name}.Sanitize()))
+	return err
+}
+
+func (r *PostgresRepository) DrainDefaultPartition(ctx context.Context, since time.Time) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT analytics_events_drain_default($1::date)`, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	created := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		created = append(created, name)
+	}
+	return created, rows.Err()
+}
@@ -0,0 +1,20 @@
+package analytics
+
+import (
+	"context"
+
+	"red-duck/internal/core/domain"
+)
+
+// SaveQueueReport stores the final report of a queue run. Saving the same run
+// again is a no-op, so the activity writing it can retry.
+func (r *PostgresRepository) SaveQueueReport(ctx context.Context, report domain.QueueReport) error {
+	query := `
+		INSERT INTO queue_reports (run_id, business_id, queue_id, reason, closed_at, served, abandoned, cancelled, avg_wait_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (run_id) DO NOTHING
+	`
+	_, err := r.pool.Exec(ctx, query, report.RunID, report.BusinessID, report.QueueID, report.Reason, report.ClosedAt,
+		report.Served, report.Abandoned, report.Cancelled, report.AvgWaitSeconds)
+	return err
+}
@@ -0,0 +1,198 @@
+package analytics
+
+import (
+	"context"
+	"time"
+)
+
+// counterIdleGap is the longest gap between two calls of a counter that still
+// counts as serving the first guest. Longer gaps are the counter standing idle
+// and are left out of the average service time.
+const counterIdleGap = 30 * time.Minute
+
+// ReportQuery scopes a report to a business, an optional queue and a time range.
+// From is inclusive and To is exclusive.
+type ReportQuery struct {
+	BusinessID string
+	QueueID    string // empty means every queue of the business
+	From       time.Time
+	To         time.Time
+}
+
+type DailyThroughput struct {
+	Day          time.Time `json:"day"`
+	Joins        int       `json:"joins"`
+	Abandonments int       `json:"abandonments"`
+	Calls        int       `json:"calls"`
+}
+
+type WaitStats struct {
+	Samples        int     `json:"samples"`
+	AvgWaitSeconds float64 `json:"avg_wait_seconds"`
+	P90WaitSeconds float64 `json:"p90_wait_seconds"`
+}
+
+type HourlyLoad struct {
+	Hour  int `json:"hour"` // 0-23, UTC
+	Joins int `json:"joins"`
+}
+
+type CounterThroughput struct {
+	CounterID string `json:"counter_id"`
+	Calls     int    `json:"calls"`
+	// AvgServiceSeconds approximates service time as the gap between
+	// consecutive calls at the same counter of the same queue, leaving out
+	// gaps over counterIdleGap.
+	AvgServiceSeconds float64 `json:"avg_service_seconds"`
+}
+
+// ReportRepository reads aggregated reports back out of analytics_events and its rollups.
+type ReportRepository interface {
+	DailyThroughput(ctx context.Context, q ReportQuery) ([]DailyThroughput, error)
+	WaitTimes(ctx context.Context, q ReportQuery) (WaitStats, error)
+	BusiestHours(ctx context.Context, q ReportQuery) ([]HourlyLoad, error)
+	CounterThroughput(ctx context.Context, q ReportQuery) ([]CounterThroughput, error)
+}
+
+// ensure PostgresRepository implements ReportRepository
+var _ ReportRepository = (*PostgresRepository)(nil)
+
+// Every report query takes the same four leading arguments:
+// $1 business_id, $2 queue_id (empty for all), $3 from, $4 to.
+// Counts come from the rollup tables, while wait percentiles and service
+// times still need the raw events.
+const (
+	reportScope = `
+	business_id = $1
+	AND ($2 = '' OR properties->>'queue_id' = $2)
+	AND timestamp >= $3 AND timestamp < $4
+`
+	rollupScope = `
+	business_id = $1
+	AND ($2 = '' OR queue_id = $2)
+	AND bucket_start >= $3 AND bucket_start < $4
+`
+)
+
+// callWait joins each event e to w.wait_seconds, the wait of the guest a
+// queue.called event called: from their latest join of the same queue up to
+// the call. A guest who left and joined again waited since the rejoin. The
+// wait report and the rollups both measure waits with it.
+const callWait = `
+		LEFT JOIN LATERAL (
+			SELECT EXTRACT(EPOCH FROM e.timestamp - MAX(j.timestamp)) AS wait_seconds
+			FROM analytics_events j
+			WHERE e.event_type = 'queue.called'
+				AND j.event_type = 'queue.joined'
+				AND j.business_id = e.business_id
+				AND j.user_id = e.user_id
+				AND j.properties->>'queue_id' = e.properties->>'queue_id'
+				AND j.timestamp <= e.timestamp
+		) w ON TRUE`
+
+func (q ReportQuery) args() []interface{} {
+	return []interface{}{q.BusinessID, q.QueueID, q.From, q.To}
+}
+
+func (r *PostgresRepository) DailyThroughput(ctx context.Context, q ReportQuery) ([]DailyThroughput, error) {
+	query := `
+		SELECT bucket_start, SUM(joins), SUM(abandonments), SUM(calls)
+		FROM analytics_rollups_daily
+		WHERE` + rollupScope + `
+		GROUP BY bucket_start
+		ORDER BY bucket_start
+	`
+	rows, err := r.pool.Query(ctx, query, q.args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]DailyThroughput, 0)
+	for rows.Next() {
+		var d DailyThroughput
+		if err := rows.Scan(&d.Day, &d.Joins, &d.Abandonments, &d.Calls); err != nil {
+			return nil, err
+		}
+		d.Day = d.Day.UTC()
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
+
+func (r *PostgresRepository) WaitTimes(ctx context.Context, q ReportQuery) (WaitStats, error) {
+	// Each call in the range is a wait, as the rollups count it.
+	query := `
+		WITH waits AS (
+			SELECT w.wait_seconds
+			FROM analytics_events e` + callWait + `
+			WHERE e.event_type = 'queue.called' AND w.wait_seconds IS NOT NULL AND` + reportScope + `
+		)
+		SELECT COUNT(*),
+			COALESCE(AVG(wait_seconds), 0),
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY wait_seconds), 0)
+		FROM waits
+	`
+	var s WaitStats
+	err := r.pool.QueryRow(ctx, query, q.args()...).Scan(&s.Samples, &s.AvgWaitSeconds, &s.P90WaitSeconds)
+	return s, err
+}
+
+func (r *PostgresRepository) BusiestHours(ctx context.Context, q ReportQuery) ([]HourlyLoad, error) {
+	query := `
+		SELECT EXTRACT(HOUR FROM bucket_start AT TIME ZONE 'UTC')::int AS hour, SUM(joins) AS joins
+		FROM analytics_rollups_hourly
+		WHERE` + rollupScope + `
+		GROUP BY hour
+		HAVING SUM(joins) > 0
+		ORDER BY joins DESC, hour
+	`
+	rows, err := r.pool.Query(ctx, query, q.args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]HourlyLoad, 0)
+	for rows.Next() {
+		var h HourlyLoad
+		if err := rows.Scan(&h.Hour, &h.Joins); err != nil {
+			return nil, err
+		}
+		result = append(result, h)
+	}
+	return result, rows.Err()
+}
+
+func (r *PostgresRepository) CounterThroughput(ctx context.Context, q ReportQuery) ([]CounterThroughput, error) {
+	query := `
+		WITH calls AS (
+			SELECT properties->>'counter_id' AS counter_id,
+				EXTRACT(EPOCH FROM LEAD(timestamp) OVER (
+					PARTITION BY properties->>'queue_id', properties->>'counter_id' ORDER BY timestamp
+				) - timestamp) AS gap_seconds
+			FROM analytics_events
+			WHERE event_type = 'queue.called' AND` + reportScope + `
+		)
+		SELECT COALESCE(counter_id, ''), COUNT(*),
+			COALESCE(AVG(gap_seconds) FILTER (WHERE gap_seconds <= $5), 0)
+		FROM calls
+		GROUP BY counter_id
+		ORDER BY COUNT(*) DESC, counter_id
+	`
+	rows, err := r.pool.Query(ctx, query, append(q.args(), counterIdleGap.Seconds())...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]CounterThroughput, 0)
+	for rows.Next() {
+		var c CounterThroughput
+		if err := rows.Scan(&c.CounterID, &c.Calls, &c.AvgServiceSeconds); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepository migrates the database at TEST_DATABASE_URL and returns a
+// repository on it, skipping the test without one.
+func newTestRepository(t *testing.T) *PostgresRepository {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	m, err := migrate.New("file://../db/migrations", url)
+	require.NoError(t, err)
+	defer m.Close()
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		require.NoError(t, err)
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return NewPostgresRepository(pool)
+}
+
+func TestWaitTimes_RejoinMatchesRollups(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	// The guest leaves after five minutes, rejoins and is called ten
+	// minutes after rejoining
+	businessID := "biz-" + uuid.NewString()
+	hour := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	events := []struct {
+		typ string
+		at  time.Duration
+	}{
+		{"queue.joined", 0},
+		{"queue.left", 5 * time.Minute},
+		{"queue.joined", 10 * time.Minute},
+		{"queue.called", 20 * time.Minute},
+	}
+	for _, e := range events {
+		require.NoError(t, repo.InsertEvent(ctx, EventPayload{
+			Type:       e.typ,
+			BusinessID: businessID,
+			UserID:     "guest-1",
+			Timestamp:  hour.Add(e.at),
+			Properties: map[string]interface{}{"queue_id": "main", "counter_id": "Counter 1"},
+		}))
+	}
+
+	waits, err := repo.WaitTimes(ctx, ReportQuery{BusinessID: businessID, From: hour, To: hour.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, waits.Samples)
+	assert.Equal(t, 600.0, waits.AvgWaitSeconds)
+
+	require.NoError(t, repo.RecomputeHours(ctx, []time.Time{hour}))
+	var samples int
+	var seconds float64
+	err = repo.pool.QueryRow(ctx, `
+		SELECT SUM(wait_samples), SUM(wait_seconds_sum)
+		FROM analytics_rollups_hourly
+		WHERE business_id = $1 AND bucket_start = $2
+	`, businessID, hour).Scan(&samples, &seconds)
+	require.NoError(t, err)
+	assert.Equal(t, waits.Samples, samples)
+	assert.Equal(t, waits.AvgWaitSeconds, seconds/float64(samples))
+}
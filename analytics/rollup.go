@@ -0,0 +1,169 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// rollupStateName is the row in analytics_rollup_state holding the incremental watermark.
+const rollupStateName = "rollups"
+
+// RollupRepository maintains the hourly and daily rollup tables.
+type RollupRepository interface {
+	// Watermark returns the ingested_at up to which rollups are known to be current.
+	Watermark(ctx context.Context) (time.Time, error)
+	// SetWatermark records that every event ingested up to t is reflected in the rollups.
+	SetWatermark(ctx context.Context, t time.Time) error
+	// FirstIngested returns the ingested_at of the earliest event, or the zero
+	// time when there are none.
+	FirstIngested(ctx context.Context) (time.Time, error)
+	// Now returns the database clock, so watermarks never depend on worker clock skew.
+	Now(ctx context.Context) (time.Time, error)
+	// DirtyHours returns the hourly buckets touched by events ingested in (since, until].
+	DirtyHours(ctx context.Context, since, until time.Time) ([]time.Time, error)
+	// RecomputeHours rebuilds the given hourly buckets from analytics_events and
+	// the daily buckets that contain them from the hourly rollups.
+	RecomputeHours(ctx context.Context, hours []time.Time) error
+}
+
+// ensure PostgresRepository implements RollupRepository
+var _ RollupRepository = (*PostgresRepository)(nil)
+
+func (r *PostgresRepository) Watermark(ctx context.Context) (time.Time, error) {
+	var t time.Time
+	err := r.pool.QueryRow(ctx, `SELECT watermark FROM analytics_rollup_state WHERE name = $1`, rollupStateName).Scan(&t)
+	if err == pgx.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return t, err
+}
+
+func (r *PostgresRepository) SetWatermark(ctx context.Context, t time.Time) error {
+	query := `
+		INSERT INTO analytics_rollup_state (name, watermark)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET watermark = GREATEST(analytics_rollup_state.watermark, EXCLUDED.watermark)
+	`
+	_, err := r.pool.Exec(ctx, query, rollupStateName, t)
+	return err
+}
+
+func (r *PostgresRepository) FirstIngested(ctx context.Context) (time.Time, error) {
+	var t *time.Time
+	if err := r.pool.QueryRow(ctx, `SELECT MIN(ingested_at) FROM analytics_events`).Scan(&t); err != nil || t == nil {
+		return time.Time{}, err
+	}
+	return *t, nil
+}
+
+func (r *PostgresRepository) Now(ctx context.Context) (time.Time, error) {
+	var t time.Time
+	err := r.pool.QueryRow(ctx, `SELECT NOW()`).Scan(&t)
+	return t, err
+}
+
+func (r *PostgresRepository) DirtyHours(ctx context.Context, since, until time.Time) ([]time.Time, error) {
+	// Besides the hour of each new event, a late join also changes the wait
+	// time recorded against the later call of the same user.
+	query := `
+		SELECT date_trunc('hour', timestamp, 'UTC') AS bucket
+		FROM analytics_events
+		WHERE ingested_at > $1 AND ingested_at <= $2
+			AND event_type IN ('queue.joined', 'queue.left', 'queue.ticket_expired', 'queue.called')
+		UNION
+		SELECT date_trunc('hour', c.timestamp, 'UTC')
+		FROM analytics_events j
+		JOIN analytics_events c ON c.business_id = j.business_id
+			AND c.user_id = j.user_id
+			AND c.event_type = 'queue.called'
+			AND c.properties->>'queue_id' = j.properties->>'queue_id'
+			AND c.timestamp >= j.timestamp
+		WHERE j.ingested_at > $1 AND j.ingested_at <= $2 AND j.event_type = 'queue.joined'
+		ORDER BY bucket
+	`
+	rows, err := r.pool.Query(ctx, query, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := make([]time.Time, 0)
+	for rows.Next() {
+		var h time.Time
+		if err := rows.Scan(&h); err != nil {
+			return nil, err
+		}
+		hours = append(hours, h.UTC())
+	}
+	return hours, rows.Err()
+}
+
+func (r *PostgresRepository) RecomputeHours(ctx context.Context, hours []time.Time) error {
+	if len(hours) == 0 {
+		return nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM analytics_rollups_hourly WHERE bucket_start = ANY($1)`, hours); err != nil {
+		return err
+	}
+
+	// Waits are attributed to the hour and counter of the call that ended them.
+	// Expired tickets were abandoned as much as those left.
+	insertHourly := `
+		INSERT INTO analytics_rollups_hourly
+			(bucket_start, business_id, queue_id, counter_id, joins, abandonments, calls, wait_samples, wait_seconds_sum)
+		SELECT h.bucket,
+			COALESCE(e.business_id, ''),
+			COALESCE(e.properties->>'queue_id', ''),
+			CASE WHEN e.event_type = 'queue.called' THEN COALESCE(e.properties->>'counter_id', '') ELSE '' END AS counter_id,
+			COUNT(*) FILTER (WHERE e.event_type = 'queue.joined'),
+			COUNT(*) FILTER (WHERE e.event_type IN ('queue.left', 'queue.ticket_expired')),
+			COUNT(*) FILTER (WHERE e.event_type = 'queue.called'),
+			COUNT(w.wait_seconds),
+			COALESCE(SUM(w.wait_seconds), 0)
+		FROM unnest($1::timestamptz[]) AS h(bucket)
+		JOIN analytics_events e ON e.timestamp >= h.bucket AND e.timestamp < h.bucket + INTERVAL '1 hour'` + callWait + `
+		WHERE e.event_type IN ('queue.joined', 'queue.left', 'queue.ticket_expired', 'queue.called')
+		GROUP BY 1, 2, 3, 4
+	`
+	if _, err := tx.Exec(ctx, insertHourly, hours); err != nil {
+		return err
+	}
+
+	days := make([]time.Time, 0, len(hours))
+	seen := make(map[time.Time]bool)
+	for _, h := range hours {
+		d := h.UTC().Truncate(24 * time.Hour)
+		if !seen[d] {
+			seen[d] = true
+			days = append(days, d)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM analytics_rollups_daily WHERE bucket_start = ANY($1)`, days); err != nil {
+		return err
+	}
+
+	insertDaily := `
+		INSERT INTO analytics_rollups_daily
+			(bucket_start, business_id, queue_id, counter_id, joins, abandonments, calls, wait_samples, wait_seconds_sum)
+		SELECT d.bucket, r.business_id, r.queue_id, r.counter_id,
+			SUM(r.joins), SUM(r.abandonments), SUM(r.calls), SUM(r.wait_samples), SUM(r.wait_seconds_sum)
+		FROM unnest($1::timestamptz[]) AS d(bucket)
+		JOIN analytics_rollups_hourly r ON r.bucket_start >= d.bucket AND r.bucket_start < d.bucket + INTERVAL '1 day'
+		GROUP BY 1, 2, 3, 4
+	`
+	if _, err := tx.Exec(ctx, insertDaily, days); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
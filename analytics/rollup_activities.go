@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// rollupSafetyLag keeps the watermark behind transactions that picked their
+// ingested_at default but have not committed yet.
+const rollupSafetyLag = time.Minute
+
+// rollupWindow is the most ingest time one plan covers, so a first run or one
+// after an outage doesn't gather all of history into a single activity result.
+const rollupWindow = rollupBatchSize * time.Hour
+
+type RollupActivities struct {
+	Repo RollupRepository
+}
+
+// RollupPlan is the set of hourly buckets to recompute for one incremental run.
+type RollupPlan struct {
+	Since time.Time
+	Until time.Time
+	Hours []time.Time
+	// More is set when the plan stopped rollupWindow after Since, short of
+	// now; the next plan carries on from Until.
+	More bool
+}
+
+// PlanRollup finds the buckets touched by events ingested since the
+// watermark, or since the first event when there is none yet, over at most
+// rollupWindow.
+func (a *RollupActivities) PlanRollup(ctx context.Context) (RollupPlan, error) {
+	since, err := a.Repo.Watermark(ctx)
+	if err != nil {
+		return RollupPlan{}, err
+	}
+	if since.IsZero() {
+		first, err := a.Repo.FirstIngested(ctx)
+		if err != nil || first.IsZero() {
+			return RollupPlan{}, err
+		}
+		// Just before it, since the plan covers (since, until]
+		since = first.Add(-time.Microsecond)
+	}
+	now, err := a.Repo.Now(ctx)
+	if err != nil {
+		return RollupPlan{}, err
+	}
+
+	until := now.Add(-rollupSafetyLag)
+	if !until.After(since) {
+		return RollupPlan{Since: since, Until: since}, nil
+	}
+	more := until.Sub(since) > rollupWindow
+	if more {
+		until = since.Add(rollupWindow)
+	}
+
+	hours, err := a.Repo.DirtyHours(ctx, since, until)
+	if err != nil {
+		return RollupPlan{}, err
+	}
+
+	activity.GetLogger(ctx).Info("Planned rollup", "Since", since, "Until", until, "Buckets", len(hours), "More", more)
+	return RollupPlan{Since: since, Until: until, Hours: hours, More: more}, nil
+}
+
+// RecomputeHours rebuilds a batch of hourly buckets and their daily buckets.
+func (a *RollupActivities) RecomputeHours(ctx context.Context, hours []time.Time) error {
+	return a.Repo.RecomputeHours(ctx, hours)
+}
+
+// AdvanceWatermark records that the rollups are current up to until.
+func (a *RollupActivities) AdvanceWatermark(ctx context.Context, until time.Time) error {
+	return a.Repo.SetWatermark(ctx, until)
+}
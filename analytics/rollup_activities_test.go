@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type MockRollupRepository struct {
+	mock.Mock
+}
+
+func (m *MockRollupRepository) Watermark(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockRollupRepository) SetWatermark(ctx context.Context, t time.Time) error {
+	return m.Called(ctx, t).Error(0)
+}
+
+func (m *MockRollupRepository) FirstIngested(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockRollupRepository) Now(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockRollupRepository) DirtyHours(ctx context.Context, since, until time.Time) ([]time.Time, error) {
+	args := m.Called(ctx, since, until)
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockRollupRepository) RecomputeHours(ctx context.Context, hours []time.Time) error {
+	return m.Called(ctx, hours).Error(0)
+}
+
+func TestPlanRollup_FirstRunStartsAtEarliestEventAndIsCapped(t *testing.T) {
+	repo := new(MockRollupRepository)
+	first := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	since := first.Add(-time.Microsecond)
+	repo.On("Watermark", mock.Anything).Return(time.Time{}, nil)
+	repo.On("FirstIngested", mock.Anything).Return(first, nil)
+	repo.On("Now", mock.Anything).Return(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), nil)
+	repo.On("DirtyHours", mock.Anything, since, since.Add(rollupWindow)).Return([]time.Time{first}, nil)
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestActivityEnvironment()
+	env.RegisterActivity(&RollupActivities{Repo: repo})
+
+	val, err := env.ExecuteActivity((&RollupActivities{}).PlanRollup)
+	require.NoError(t, err)
+	var plan RollupPlan
+	require.NoError(t, val.Get(&plan))
+	assert.True(t, plan.Since.Equal(since))
+	assert.True(t, plan.Until.Equal(since.Add(rollupWindow)))
+	assert.True(t, plan.More)
+	repo.AssertExpectations(t)
+}
+
+func TestPlanRollup_NoEventsYet(t *testing.T) {
+	repo := new(MockRollupRepository)
+	repo.On("Watermark", mock.Anything).Return(time.Time{}, nil)
+	repo.On("FirstIngested", mock.Anything).Return(time.Time{}, nil)
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestActivityEnvironment()
+	env.RegisterActivity(&RollupActivities{Repo: repo})
+
+	val, err := env.ExecuteActivity((&RollupActivities{}).PlanRollup)
+	require.NoError(t, err)
+	var plan RollupPlan
+	require.NoError(t, val.Get(&plan))
+	assert.Empty(t, plan.Hours)
+	assert.False(t, plan.Until.After(plan.Since))
+	repo.AssertNotCalled(t, "DirtyHours", mock.Anything, mock.Anything, mock.Anything)
+}
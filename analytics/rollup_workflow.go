@@ -0,0 +1,96 @@
+package analytics
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// RollupScheduleID identifies both the schedule and the workflows it starts.
+	RollupScheduleID = "analytics-rollup"
+
+	// rollupBatchSize is the number of hourly buckets recomputed per activity.
+	rollupBatchSize = 24
+
+	// rollupPlansPerRun is how many capped plans a run works through before
+	// continuing as a new run, which keeps a catch-up's history small.
+	rollupPlansPerRun = 50
+)
+
+// RollupInput selects between an incremental run (the default) and a backfill.
+type RollupInput struct {
+	// Backfill recomputes every hour in [From, To) and leaves the watermark alone.
+	Backfill bool
+	From     time.Time
+	To       time.Time
+}
+
+// RollupWorkflow brings the hourly and daily rollup tables up to date and
+// returns the number of hourly buckets it recomputed. An incremental run far
+// behind catches up one plan of at most rollupWindow at a time.
+func RollupWorkflow(ctx workflow.Context, input RollupInput) (int, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *RollupActivities
+	recompute := func(hours []time.Time) error {
+		for start := 0; start < len(hours); start += rollupBatchSize {
+			end := min(start+rollupBatchSize, len(hours))
+			if err := workflow.ExecuteActivity(ctx, a.RecomputeHours, hours[start:end]).Get(ctx, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if input.Backfill {
+		if !input.To.After(input.From) {
+			return 0, errors.New("backfill range is empty")
+		}
+		var hours []time.Time
+		for h := input.From.UTC().Truncate(time.Hour); h.Before(input.To); h = h.Add(time.Hour) {
+			hours = append(hours, h)
+		}
+		if err := recompute(hours); err != nil {
+			return 0, err
+		}
+		logger.Info("RollupWorkflow completed", "Backfill", true, "Buckets", len(hours))
+		return len(hours), nil
+	}
+
+	buckets := 0
+	for plans := 1; ; plans++ {
+		var plan RollupPlan
+		if err := workflow.ExecuteActivity(ctx, a.PlanRollup).Get(ctx, &plan); err != nil {
+			return buckets, err
+		}
+		if err := recompute(plan.Hours); err != nil {
+			return buckets, err
+		}
+
+		// Only advance once every dirty bucket is rebuilt, so a failed run is simply retried next time.
+		if plan.Until.After(plan.Since) {
+			if err := workflow.ExecuteActivity(ctx, a.AdvanceWatermark, plan.Until).Get(ctx, nil); err != nil {
+				return buckets, err
+			}
+		}
+		buckets += len(plan.Hours)
+
+		if !plan.More {
+			break
+		}
+		if plans == rollupPlansPerRun {
+			logger.Info("RollupWorkflow continuing as new", "Buckets", buckets)
+			return buckets, workflow.NewContinueAsNewError(ctx, RollupWorkflow, input)
+		}
+	}
+
+	logger.Info("RollupWorkflow completed", "Backfill", false, "Buckets", buckets)
+	return buckets, nil
+}
@@ -0,0 +1,135 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type RollupWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestRollupWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(RollupWorkflowTestSuite))
+}
+
+func (s *RollupWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&RollupActivities{})
+}
+
+func (s *RollupWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *RollupWorkflowTestSuite) TestIncremental_RecomputesDirtyHoursThenAdvancesWatermark() {
+	var a *RollupActivities
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	until := since.Add(15 * time.Minute)
+	late := time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC) // late event from two days ago
+	plan := RollupPlan{Since: since, Until: until, Hours: []time.Time{late, since}}
+
+	s.env.OnActivity(a.PlanRollup, mock.Anything).Return(plan, nil)
+	s.env.OnActivity(a.RecomputeHours, mock.Anything, plan.Hours).Return(nil).Once()
+	s.env.OnActivity(a.AdvanceWatermark, mock.Anything, until).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(RollupWorkflow, RollupInput{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	var buckets int
+	s.NoError(s.env.GetWorkflowResult(&buckets))
+	s.Equal(2, buckets)
+}
+
+func (s *RollupWorkflowTestSuite) TestIncremental_NothingToDo() {
+	var a *RollupActivities
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	s.env.OnActivity(a.PlanRollup, mock.Anything).Return(RollupPlan{Since: since, Until: since}, nil)
+
+	s.env.ExecuteWorkflow(RollupWorkflow, RollupInput{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.env.AssertActivityNotCalled(s.T(), "RecomputeHours", mock.Anything, mock.Anything)
+	s.env.AssertActivityNotCalled(s.T(), "AdvanceWatermark", mock.Anything, mock.Anything)
+}
+
+func (s *RollupWorkflowTestSuite) TestIncremental_CatchesUpOnePlanAtATime() {
+	var a *RollupActivities
+	first := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(rollupWindow)
+	plans := []RollupPlan{
+		{Since: first, Until: second, Hours: []time.Time{first}, More: true},
+		{Since: second, Until: second.Add(time.Hour), Hours: []time.Time{second}},
+	}
+
+	s.env.OnActivity(a.PlanRollup, mock.Anything).Return(plans[0], nil).Once()
+	s.env.OnActivity(a.PlanRollup, mock.Anything).Return(plans[1], nil).Once()
+	s.env.OnActivity(a.RecomputeHours, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.env.OnActivity(a.AdvanceWatermark, mock.Anything, plans[0].Until).Return(nil).Once()
+	s.env.OnActivity(a.AdvanceWatermark, mock.Anything, plans[1].Until).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(RollupWorkflow, RollupInput{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	var buckets int
+	s.NoError(s.env.GetWorkflowResult(&buckets))
+	s.Equal(2, buckets)
+}
+
+func (s *RollupWorkflowTestSuite) TestIncremental_ContinuesAsNewWhenFarBehind() {
+	var a *RollupActivities
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	plan := RollupPlan{Since: since, Until: since.Add(rollupWindow), More: true}
+
+	s.env.OnActivity(a.PlanRollup, mock.Anything).Return(plan, nil).Times(rollupPlansPerRun)
+	s.env.OnActivity(a.AdvanceWatermark, mock.Anything, plan.Until).Return(nil).Times(rollupPlansPerRun)
+
+	s.env.ExecuteWorkflow(RollupWorkflow, RollupInput{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	var continued *workflow.ContinueAsNewError
+	s.ErrorAs(s.env.GetWorkflowError(), &continued)
+}
+
+func (s *RollupWorkflowTestSuite) TestBackfill_BatchesRangeAndKeepsWatermark() {
+	var a *RollupActivities
+	from := time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	var batches [][]time.Time
+	s.env.OnActivity(a.RecomputeHours, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		batches = append(batches, args.Get(1).([]time.Time))
+	})
+
+	s.env.ExecuteWorkflow(RollupWorkflow, RollupInput{Backfill: true, From: from, To: to})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.Require().Len(batches, 2)
+	s.Len(batches[0], rollupBatchSize)
+	s.Len(batches[1], rollupBatchSize)
+	s.True(batches[0][0].Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	s.env.AssertActivityNotCalled(s.T(), "PlanRollup", mock.Anything)
+	s.env.AssertActivityNotCalled(s.T(), "AdvanceWatermark", mock.Anything, mock.Anything)
+}
+
+func (s *RollupWorkflowTestSuite) TestBackfill_RejectsEmptyRange() {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s.env.ExecuteWorkflow(RollupWorkflow, RollupInput{Backfill: true, From: at, To: at})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
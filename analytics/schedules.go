@@ -0,0 +1,41 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
+
+// EnsureRollupSchedule registers the schedule that runs RollupWorkflow incrementally.
+// An existing schedule is left untouched.
+func EnsureRollupSchedule(ctx context.Context, c client.Client, taskQueue string, every time.Duration) error {
+	return ensureSchedule(ctx, c, RollupScheduleID, taskQueue, every, RollupWorkflow, RollupInput{})
+}
+
+// EnsureMaintenanceSchedule registers the schedule that runs PartitionMaintenanceWorkflow.
+// An existing schedule is left untouched.
+func EnsureMaintenanceSchedule(ctx context.Context, c client.Client, taskQueue string, every time.Duration, input MaintenanceInput) error {
+	return ensureSchedule(ctx, c, MaintenanceScheduleID, taskQueue, every, PartitionMaintenanceWorkflow, input)
+}
+
+func ensureSchedule(ctx context.Context, c client.Client, id, taskQueue string, every time.Duration, wf interface{}, input interface{}) error {
+	_, err := c.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID: id,
+		Spec: client.ScheduleSpec{
+			Intervals: []client.ScheduleIntervalSpec{{Every: every}},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			ID:        id,
+			Workflow:  wf,
+			Args:      []interface{}{input},
+			TaskQueue: taskQueue,
+		},
+	})
+	if errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		return nil
+	}
+	return err
+}
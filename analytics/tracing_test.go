@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContext_RoundTripsThroughNatsHeaders(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "join")
+	defer span.End()
+
+	msg := &nats.Msg{Subject: "events.queue.joined"}
+	injectTraceContext(ctx, msg)
+	assert.NotEmpty(t, propagation.HeaderCarrier(msg.Header).Get("traceparent"))
+
+	got := trace.SpanContextFromContext(extractTraceContext(context.Background(), msg))
+	assert.Equal(t, span.SpanContext().TraceID(), got.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), got.SpanID())
+	assert.True(t, got.IsRemote())
+}
+
+func TestExtractTraceContext_WithoutHeaders(t *testing.T) {
+	ctx := extractTraceContext(context.Background(), &nats.Msg{Subject: "events.queue.joined"})
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
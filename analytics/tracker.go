@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"red-duck/internal/pkg/requestid"
 )
 
 type EventTracker interface {
-	Track(eventType, businessID, userID string, props map[string]interface{}) error
+	Track(ctx context.Context, eventType, businessID, userID string, props map[string]interface{}) error
 }
 
 type Tracker struct {
@@ -24,7 +29,8 @@ func NewTracker(nc *nats.Conn) *Tracker {
 	return &Tracker{nc: nc}
 }
 
-func (t *Tracker) Track(eventType, businessID, userID string, props map[string]interface{}) error {
+// Track publishes an event. The trace in ctx, if any, continues in the ingest consumer.
+func (t *Tracker) Track(ctx context.Context, eventType, businessID, userID string, props map[string]interface{}) error {
 	payload := EventPayload{
 		Type:       eventType,
 		BusinessID: businessID,
@@ -38,8 +44,20 @@ func (t *Tracker) Track(eventType, businessID, userID string, props map[string]i
 		return err
 	}
 
-	if err := t.nc.Publish(fmt.Sprintf("events.%s", eventType), data); err != nil {
-		fmt.Printf("Error publishing event to NATS: %v\n", err)
+	subject := fmt.Sprintf("events.%s", eventType)
+	ctx, span := startMessagingSpan(ctx, "publish", subject, trace.SpanKindProducer)
+	defer span.End()
+
+	msg := &nats.Msg{Subject: subject, Data: data}
+	injectTraceContext(ctx, msg)
+	if id := requestid.FromContext(ctx); id != "" {
+		msg.Header.Set(requestid.Header, id)
+	}
+	if err := t.nc.PublishMsg(msg); err != nil {
+		publishFailures.WithLabelValues(eventType).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "publish failed")
+		slog.ErrorContext(ctx, "Failed to publish event to NATS", "event_type", eventType, "error", err)
 		return nil // Non-blocking: swallow error
 	}
 	return nil
@@ -87,5 +105,5 @@ func Track(ctx context.Context, eventType string, props map[string]interface{})
 	// Since we used `auth.UserKey` (int), we rely on `auth` being imported.
 	// Let's TRY to import `red-duck/auth`. If it fails due to cycle later, we solve it then.
 
-	return GlobalTracker.Track(eventType, "", userID, props)
+	return GlobalTracker.Track(ctx, eventType, "", userID, props)
 }
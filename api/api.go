@@ -0,0 +1,15 @@
+// Package api holds the definitions of the public APIs: the OpenAPI document
+// of the /v1 HTTP API, from which the request validation middleware is driven
+// and the Go client in api/client is generated, and the protobuf definitions
+// of the gRPC API in api/proto.
+package api
+
+import _ "embed"
+
+// Spec is the OpenAPI 3 document, as served at /v1/openapi.yaml.
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config client/oapi-codegen.yaml openapi.yaml
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.50.0 generate
@@ -0,0 +1,5120 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version (devel) DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	StaffAuthScopes  = "staffAuth.Scopes"
+	TicketAuthScopes = "ticketAuth.Scopes"
+)
+
+// Defines values for AppointmentStatus.
+const (
+	AppointmentStatusBOOKED    AppointmentStatus = "BOOKED"
+	AppointmentStatusCANCELLED AppointmentStatus = "CANCELLED"
+	AppointmentStatusCHECKEDIN AppointmentStatus = "CHECKED_IN"
+	AppointmentStatusNOSHOW    AppointmentStatus = "NO_SHOW"
+)
+
+// Defines values for AuditEntryAction.
+const (
+	CallNext      AuditEntryAction = "call_next"
+	CloseQueue    AuditEntryAction = "close_queue"
+	InsertTicket  AuditEntryAction = "insert_ticket"
+	MoveTicket    AuditEntryAction = "move_ticket"
+	OpenQueue     AuditEntryAction = "open_queue"
+	RemoveTicket  AuditEntryAction = "remove_ticket"
+	ShutdownQueue AuditEntryAction = "shutdown_queue"
+)
+
+// Defines values for ExportJobRequestFormat.
+const (
+	Csv    ExportJobRequestFormat = "csv"
+	Ndjson ExportJobRequestFormat = "ndjson"
+)
+
+// Defines values for ExportJobStatusStatus.
+const (
+	Completed ExportJobStatusStatus = "completed"
+	Failed    ExportJobStatusStatus = "failed"
+	Running   ExportJobStatusStatus = "running"
+)
+
+// Defines values for QueueState.
+const (
+	CLOSED QueueState = "CLOSED"
+	OPEN   QueueState = "OPEN"
+)
+
+// Defines values for TicketStatus.
+const (
+	TicketStatusCANCELLED TicketStatus = "CANCELLED"
+	TicketStatusCOMPLETED TicketStatus = "COMPLETED"
+	TicketStatusREADY     TicketStatus = "READY"
+	TicketStatusWAITING   TicketStatus = "WAITING"
+)
+
+// Appointment defines model for Appointment.
+type Appointment struct {
+	AppointmentId string     `json:"appointment_id"`
+	BusinessId    string     `json:"business_id"`
+	CheckInFrom   time.Time  `json:"check_in_from"`
+	CheckedInAt   *time.Time `json:"checked_in_at,omitempty"`
+	NoShowAt      time.Time  `json:"no_show_at"`
+
+	// Position The guest's place in the queue when they checked in.
+	Position  *int      `json:"position,omitempty"`
+	QueueId   string    `json:"queue_id"`
+	Seat      int       `json:"seat"`
+	SlotStart time.Time `json:"slot_start"`
+
+	// Status Appointments not checked in by `no_show_at` are NO_SHOW and lose their seat.
+	Status AppointmentStatus `json:"status"`
+
+	// Token The guest's ticket, on booking and check-in. A booking replaying an earlier one's Idempotency-Key gets a fresh one.
+	Token  *string `json:"token,omitempty"`
+	UserId string  `json:"user_id"`
+}
+
+// AppointmentStatus Appointments not checked in by `no_show_at` are NO_SHOW and lose their seat.
+type AppointmentStatus string
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action AuditEntryAction `json:"action"`
+
+	// ActorId The staff member, from their token.
+	ActorId   string `json:"actor_id"`
+	ActorRole string `json:"actor_role"`
+
+	// After The ticket, or queue state, after the change; null when there is none.
+	After interface{} `json:"after"`
+	At    time.Time   `json:"at"`
+
+	// Before The ticket, or queue state, before the change; null when there was none.
+	Before     interface{} `json:"before"`
+	BusinessId string      `json:"business_id"`
+
+	// Hash Hex SHA-256 of the entry's content and prev_hash.
+	Hash string `json:"hash"`
+	Id   int64  `json:"id"`
+
+	// PrevHash Hash of the business's previous entry, empty for the first.
+	PrevHash  string  `json:"prev_hash"`
+	QueueId   string  `json:"queue_id"`
+	RequestId *string `json:"request_id,omitempty"`
+
+	// TargetUserId The guest whose ticket changed.
+	TargetUserId *string `json:"target_user_id,omitempty"`
+}
+
+// AuditEntryAction defines model for AuditEntry.Action.
+type AuditEntryAction string
+
+// AuditLog defines model for AuditLog.
+type AuditLog struct {
+	BusinessId string `json:"business_id"`
+
+	// Chained Whether the entries' links to each other were checked, which filters prevent.
+	Chained bool         `json:"chained"`
+	Entries []AuditEntry `json:"entries"`
+
+	// NextAfter Cursor for the next page; absent after the last.
+	NextAfter *int64 `json:"next_after,omitempty"`
+
+	// Verified Whether every entry hashes to its hash.
+	Verified bool `json:"verified"`
+}
+
+// BookAppointmentRequest defines model for BookAppointmentRequest.
+type BookAppointmentRequest struct {
+	SlotStart time.Time `json:"slot_start"`
+}
+
+// CallNextRequest defines model for CallNextRequest.
+type CallNextRequest struct {
+	// Capacity How many guests the counter or table takes. The first waiting party
+	// that fits is called; a party passed over `queues.maxSkips` times
+	// holds the line until a table it fits is called. 0 or omitted calls
+	// the next waiting ticket whatever its size.
+	Capacity  *int   `json:"capacity,omitempty"`
+	CounterId string `json:"counter_id"`
+}
+
+// ConfirmTicketRequest defines model for ConfirmTicketRequest.
+type ConfirmTicketRequest struct {
+	Location *Location `json:"location,omitempty"`
+}
+
+// CounterThroughput defines model for CounterThroughput.
+type CounterThroughput struct {
+	// AvgServiceSeconds Average gap between consecutive calls at the counter within a queue. Gaps over 30 minutes count as the counter standing idle and are left out.
+	AvgServiceSeconds float32 `json:"avg_service_seconds"`
+	Calls             int     `json:"calls"`
+	CounterId         string  `json:"counter_id"`
+}
+
+// DailyThroughput defines model for DailyThroughput.
+type DailyThroughput struct {
+	Abandonments int       `json:"abandonments"`
+	Calls        int       `json:"calls"`
+	Day          time.Time `json:"day"`
+	Joins        int       `json:"joins"`
+}
+
+// ExportJobRequest defines model for ExportJobRequest.
+type ExportJobRequest struct {
+	Format     *ExportJobRequestFormat `json:"format,omitempty"`
+	From       *openapi_types.Date     `json:"from,omitempty"`
+	IncludePii *bool                   `json:"include_pii,omitempty"`
+	QueueId    *string                 `json:"queue_id,omitempty"`
+	To         *openapi_types.Date     `json:"to,omitempty"`
+}
+
+// ExportJobRequestFormat defines model for ExportJobRequest.Format.
+type ExportJobRequestFormat string
+
+// ExportJobStatus defines model for ExportJobStatus.
+type ExportJobStatus struct {
+	DownloadUrl *string               `json:"download_url,omitempty"`
+	Error       *string               `json:"error,omitempty"`
+	JobId       string                `json:"job_id"`
+	Rows        *int64                `json:"rows,omitempty"`
+	Status      ExportJobStatusStatus `json:"status"`
+}
+
+// ExportJobStatusStatus defines model for ExportJobStatus.Status.
+type ExportJobStatusStatus string
+
+// HourlyLoad defines model for HourlyLoad.
+type HourlyLoad struct {
+	Hour  int `json:"hour"`
+	Joins int `json:"joins"`
+}
+
+// JoinRequest defines model for JoinRequest.
+type JoinRequest struct {
+	// PartySize How many guests join on the ticket; 0 or omitted is one.
+	PartySize *PartySize `json:"party_size,omitempty"`
+}
+
+// JoinResponse defines model for JoinResponse.
+type JoinResponse struct {
+	EstimatedWaitMinutes int `json:"estimated_wait_minutes"`
+	Position             int `json:"position"`
+
+	// Token The guest's ticket. A join replaying an earlier one's Idempotency-Key gets a fresh one for the same guest.
+	Token  *string `json:"token,omitempty"`
+	UserId string  `json:"user_id"`
+}
+
+// LeaveResponse defines model for LeaveResponse.
+type LeaveResponse struct {
+	RemainingUsers int `json:"remaining_users"`
+}
+
+// Location defines model for Location.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email string `json:"email"`
+}
+
+// LoginStarted defines model for LoginStarted.
+type LoginStarted struct {
+	RunID      string `json:"runID"`
+	WorkflowID string `json:"workflowID"`
+}
+
+// Me defines model for Me.
+type Me struct {
+	Message string `json:"message"`
+	Role    string `json:"role"`
+	UserId  string `json:"user_id"`
+}
+
+// Media defines model for Media.
+type Media struct {
+	HeaderUrl string `json:"header_url"`
+	LogoUrl   string `json:"logo_url"`
+}
+
+// MoveTicketRequest defines model for MoveTicketRequest.
+type MoveTicketRequest struct {
+	Position int `json:"position"`
+}
+
+// PartySize How many guests join on the ticket; 0 or omitted is one.
+type PartySize = int
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Code Stable error code, see docs/API.md.
+	Code   string  `json:"code"`
+	Detail *string `json:"detail,omitempty"`
+	Status int     `json:"status"`
+	Title  string  `json:"title"`
+	Type   string  `json:"type"`
+}
+
+// QueueCreated defines model for QueueCreated.
+type QueueCreated struct {
+	RunId      string `json:"run_id"`
+	WorkflowId string `json:"workflow_id"`
+}
+
+// QueueList defines model for QueueList.
+type QueueList struct {
+	// NextPageToken Absent on the last page.
+	NextPageToken *string        `json:"next_page_token,omitempty"`
+	Queues        []QueueSummary `json:"queues"`
+}
+
+// QueueReport defines model for QueueReport.
+type QueueReport struct {
+	// Abandoned Guests who left before being called, plus the cancelled tickets.
+	Abandoned int `json:"abandoned"`
+
+	// AvgWaitSeconds Mean time from join to call over served tickets.
+	AvgWaitSeconds float32 `json:"avg_wait_seconds"`
+	BusinessId     string  `json:"business_id"`
+
+	// Cancelled Tickets still waiting when the queue shut down.
+	Cancelled int       `json:"cancelled"`
+	ClosedAt  time.Time `json:"closed_at"`
+	QueueId   string    `json:"queue_id"`
+	Reason    string    `json:"reason"`
+	RunId     string    `json:"run_id"`
+
+	// Served Tickets called to a counter.
+	Served int `json:"served"`
+}
+
+// QueueState Closed queues serve their tickets but take no new joins.
+type QueueState string
+
+// QueueStatus defines model for QueueStatus.
+type QueueStatus struct {
+	BusinessId string `json:"business_id"`
+
+	// ConfirmBy While set, the holder is asked to confirm they are on their way by this time.
+	ConfirmBy *time.Time `json:"confirm_by,omitempty"`
+
+	// EstimatedWaitMinutes The wait of the parties still waiting ahead of the holder and their own, weighted by party size; 0 once they are called.
+	EstimatedWaitMinutes int   `json:"estimated_wait_minutes"`
+	Media                Media `json:"media"`
+
+	// Position The ticket holder's 1-based place, 0 once they are no longer in the queue.
+	Position    int `json:"position"`
+	QueueLength int `json:"queue_length"`
+}
+
+// QueueSummary defines model for QueueSummary.
+type QueueSummary struct {
+	BusinessId  string `json:"business_id"`
+	QueueId     string `json:"queue_id"`
+	QueueLength int    `json:"queue_length"`
+
+	// State Closed queues serve their tickets but take no new joins.
+	State QueueState `json:"state"`
+}
+
+// ReportScope defines model for ReportScope.
+type ReportScope struct {
+	BusinessId string             `json:"business_id"`
+	From       openapi_types.Date `json:"from"`
+	QueueId    string             `json:"queue_id"`
+	To         openapi_types.Date `json:"to"`
+}
+
+// Ticket defines model for Ticket.
+type Ticket struct {
+	// Arrived The guest confirmed from inside the business's geofence.
+	Arrived    *bool   `json:"arrived,omitempty"`
+	AssignedTo *string `json:"assignedTo,omitempty"`
+
+	// ConfirmBy The deadline of a pending confirmation.
+	ConfirmBy *time.Time `json:"confirmBy,omitempty"`
+	Confirmed *bool      `json:"confirmed,omitempty"`
+	JoinedAt  time.Time  `json:"joinedAt"`
+
+	// Misses Confirmation deadlines the guest let pass.
+	Misses *int `json:"misses,omitempty"`
+
+	// PartySize How many guests the ticket stands for; omitted for one.
+	PartySize *int `json:"partySize,omitempty"`
+
+	// Priority 1 for checked-in appointments, which are placed ahead of waiting walk-ins; omitted for walk-ins.
+	Priority *int `json:"priority,omitempty"`
+
+	// Remote The guest joined from their phone, and is asked to confirm as their turn nears.
+	Remote *bool `json:"remote,omitempty"`
+
+	// Skips Calls for smaller tables that passed the party over.
+	Skips  *int         `json:"skips,omitempty"`
+	Status TicketStatus `json:"status"`
+	UserId string       `json:"userId"`
+}
+
+// TicketStatus defines model for Ticket.Status.
+type TicketStatus string
+
+// TicketPosition defines model for TicketPosition.
+type TicketPosition struct {
+	Position int    `json:"position"`
+	QueueId  string `json:"queue_id"`
+
+	// Token The guest's ticket for the new queue, after a transfer.
+	Token  *string `json:"token,omitempty"`
+	UserId string  `json:"user_id"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	Token string `json:"token"`
+}
+
+// TransferTicketRequest defines model for TransferTicketRequest.
+type TransferTicketRequest struct {
+	// KeepJoinTime Keep the original join time. Defaults to the server's `queues.transferKeepsJoinTime`.
+	KeepJoinTime *bool `json:"keep_join_time,omitempty"`
+
+	// Position 1-based place in the target queue; 0 or omitted is the end.
+	Position  *int   `json:"position,omitempty"`
+	ToQueueId string `json:"to_queue_id"`
+}
+
+// VerifyRequest defines model for VerifyRequest.
+type VerifyRequest struct {
+	Code  string `json:"code"`
+	Email string `json:"email"`
+}
+
+// WaitStats defines model for WaitStats.
+type WaitStats struct {
+	AvgWaitSeconds float32 `json:"avg_wait_seconds"`
+	P90WaitSeconds float32 `json:"p90_wait_seconds"`
+	Samples        int     `json:"samples"`
+}
+
+// WalkInRequest defines model for WalkInRequest.
+type WalkInRequest struct {
+	// PartySize How many guests join on the ticket; 0 or omitted is one.
+	PartySize *PartySize `json:"party_size,omitempty"`
+
+	// Position 1-based place in the queue; 0 or omitted is the end.
+	Position *int `json:"position,omitempty"`
+}
+
+// AppointmentID defines model for AppointmentID.
+type AppointmentID = string
+
+// BusinessID defines model for BusinessID.
+type BusinessID = string
+
+// From defines model for From.
+type From = openapi_types.Date
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// QueueID defines model for QueueID.
+type QueueID = string
+
+// ReportQueueID defines model for ReportQueueID.
+type ReportQueueID = string
+
+// To defines model for To.
+type To = openapi_types.Date
+
+// UserID defines model for UserID.
+type UserID = string
+
+// GetBusiestHoursParams defines parameters for GetBusiestHours.
+type GetBusiestHoursParams struct {
+	// QueueId Limit the report to one queue.
+	QueueId *ReportQueueID `form:"queue_id,omitempty" json:"queue_id,omitempty"`
+
+	// From First day, inclusive. Defaults to 6 days before `to`.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day, inclusive. Defaults to today (UTC).
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetCounterThroughputParams defines parameters for GetCounterThroughput.
+type GetCounterThroughputParams struct {
+	// QueueId Limit the report to one queue.
+	QueueId *ReportQueueID `form:"queue_id,omitempty" json:"queue_id,omitempty"`
+
+	// From First day, inclusive. Defaults to 6 days before `to`.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day, inclusive. Defaults to today (UTC).
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetThroughputParams defines parameters for GetThroughput.
+type GetThroughputParams struct {
+	// QueueId Limit the report to one queue.
+	QueueId *ReportQueueID `form:"queue_id,omitempty" json:"queue_id,omitempty"`
+
+	// From First day, inclusive. Defaults to 6 days before `to`.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day, inclusive. Defaults to today (UTC).
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetWaitTimesParams defines parameters for GetWaitTimes.
+type GetWaitTimesParams struct {
+	// QueueId Limit the report to one queue.
+	QueueId *ReportQueueID `form:"queue_id,omitempty" json:"queue_id,omitempty"`
+
+	// From First day, inclusive. Defaults to 6 days before `to`.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day, inclusive. Defaults to today (UTC).
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListAuditLogParams defines parameters for ListAuditLog.
+type ListAuditLogParams struct {
+	// QueueId Limit the report to one queue.
+	QueueId *ReportQueueID `form:"queue_id,omitempty" json:"queue_id,omitempty"`
+
+	// ActorId Only list changes made by this staff member.
+	ActorId *string `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+
+	// From Earliest change, inclusive.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Latest change, exclusive.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// After The `next_after` of the previous page.
+	After *int64 `form:"after,omitempty" json:"after,omitempty"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListQueuesParams defines parameters for ListQueues.
+type ListQueuesParams struct {
+	// State Only list queues in this state.
+	State    *QueueState `form:"state,omitempty" json:"state,omitempty"`
+	PageSize *int        `form:"page_size,omitempty" json:"page_size,omitempty"`
+
+	// PageToken The `next_page_token` of the previous page.
+	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
+}
+
+// DeleteQueueParams defines parameters for DeleteQueue.
+type DeleteQueueParams struct {
+	// Reason Recorded in the report and sent to cancelled guests.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// BookAppointmentParams defines parameters for BookAppointment.
+type BookAppointmentParams struct {
+	// IdempotencyKey Retrying with the same key returns the original result instead of acting twice.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CheckInParams defines parameters for CheckIn.
+type CheckInParams struct {
+	// IdempotencyKey Retrying with the same key returns the original result instead of acting twice.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CallNextParams defines parameters for CallNext.
+type CallNextParams struct {
+	// IdempotencyKey Retrying with the same key returns the original result instead of acting twice.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// JoinQueueParams defines parameters for JoinQueue.
+type JoinQueueParams struct {
+	// IdempotencyKey Retrying with the same key returns the original result instead of acting twice.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// LeaveQueueParams defines parameters for LeaveQueue.
+type LeaveQueueParams struct {
+	// Reason Why staff removed the guest. Ignored for guests.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+
+	// IdempotencyKey Retrying with the same key returns the original result instead of acting twice.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AddWalkInParams defines parameters for AddWalkIn.
+type AddWalkInParams struct {
+	// IdempotencyKey Retrying with the same key returns the original result instead of acting twice.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// VerifyJSONRequestBody defines body for Verify for application/json ContentType.
+type VerifyJSONRequestBody = VerifyRequest
+
+// BookAppointmentJSONRequestBody defines body for BookAppointment for application/json ContentType.
+type BookAppointmentJSONRequestBody = BookAppointmentRequest
+
+// CallNextJSONRequestBody defines body for CallNext for application/json ContentType.
+type CallNextJSONRequestBody = CallNextRequest
+
+// JoinQueueJSONRequestBody defines body for JoinQueue for application/json ContentType.
+type JoinQueueJSONRequestBody = JoinRequest
+
+// MoveTicketJSONRequestBody defines body for MoveTicket for application/json ContentType.
+type MoveTicketJSONRequestBody = MoveTicketRequest
+
+// ConfirmTicketJSONRequestBody defines body for ConfirmTicket for application/json ContentType.
+type ConfirmTicketJSONRequestBody = ConfirmTicketRequest
+
+// TransferTicketJSONRequestBody defines body for TransferTicket for application/json ContentType.
+type TransferTicketJSONRequestBody = TransferTicketRequest
+
+// AddWalkInJSONRequestBody defines body for AddWalkIn for application/json ContentType.
+type AddWalkInJSONRequestBody = WalkInRequest
+
+// StartExportJSONRequestBody defines body for StartExport for application/json ContentType.
+type StartExportJSONRequestBody = ExportJobRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetBusiestHours request
+	GetBusiestHours(ctx context.Context, params *GetBusiestHoursParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCounterThroughput request
+	GetCounterThroughput(ctx context.Context, params *GetCounterThroughputParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetThroughput request
+	GetThroughput(ctx context.Context, params *GetThroughputParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWaitTimes request
+	GetWaitTimes(ctx context.Context, params *GetWaitTimesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LoginWithBody request with any body
+	LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// VerifyWithBody request with any body
+	VerifyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Verify(ctx context.Context, body VerifyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAuditLog request
+	ListAuditLog(ctx context.Context, businessId BusinessID, params *ListAuditLogParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListQueues request
+	ListQueues(ctx context.Context, businessId BusinessID, params *ListQueuesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteQueue request
+	DeleteQueue(ctx context.Context, businessId BusinessID, queueId QueueID, params *DeleteQueueParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQueueStatus request
+	GetQueueStatus(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateQueue request
+	CreateQueue(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BookAppointmentWithBody request with any body
+	BookAppointmentWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BookAppointment(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, body BookAppointmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelAppointment request
+	CancelAppointment(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAppointment request
+	GetAppointment(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckIn request
+	CheckIn(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, params *CheckInParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CallNextWithBody request with any body
+	CallNextWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CallNext(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, body CallNextJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// JoinQueueWithBody request with any body
+	JoinQueueWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	JoinQueue(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, body JoinQueueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LeaveQueue request
+	LeaveQueue(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, params *LeaveQueueParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// MoveTicketWithBody request with any body
+	MoveTicketWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	MoveTicket(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body MoveTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ConfirmTicketWithBody request with any body
+	ConfirmTicketWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ConfirmTicket(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body ConfirmTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TransferTicketWithBody request with any body
+	TransferTicketWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TransferTicket(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body TransferTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddWalkInWithBody request with any body
+	AddWalkInWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddWalkIn(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, body AddWalkInJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartExportWithBody request with any body
+	StartExportWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StartExport(ctx context.Context, body StartExportJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetExport request
+	GetExport(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMe request
+	GetMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetBusiestHours(ctx context.Context, params *GetBusiestHoursParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusiestHoursRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCounterThroughput(ctx context.Context, params *GetCounterThroughputParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCounterThroughputRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetThroughput(ctx context.Context, params *GetThroughputParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetThroughputRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWaitTimes(ctx context.Context, params *GetWaitTimesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWaitTimesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) VerifyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVerifyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Verify(ctx context.Context, body VerifyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVerifyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAuditLog(ctx context.Context, businessId BusinessID, params *ListAuditLogParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAuditLogRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListQueues(ctx context.Context, businessId BusinessID, params *ListQueuesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListQueuesRequest(c.Server, businessId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteQueue(ctx context.Context, businessId BusinessID, queueId QueueID, params *DeleteQueueParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteQueueRequest(c.Server, businessId, queueId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQueueStatus(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQueueStatusRequest(c.Server, businessId, queueId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateQueue(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateQueueRequest(c.Server, businessId, queueId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BookAppointmentWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBookAppointmentRequestWithBody(c.Server, businessId, queueId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BookAppointment(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, body BookAppointmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBookAppointmentRequest(c.Server, businessId, queueId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelAppointment(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelAppointmentRequest(c.Server, businessId, queueId, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAppointment(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppointmentRequest(c.Server, businessId, queueId, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckIn(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, params *CheckInParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckInRequest(c.Server, businessId, queueId, appointmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CallNextWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCallNextRequestWithBody(c.Server, businessId, queueId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CallNext(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, body CallNextJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCallNextRequest(c.Server, businessId, queueId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) JoinQueueWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewJoinQueueRequestWithBody(c.Server, businessId, queueId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) JoinQueue(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, body JoinQueueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewJoinQueueRequest(c.Server, businessId, queueId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LeaveQueue(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, params *LeaveQueueParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLeaveQueueRequest(c.Server, businessId, queueId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MoveTicketWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMoveTicketRequestWithBody(c.Server, businessId, queueId, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MoveTicket(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body MoveTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMoveTicketRequest(c.Server, businessId, queueId, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmTicketWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmTicketRequestWithBody(c.Server, businessId, queueId, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmTicket(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body ConfirmTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmTicketRequest(c.Server, businessId, queueId, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferTicketWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferTicketRequestWithBody(c.Server, businessId, queueId, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferTicket(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body TransferTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferTicketRequest(c.Server, businessId, queueId, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddWalkInWithBody(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddWalkInRequestWithBody(c.Server, businessId, queueId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddWalkIn(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, body AddWalkInJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddWalkInRequest(c.Server, businessId, queueId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartExportWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartExportRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartExport(ctx context.Context, body StartExportJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartExportRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetExport(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetExportRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMeRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetBusiestHoursRequest generates requests for GetBusiestHours
+func NewGetBusiestHoursRequest(server string, params *GetBusiestHoursParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/analytics/busiest-hours")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.QueueId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "queue_id", runtime.ParamLocationQuery, *params.QueueId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCounterThroughputRequest generates requests for GetCounterThroughput
+func NewGetCounterThroughputRequest(server string, params *GetCounterThroughputParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/analytics/counters")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.QueueId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "queue_id", runtime.ParamLocationQuery, *params.QueueId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetThroughputRequest generates requests for GetThroughput
+func NewGetThroughputRequest(server string, params *GetThroughputParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/analytics/throughput")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.QueueId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "queue_id", runtime.ParamLocationQuery, *params.QueueId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWaitTimesRequest generates requests for GetWaitTimes
+func NewGetWaitTimesRequest(server string, params *GetWaitTimesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/analytics/wait-times")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.QueueId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "queue_id", runtime.ParamLocationQuery, *params.QueueId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLoginRequest calls the generic Login builder with application/json body
+func NewLoginRequest(server string, body LoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLoginRequestWithBody generates requests for Login with any type of body
+func NewLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/auth/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewVerifyRequest calls the generic Verify builder with application/json body
+func NewVerifyRequest(server string, body VerifyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewVerifyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewVerifyRequestWithBody generates requests for Verify with any type of body
+func NewVerifyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/auth/verify")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListAuditLogRequest generates requests for ListAuditLog
+func NewListAuditLogRequest(server string, businessId BusinessID, params *ListAuditLogParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/audit-log", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.QueueId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "queue_id", runtime.ParamLocationQuery, *params.QueueId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ActorId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor_id", runtime.ParamLocationQuery, *params.ActorId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "after", runtime.ParamLocationQuery, *params.After); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListQueuesRequest generates requests for ListQueues
+func NewListQueuesRequest(server string, businessId BusinessID, params *ListQueuesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.State != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "state", runtime.ParamLocationQuery, *params.State); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_token", runtime.ParamLocationQuery, *params.PageToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteQueueRequest generates requests for DeleteQueue
+func NewDeleteQueueRequest(server string, businessId BusinessID, queueId QueueID, params *DeleteQueueParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Reason != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reason", runtime.ParamLocationQuery, *params.Reason); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQueueStatusRequest generates requests for GetQueueStatus
+func NewGetQueueStatusRequest(server string, businessId BusinessID, queueId QueueID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateQueueRequest generates requests for CreateQueue
+func NewCreateQueueRequest(server string, businessId BusinessID, queueId QueueID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewBookAppointmentRequest calls the generic BookAppointment builder with application/json body
+func NewBookAppointmentRequest(server string, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, body BookAppointmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBookAppointmentRequestWithBody(server, businessId, queueId, params, "application/json", bodyReader)
+}
+
+// NewBookAppointmentRequestWithBody generates requests for BookAppointment with any type of body
+func NewBookAppointmentRequestWithBody(server string, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/appointments", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewCancelAppointmentRequest generates requests for CancelAppointment
+func NewCancelAppointmentRequest(server string, businessId BusinessID, queueId QueueID, appointmentId AppointmentID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "appointment_id", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/appointments/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAppointmentRequest generates requests for GetAppointment
+func NewGetAppointmentRequest(server string, businessId BusinessID, queueId QueueID, appointmentId AppointmentID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "appointment_id", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/appointments/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCheckInRequest generates requests for CheckIn
+func NewCheckInRequest(server string, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, params *CheckInParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "appointment_id", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/appointments/%s/check-in", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewCallNextRequest calls the generic CallNext builder with application/json body
+func NewCallNextRequest(server string, businessId BusinessID, queueId QueueID, params *CallNextParams, body CallNextJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCallNextRequestWithBody(server, businessId, queueId, params, "application/json", bodyReader)
+}
+
+// NewCallNextRequestWithBody generates requests for CallNext with any type of body
+func NewCallNextRequestWithBody(server string, businessId BusinessID, queueId QueueID, params *CallNextParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/calls", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewJoinQueueRequest calls the generic JoinQueue builder with application/json body
+func NewJoinQueueRequest(server string, businessId BusinessID, queueId QueueID, params *JoinQueueParams, body JoinQueueJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewJoinQueueRequestWithBody(server, businessId, queueId, params, "application/json", bodyReader)
+}
+
+// NewJoinQueueRequestWithBody generates requests for JoinQueue with any type of body
+func NewJoinQueueRequestWithBody(server string, businessId BusinessID, queueId QueueID, params *JoinQueueParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/tickets", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewLeaveQueueRequest generates requests for LeaveQueue
+func NewLeaveQueueRequest(server string, businessId BusinessID, queueId QueueID, userId UserID, params *LeaveQueueParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/tickets/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Reason != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reason", runtime.ParamLocationQuery, *params.Reason); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewMoveTicketRequest calls the generic MoveTicket builder with application/json body
+func NewMoveTicketRequest(server string, businessId BusinessID, queueId QueueID, userId UserID, body MoveTicketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewMoveTicketRequestWithBody(server, businessId, queueId, userId, "application/json", bodyReader)
+}
+
+// NewMoveTicketRequestWithBody generates requests for MoveTicket with any type of body
+func NewMoveTicketRequestWithBody(server string, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/tickets/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewConfirmTicketRequest calls the generic ConfirmTicket builder with application/json body
+func NewConfirmTicketRequest(server string, businessId BusinessID, queueId QueueID, userId UserID, body ConfirmTicketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewConfirmTicketRequestWithBody(server, businessId, queueId, userId, "application/json", bodyReader)
+}
+
+// NewConfirmTicketRequestWithBody generates requests for ConfirmTicket with any type of body
+func NewConfirmTicketRequestWithBody(server string, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/tickets/%s/confirm", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTransferTicketRequest calls the generic TransferTicket builder with application/json body
+func NewTransferTicketRequest(server string, businessId BusinessID, queueId QueueID, userId UserID, body TransferTicketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTransferTicketRequestWithBody(server, businessId, queueId, userId, "application/json", bodyReader)
+}
+
+// NewTransferTicketRequestWithBody generates requests for TransferTicket with any type of body
+func NewTransferTicketRequestWithBody(server string, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/tickets/%s/transfer", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAddWalkInRequest calls the generic AddWalkIn builder with application/json body
+func NewAddWalkInRequest(server string, businessId BusinessID, queueId QueueID, params *AddWalkInParams, body AddWalkInJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddWalkInRequestWithBody(server, businessId, queueId, params, "application/json", bodyReader)
+}
+
+// NewAddWalkInRequestWithBody generates requests for AddWalkIn with any type of body
+func NewAddWalkInRequestWithBody(server string, businessId BusinessID, queueId QueueID, params *AddWalkInParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "business_id", runtime.ParamLocationPath, businessId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "queue_id", runtime.ParamLocationPath, queueId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/businesses/%s/queues/%s/walk-ins", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewStartExportRequest calls the generic StartExport builder with application/json body
+func NewStartExportRequest(server string, body StartExportJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStartExportRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStartExportRequestWithBody generates requests for StartExport with any type of body
+func NewStartExportRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/exports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetExportRequest generates requests for GetExport
+func NewGetExportRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/exports/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMeRequest generates requests for GetMe
+func NewGetMeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetBusiestHoursWithResponse request
+	GetBusiestHoursWithResponse(ctx context.Context, params *GetBusiestHoursParams, reqEditors ...RequestEditorFn) (*GetBusiestHoursResponse, error)
+
+	// GetCounterThroughputWithResponse request
+	GetCounterThroughputWithResponse(ctx context.Context, params *GetCounterThroughputParams, reqEditors ...RequestEditorFn) (*GetCounterThroughputResponse, error)
+
+	// GetThroughputWithResponse request
+	GetThroughputWithResponse(ctx context.Context, params *GetThroughputParams, reqEditors ...RequestEditorFn) (*GetThroughputResponse, error)
+
+	// GetWaitTimesWithResponse request
+	GetWaitTimesWithResponse(ctx context.Context, params *GetWaitTimesParams, reqEditors ...RequestEditorFn) (*GetWaitTimesResponse, error)
+
+	// LoginWithBodyWithResponse request with any body
+	LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	// VerifyWithBodyWithResponse request with any body
+	VerifyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyResponse, error)
+
+	VerifyWithResponse(ctx context.Context, body VerifyJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyResponse, error)
+
+	// ListAuditLogWithResponse request
+	ListAuditLogWithResponse(ctx context.Context, businessId BusinessID, params *ListAuditLogParams, reqEditors ...RequestEditorFn) (*ListAuditLogResponse, error)
+
+	// ListQueuesWithResponse request
+	ListQueuesWithResponse(ctx context.Context, businessId BusinessID, params *ListQueuesParams, reqEditors ...RequestEditorFn) (*ListQueuesResponse, error)
+
+	// DeleteQueueWithResponse request
+	DeleteQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *DeleteQueueParams, reqEditors ...RequestEditorFn) (*DeleteQueueResponse, error)
+
+	// GetQueueStatusWithResponse request
+	GetQueueStatusWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*GetQueueStatusResponse, error)
+
+	// CreateQueueWithResponse request
+	CreateQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*CreateQueueResponse, error)
+
+	// BookAppointmentWithBodyWithResponse request with any body
+	BookAppointmentWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BookAppointmentResponse, error)
+
+	BookAppointmentWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, body BookAppointmentJSONRequestBody, reqEditors ...RequestEditorFn) (*BookAppointmentResponse, error)
+
+	// CancelAppointmentWithResponse request
+	CancelAppointmentWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*CancelAppointmentResponse, error)
+
+	// GetAppointmentWithResponse request
+	GetAppointmentWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*GetAppointmentResponse, error)
+
+	// CheckInWithResponse request
+	CheckInWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, params *CheckInParams, reqEditors ...RequestEditorFn) (*CheckInResponse, error)
+
+	// CallNextWithBodyWithResponse request with any body
+	CallNextWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CallNextResponse, error)
+
+	CallNextWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, body CallNextJSONRequestBody, reqEditors ...RequestEditorFn) (*CallNextResponse, error)
+
+	// JoinQueueWithBodyWithResponse request with any body
+	JoinQueueWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*JoinQueueResponse, error)
+
+	JoinQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, body JoinQueueJSONRequestBody, reqEditors ...RequestEditorFn) (*JoinQueueResponse, error)
+
+	// LeaveQueueWithResponse request
+	LeaveQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, params *LeaveQueueParams, reqEditors ...RequestEditorFn) (*LeaveQueueResponse, error)
+
+	// MoveTicketWithBodyWithResponse request with any body
+	MoveTicketWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MoveTicketResponse, error)
+
+	MoveTicketWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body MoveTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*MoveTicketResponse, error)
+
+	// ConfirmTicketWithBodyWithResponse request with any body
+	ConfirmTicketWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfirmTicketResponse, error)
+
+	ConfirmTicketWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body ConfirmTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*ConfirmTicketResponse, error)
+
+	// TransferTicketWithBodyWithResponse request with any body
+	TransferTicketWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferTicketResponse, error)
+
+	TransferTicketWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body TransferTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferTicketResponse, error)
+
+	// AddWalkInWithBodyWithResponse request with any body
+	AddWalkInWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddWalkInResponse, error)
+
+	AddWalkInWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, body AddWalkInJSONRequestBody, reqEditors ...RequestEditorFn) (*AddWalkInResponse, error)
+
+	// StartExportWithBodyWithResponse request with any body
+	StartExportWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartExportResponse, error)
+
+	StartExportWithResponse(ctx context.Context, body StartExportJSONRequestBody, reqEditors ...RequestEditorFn) (*StartExportResponse, error)
+
+	// GetExportWithResponse request
+	GetExportWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetExportResponse, error)
+
+	// GetMeWithResponse request
+	GetMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMeResponse, error)
+}
+
+type GetBusiestHoursResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		BusinessId string             `json:"business_id"`
+		From       openapi_types.Date `json:"from"`
+		Hours      []HourlyLoad       `json:"hours"`
+		QueueId    string             `json:"queue_id"`
+		To         openapi_types.Date `json:"to"`
+	}
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusiestHoursResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusiestHoursResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCounterThroughputResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		BusinessId string              `json:"business_id"`
+		Counters   []CounterThroughput `json:"counters"`
+		From       openapi_types.Date  `json:"from"`
+		QueueId    string              `json:"queue_id"`
+		To         openapi_types.Date  `json:"to"`
+	}
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCounterThroughputResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCounterThroughputResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetThroughputResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		BusinessId string             `json:"business_id"`
+		Days       []DailyThroughput  `json:"days"`
+		From       openapi_types.Date `json:"from"`
+		QueueId    string             `json:"queue_id"`
+		To         openapi_types.Date `json:"to"`
+	}
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetThroughputResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetThroughputResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWaitTimesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		BusinessId string             `json:"business_id"`
+		From       openapi_types.Date `json:"from"`
+		QueueId    string             `json:"queue_id"`
+		To         openapi_types.Date `json:"to"`
+		Wait       WaitStats          `json:"wait"`
+	}
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWaitTimesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWaitTimesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *LoginStarted
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type VerifyResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *TokenResponse
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r VerifyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r VerifyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAuditLogResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *AuditLog
+	ApplicationproblemJSON400     *Problem
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAuditLogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAuditLogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListQueuesResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *QueueList
+	ApplicationproblemJSON400     *Problem
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListQueuesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListQueuesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteQueueResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *QueueReport
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteQueueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteQueueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQueueStatusResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *QueueStatus
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQueueStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQueueStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateQueueResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *QueueCreated
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateQueueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateQueueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BookAppointmentResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *Appointment
+	ApplicationproblemJSON400     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSON422     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r BookAppointmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BookAppointmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelAppointmentResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *Appointment
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelAppointmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelAppointmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAppointmentResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *Appointment
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppointmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppointmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckInResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *Appointment
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckInResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckInResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CallNextResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *Ticket
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CallNextResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CallNextResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type JoinQueueResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *JoinResponse
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSON422     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r JoinQueueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r JoinQueueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LeaveQueueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		union json.RawMessage
+	}
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r LeaveQueueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LeaveQueueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MoveTicketResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *TicketPosition
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r MoveTicketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MoveTicketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConfirmTicketResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *Ticket
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSON422     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ConfirmTicketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConfirmTicketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TransferTicketResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *TicketPosition
+	ApplicationproblemJSON400     *Problem
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSON409     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r TransferTicketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TransferTicketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddWalkInResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *JoinResponse
+	ApplicationproblemJSON401     *Problem
+	ApplicationproblemJSON403     *Problem
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r AddWalkInResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddWalkInResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartExportResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON202                       *ExportJobStatus
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r StartExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetExportResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *ExportJobStatus
+	ApplicationproblemJSON404     *Problem
+	ApplicationproblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMeResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Me
+	ApplicationproblemJSON401 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetBusiestHoursWithResponse request returning *GetBusiestHoursResponse
+func (c *ClientWithResponses) GetBusiestHoursWithResponse(ctx context.Context, params *GetBusiestHoursParams, reqEditors ...RequestEditorFn) (*GetBusiestHoursResponse, error) {
+	rsp, err := c.GetBusiestHours(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusiestHoursResponse(rsp)
+}
+
+// GetCounterThroughputWithResponse request returning *GetCounterThroughputResponse
+func (c *ClientWithResponses) GetCounterThroughputWithResponse(ctx context.Context, params *GetCounterThroughputParams, reqEditors ...RequestEditorFn) (*GetCounterThroughputResponse, error) {
+	rsp, err := c.GetCounterThroughput(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCounterThroughputResponse(rsp)
+}
+
+// GetThroughputWithResponse request returning *GetThroughputResponse
+func (c *ClientWithResponses) GetThroughputWithResponse(ctx context.Context, params *GetThroughputParams, reqEditors ...RequestEditorFn) (*GetThroughputResponse, error) {
+	rsp, err := c.GetThroughput(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetThroughputResponse(rsp)
+}
+
+// GetWaitTimesWithResponse request returning *GetWaitTimesResponse
+func (c *ClientWithResponses) GetWaitTimesWithResponse(ctx context.Context, params *GetWaitTimesParams, reqEditors ...RequestEditorFn) (*GetWaitTimesResponse, error) {
+	rsp, err := c.GetWaitTimes(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWaitTimesResponse(rsp)
+}
+
+// LoginWithBodyWithResponse request with arbitrary body returning *LoginResponse
+func (c *ClientWithResponses) LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.LoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.Login(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+// VerifyWithBodyWithResponse request with arbitrary body returning *VerifyResponse
+func (c *ClientWithResponses) VerifyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyResponse, error) {
+	rsp, err := c.VerifyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyResponse(rsp)
+}
+
+func (c *ClientWithResponses) VerifyWithResponse(ctx context.Context, body VerifyJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyResponse, error) {
+	rsp, err := c.Verify(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyResponse(rsp)
+}
+
+// ListAuditLogWithResponse request returning *ListAuditLogResponse
+func (c *ClientWithResponses) ListAuditLogWithResponse(ctx context.Context, businessId BusinessID, params *ListAuditLogParams, reqEditors ...RequestEditorFn) (*ListAuditLogResponse, error) {
+	rsp, err := c.ListAuditLog(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAuditLogResponse(rsp)
+}
+
+// ListQueuesWithResponse request returning *ListQueuesResponse
+func (c *ClientWithResponses) ListQueuesWithResponse(ctx context.Context, businessId BusinessID, params *ListQueuesParams, reqEditors ...RequestEditorFn) (*ListQueuesResponse, error) {
+	rsp, err := c.ListQueues(ctx, businessId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListQueuesResponse(rsp)
+}
+
+// DeleteQueueWithResponse request returning *DeleteQueueResponse
+func (c *ClientWithResponses) DeleteQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *DeleteQueueParams, reqEditors ...RequestEditorFn) (*DeleteQueueResponse, error) {
+	rsp, err := c.DeleteQueue(ctx, businessId, queueId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteQueueResponse(rsp)
+}
+
+// GetQueueStatusWithResponse request returning *GetQueueStatusResponse
+func (c *ClientWithResponses) GetQueueStatusWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*GetQueueStatusResponse, error) {
+	rsp, err := c.GetQueueStatus(ctx, businessId, queueId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQueueStatusResponse(rsp)
+}
+
+// CreateQueueWithResponse request returning *CreateQueueResponse
+func (c *ClientWithResponses) CreateQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, reqEditors ...RequestEditorFn) (*CreateQueueResponse, error) {
+	rsp, err := c.CreateQueue(ctx, businessId, queueId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateQueueResponse(rsp)
+}
+
+// BookAppointmentWithBodyWithResponse request with arbitrary body returning *BookAppointmentResponse
+func (c *ClientWithResponses) BookAppointmentWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BookAppointmentResponse, error) {
+	rsp, err := c.BookAppointmentWithBody(ctx, businessId, queueId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBookAppointmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) BookAppointmentWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *BookAppointmentParams, body BookAppointmentJSONRequestBody, reqEditors ...RequestEditorFn) (*BookAppointmentResponse, error) {
+	rsp, err := c.BookAppointment(ctx, businessId, queueId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBookAppointmentResponse(rsp)
+}
+
+// CancelAppointmentWithResponse request returning *CancelAppointmentResponse
+func (c *ClientWithResponses) CancelAppointmentWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*CancelAppointmentResponse, error) {
+	rsp, err := c.CancelAppointment(ctx, businessId, queueId, appointmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelAppointmentResponse(rsp)
+}
+
+// GetAppointmentWithResponse request returning *GetAppointmentResponse
+func (c *ClientWithResponses) GetAppointmentWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, reqEditors ...RequestEditorFn) (*GetAppointmentResponse, error) {
+	rsp, err := c.GetAppointment(ctx, businessId, queueId, appointmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppointmentResponse(rsp)
+}
+
+// CheckInWithResponse request returning *CheckInResponse
+func (c *ClientWithResponses) CheckInWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, appointmentId AppointmentID, params *CheckInParams, reqEditors ...RequestEditorFn) (*CheckInResponse, error) {
+	rsp, err := c.CheckIn(ctx, businessId, queueId, appointmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckInResponse(rsp)
+}
+
+// CallNextWithBodyWithResponse request with arbitrary body returning *CallNextResponse
+func (c *ClientWithResponses) CallNextWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CallNextResponse, error) {
+	rsp, err := c.CallNextWithBody(ctx, businessId, queueId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCallNextResponse(rsp)
+}
+
+func (c *ClientWithResponses) CallNextWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *CallNextParams, body CallNextJSONRequestBody, reqEditors ...RequestEditorFn) (*CallNextResponse, error) {
+	rsp, err := c.CallNext(ctx, businessId, queueId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCallNextResponse(rsp)
+}
+
+// JoinQueueWithBodyWithResponse request with arbitrary body returning *JoinQueueResponse
+func (c *ClientWithResponses) JoinQueueWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*JoinQueueResponse, error) {
+	rsp, err := c.JoinQueueWithBody(ctx, businessId, queueId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJoinQueueResponse(rsp)
+}
+
+func (c *ClientWithResponses) JoinQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *JoinQueueParams, body JoinQueueJSONRequestBody, reqEditors ...RequestEditorFn) (*JoinQueueResponse, error) {
+	rsp, err := c.JoinQueue(ctx, businessId, queueId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJoinQueueResponse(rsp)
+}
+
+// LeaveQueueWithResponse request returning *LeaveQueueResponse
+func (c *ClientWithResponses) LeaveQueueWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, params *LeaveQueueParams, reqEditors ...RequestEditorFn) (*LeaveQueueResponse, error) {
+	rsp, err := c.LeaveQueue(ctx, businessId, queueId, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLeaveQueueResponse(rsp)
+}
+
+// MoveTicketWithBodyWithResponse request with arbitrary body returning *MoveTicketResponse
+func (c *ClientWithResponses) MoveTicketWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MoveTicketResponse, error) {
+	rsp, err := c.MoveTicketWithBody(ctx, businessId, queueId, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMoveTicketResponse(rsp)
+}
+
+func (c *ClientWithResponses) MoveTicketWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body MoveTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*MoveTicketResponse, error) {
+	rsp, err := c.MoveTicket(ctx, businessId, queueId, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMoveTicketResponse(rsp)
+}
+
+// ConfirmTicketWithBodyWithResponse request with arbitrary body returning *ConfirmTicketResponse
+func (c *ClientWithResponses) ConfirmTicketWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfirmTicketResponse, error) {
+	rsp, err := c.ConfirmTicketWithBody(ctx, businessId, queueId, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmTicketResponse(rsp)
+}
+
+func (c *ClientWithResponses) ConfirmTicketWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body ConfirmTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*ConfirmTicketResponse, error) {
+	rsp, err := c.ConfirmTicket(ctx, businessId, queueId, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmTicketResponse(rsp)
+}
+
+// TransferTicketWithBodyWithResponse request with arbitrary body returning *TransferTicketResponse
+func (c *ClientWithResponses) TransferTicketWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferTicketResponse, error) {
+	rsp, err := c.TransferTicketWithBody(ctx, businessId, queueId, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferTicketResponse(rsp)
+}
+
+func (c *ClientWithResponses) TransferTicketWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, userId UserID, body TransferTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferTicketResponse, error) {
+	rsp, err := c.TransferTicket(ctx, businessId, queueId, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferTicketResponse(rsp)
+}
+
+// AddWalkInWithBodyWithResponse request with arbitrary body returning *AddWalkInResponse
+func (c *ClientWithResponses) AddWalkInWithBodyWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddWalkInResponse, error) {
+	rsp, err := c.AddWalkInWithBody(ctx, businessId, queueId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddWalkInResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddWalkInWithResponse(ctx context.Context, businessId BusinessID, queueId QueueID, params *AddWalkInParams, body AddWalkInJSONRequestBody, reqEditors ...RequestEditorFn) (*AddWalkInResponse, error) {
+	rsp, err := c.AddWalkIn(ctx, businessId, queueId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddWalkInResponse(rsp)
+}
+
+// StartExportWithBodyWithResponse request with arbitrary body returning *StartExportResponse
+func (c *ClientWithResponses) StartExportWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartExportResponse, error) {
+	rsp, err := c.StartExportWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartExportResponse(rsp)
+}
+
+func (c *ClientWithResponses) StartExportWithResponse(ctx context.Context, body StartExportJSONRequestBody, reqEditors ...RequestEditorFn) (*StartExportResponse, error) {
+	rsp, err := c.StartExport(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartExportResponse(rsp)
+}
+
+// GetExportWithResponse request returning *GetExportResponse
+func (c *ClientWithResponses) GetExportWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetExportResponse, error) {
+	rsp, err := c.GetExport(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetExportResponse(rsp)
+}
+
+// GetMeWithResponse request returning *GetMeResponse
+func (c *ClientWithResponses) GetMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMeResponse, error) {
+	rsp, err := c.GetMe(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMeResponse(rsp)
+}
+
+// ParseGetBusiestHoursResponse parses an HTTP response from a GetBusiestHoursWithResponse call
+func ParseGetBusiestHoursResponse(rsp *http.Response) (*GetBusiestHoursResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusiestHoursResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			BusinessId string             `json:"business_id"`
+			From       openapi_types.Date `json:"from"`
+			Hours      []HourlyLoad       `json:"hours"`
+			QueueId    string             `json:"queue_id"`
+			To         openapi_types.Date `json:"to"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCounterThroughputResponse parses an HTTP response from a GetCounterThroughputWithResponse call
+func ParseGetCounterThroughputResponse(rsp *http.Response) (*GetCounterThroughputResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCounterThroughputResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			BusinessId string              `json:"business_id"`
+			Counters   []CounterThroughput `json:"counters"`
+			From       openapi_types.Date  `json:"from"`
+			QueueId    string              `json:"queue_id"`
+			To         openapi_types.Date  `json:"to"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetThroughputResponse parses an HTTP response from a GetThroughputWithResponse call
+func ParseGetThroughputResponse(rsp *http.Response) (*GetThroughputResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetThroughputResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			BusinessId string             `json:"business_id"`
+			Days       []DailyThroughput  `json:"days"`
+			From       openapi_types.Date `json:"from"`
+			QueueId    string             `json:"queue_id"`
+			To         openapi_types.Date `json:"to"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWaitTimesResponse parses an HTTP response from a GetWaitTimesWithResponse call
+func ParseGetWaitTimesResponse(rsp *http.Response) (*GetWaitTimesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWaitTimesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			BusinessId string             `json:"business_id"`
+			From       openapi_types.Date `json:"from"`
+			QueueId    string             `json:"queue_id"`
+			To         openapi_types.Date `json:"to"`
+			Wait       WaitStats          `json:"wait"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginResponse parses an HTTP response from a LoginWithResponse call
+func ParseLoginResponse(rsp *http.Response) (*LoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LoginStarted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseVerifyResponse parses an HTTP response from a VerifyWithResponse call
+func ParseVerifyResponse(rsp *http.Response) (*VerifyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VerifyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAuditLogResponse parses an HTTP response from a ListAuditLogWithResponse call
+func ParseListAuditLogResponse(rsp *http.Response) (*ListAuditLogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAuditLogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditLog
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListQueuesResponse parses an HTTP response from a ListQueuesWithResponse call
+func ParseListQueuesResponse(rsp *http.Response) (*ListQueuesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListQueuesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QueueList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteQueueResponse parses an HTTP response from a DeleteQueueWithResponse call
+func ParseDeleteQueueResponse(rsp *http.Response) (*DeleteQueueResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteQueueResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QueueReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQueueStatusResponse parses an HTTP response from a GetQueueStatusWithResponse call
+func ParseGetQueueStatusResponse(rsp *http.Response) (*GetQueueStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQueueStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QueueStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateQueueResponse parses an HTTP response from a CreateQueueWithResponse call
+func ParseCreateQueueResponse(rsp *http.Response) (*CreateQueueResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateQueueResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest QueueCreated
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBookAppointmentResponse parses an HTTP response from a BookAppointmentWithResponse call
+func ParseBookAppointmentResponse(rsp *http.Response) (*BookAppointmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BookAppointmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelAppointmentResponse parses an HTTP response from a CancelAppointmentWithResponse call
+func ParseCancelAppointmentResponse(rsp *http.Response) (*CancelAppointmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelAppointmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAppointmentResponse parses an HTTP response from a GetAppointmentWithResponse call
+func ParseGetAppointmentResponse(rsp *http.Response) (*GetAppointmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppointmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckInResponse parses an HTTP response from a CheckInWithResponse call
+func ParseCheckInResponse(rsp *http.Response) (*CheckInResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckInResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCallNextResponse parses an HTTP response from a CallNextWithResponse call
+func ParseCallNextResponse(rsp *http.Response) (*CallNextResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CallNextResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Ticket
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseJoinQueueResponse parses an HTTP response from a JoinQueueWithResponse call
+func ParseJoinQueueResponse(rsp *http.Response) (*JoinQueueResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &JoinQueueResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest JoinResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLeaveQueueResponse parses an HTTP response from a LeaveQueueWithResponse call
+func ParseLeaveQueueResponse(rsp *http.Response) (*LeaveQueueResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LeaveQueueResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			union json.RawMessage
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMoveTicketResponse parses an HTTP response from a MoveTicketWithResponse call
+func ParseMoveTicketResponse(rsp *http.Response) (*MoveTicketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MoveTicketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TicketPosition
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseConfirmTicketResponse parses an HTTP response from a ConfirmTicketWithResponse call
+func ParseConfirmTicketResponse(rsp *http.Response) (*ConfirmTicketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ConfirmTicketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Ticket
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTransferTicketResponse parses an HTTP response from a TransferTicketWithResponse call
+func ParseTransferTicketResponse(rsp *http.Response) (*TransferTicketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransferTicketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TicketPosition
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddWalkInResponse parses an HTTP response from a AddWalkInWithResponse call
+func ParseAddWalkInResponse(rsp *http.Response) (*AddWalkInResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddWalkInResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest JoinResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartExportResponse parses an HTTP response from a StartExportWithResponse call
+func ParseStartExportResponse(rsp *http.Response) (*StartExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ExportJobStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetExportResponse parses an HTTP response from a GetExportWithResponse call
+func ParseGetExportResponse(rsp *http.Response) (*GetExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ExportJobStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMeResponse parses an HTTP response from a GetMeWithResponse call
+func ParseGetMeResponse(rsp *http.Response) (*GetMeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Me
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	}
+
+	return response, nil
+}
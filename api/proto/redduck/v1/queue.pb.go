@@ -0,0 +1,1067 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: redduck/v1/queue.proto
+
+package redduckv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TicketStatus int32
+
+const (
+	TicketStatus_TICKET_STATUS_UNSPECIFIED TicketStatus = 0
+	TicketStatus_TICKET_STATUS_WAITING     TicketStatus = 1
+	TicketStatus_TICKET_STATUS_READY       TicketStatus = 2
+	TicketStatus_TICKET_STATUS_COMPLETED   TicketStatus = 3
+	// Still waiting when the queue shut down.
+	TicketStatus_TICKET_STATUS_CANCELLED TicketStatus = 4
+)
+
+// Enum value maps for TicketStatus.
+var (
+	TicketStatus_name = map[int32]string{
+		0: "TICKET_STATUS_UNSPECIFIED",
+		1: "TICKET_STATUS_WAITING",
+		2: "TICKET_STATUS_READY",
+		3: "TICKET_STATUS_COMPLETED",
+		4: "TICKET_STATUS_CANCELLED",
+	}
+	TicketStatus_value = map[string]int32{
+		"TICKET_STATUS_UNSPECIFIED": 0,
+		"TICKET_STATUS_WAITING":     1,
+		"TICKET_STATUS_READY":       2,
+		"TICKET_STATUS_COMPLETED":   3,
+		"TICKET_STATUS_CANCELLED":   4,
+	}
+)
+
+func (x TicketStatus) Enum() *TicketStatus {
+	p := new(TicketStatus)
+	*p = x
+	return p
+}
+
+func (x TicketStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_redduck_v1_queue_proto_enumTypes[0].Descriptor()
+}
+
+func (TicketStatus) Type() protoreflect.EnumType {
+	return &file_redduck_v1_queue_proto_enumTypes[0]
+}
+
+func (x TicketStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketStatus.Descriptor instead.
+func (TicketStatus) EnumDescriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{0}
+}
+
+type CreateQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId    string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateQueueRequest) Reset() {
+	*x = CreateQueueRequest{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQueueRequest) ProtoMessage() {}
+
+func (x *CreateQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQueueRequest.ProtoReflect.Descriptor instead.
+func (*CreateQueueRequest) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateQueueRequest) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *CreateQueueRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+type CreateQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateQueueResponse) Reset() {
+	*x = CreateQueueResponse{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQueueResponse) ProtoMessage() {}
+
+func (x *CreateQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQueueResponse.ProtoReflect.Descriptor instead.
+func (*CreateQueueResponse) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateQueueResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *CreateQueueResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type JoinQueueRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId    string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	// Retrying with the same key returns the same guest and place, but only
+	// the join that added the guest gets their ticket.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// How many guests join on the ticket; 0 is one.
+	PartySize     int32 `protobuf:"varint,4,opt,name=party_size,json=partySize,proto3" json:"party_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinQueueRequest) Reset() {
+	*x = JoinQueueRequest{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinQueueRequest) ProtoMessage() {}
+
+func (x *JoinQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinQueueRequest.ProtoReflect.Descriptor instead.
+func (*JoinQueueRequest) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JoinQueueRequest) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *JoinQueueRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+func (x *JoinQueueRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *JoinQueueRequest) GetPartySize() int32 {
+	if x != nil {
+		return x.PartySize
+	}
+	return 0
+}
+
+type JoinQueueResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	UserId               string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Position             int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	EstimatedWaitMinutes int32                  `protobuf:"varint,3,opt,name=estimated_wait_minutes,json=estimatedWaitMinutes,proto3" json:"estimated_wait_minutes,omitempty"`
+	// The guest's ticket, for LeaveQueue, GetQueueStatus and WatchQueue. A join
+	// replaying an earlier one's idempotency key gets a fresh one.
+	Token         string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinQueueResponse) Reset() {
+	*x = JoinQueueResponse{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinQueueResponse) ProtoMessage() {}
+
+func (x *JoinQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinQueueResponse.ProtoReflect.Descriptor instead.
+func (*JoinQueueResponse) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinQueueResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JoinQueueResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *JoinQueueResponse) GetEstimatedWaitMinutes() int32 {
+	if x != nil {
+		return x.EstimatedWaitMinutes
+	}
+	return 0
+}
+
+func (x *JoinQueueResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LeaveQueueRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId     string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId        string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LeaveQueueRequest) Reset() {
+	*x = LeaveQueueRequest{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveQueueRequest) ProtoMessage() {}
+
+func (x *LeaveQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveQueueRequest.ProtoReflect.Descriptor instead.
+func (*LeaveQueueRequest) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LeaveQueueRequest) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *LeaveQueueRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+func (x *LeaveQueueRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type LeaveQueueResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RemainingUsers int32                  `protobuf:"varint,1,opt,name=remaining_users,json=remainingUsers,proto3" json:"remaining_users,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LeaveQueueResponse) Reset() {
+	*x = LeaveQueueResponse{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveQueueResponse) ProtoMessage() {}
+
+func (x *LeaveQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveQueueResponse.ProtoReflect.Descriptor instead.
+func (*LeaveQueueResponse) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LeaveQueueResponse) GetRemainingUsers() int32 {
+	if x != nil {
+		return x.RemainingUsers
+	}
+	return 0
+}
+
+type GetQueueStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId    string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueStatusRequest) Reset() {
+	*x = GetQueueStatusRequest{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueStatusRequest) ProtoMessage() {}
+
+func (x *GetQueueStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetQueueStatusRequest) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetQueueStatusRequest) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *GetQueueStatusRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+type GetQueueStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *QueueStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueStatusResponse) Reset() {
+	*x = GetQueueStatusResponse{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueStatusResponse) ProtoMessage() {}
+
+func (x *GetQueueStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetQueueStatusResponse) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetQueueStatusResponse) GetStatus() *QueueStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type WatchQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId    string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchQueueRequest) Reset() {
+	*x = WatchQueueRequest{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchQueueRequest) ProtoMessage() {}
+
+func (x *WatchQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchQueueRequest.ProtoReflect.Descriptor instead.
+func (*WatchQueueRequest) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchQueueRequest) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *WatchQueueRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+type WatchQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *QueueStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchQueueResponse) Reset() {
+	*x = WatchQueueResponse{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchQueueResponse) ProtoMessage() {}
+
+func (x *WatchQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchQueueResponse.ProtoReflect.Descriptor instead.
+func (*WatchQueueResponse) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchQueueResponse) GetStatus() *QueueStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type QueueStatus struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId  string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId     string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	QueueLength int32                  `protobuf:"varint,3,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"`
+	// The ticket holder's 1-based place; 0 for staff or once they left or were called.
+	Position int32 `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	// For a ticket holder, the wait of the parties still waiting ahead of them
+	// and their own, 0 once called. For staff, the wait of a guest joining now.
+	EstimatedWaitMinutes int32 `protobuf:"varint,5,opt,name=estimated_wait_minutes,json=estimatedWaitMinutes,proto3" json:"estimated_wait_minutes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *QueueStatus) Reset() {
+	*x = QueueStatus{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatus) ProtoMessage() {}
+
+func (x *QueueStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatus.ProtoReflect.Descriptor instead.
+func (*QueueStatus) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QueueStatus) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *QueueStatus) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+func (x *QueueStatus) GetQueueLength() int32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *QueueStatus) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *QueueStatus) GetEstimatedWaitMinutes() int32 {
+	if x != nil {
+		return x.EstimatedWaitMinutes
+	}
+	return 0
+}
+
+type CallNextRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BusinessId     string                 `protobuf:"bytes,1,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	QueueId        string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	CounterId      string                 `protobuf:"bytes,3,opt,name=counter_id,json=counterId,proto3" json:"counter_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// How many guests the counter or table takes. The call serves the first
+	// waiting party that fits; 0 serves the next waiting ticket whatever its size.
+	Capacity      int32 `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallNextRequest) Reset() {
+	*x = CallNextRequest{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallNextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallNextRequest) ProtoMessage() {}
+
+func (x *CallNextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallNextRequest.ProtoReflect.Descriptor instead.
+func (*CallNextRequest) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CallNextRequest) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *CallNextRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+func (x *CallNextRequest) GetCounterId() string {
+	if x != nil {
+		return x.CounterId
+	}
+	return ""
+}
+
+func (x *CallNextRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CallNextRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type CallNextResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallNextResponse) Reset() {
+	*x = CallNextResponse{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallNextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallNextResponse) ProtoMessage() {}
+
+func (x *CallNextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallNextResponse.ProtoReflect.Descriptor instead.
+func (*CallNextResponse) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CallNextResponse) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+type Ticket struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status TicketStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=redduck.v1.TicketStatus" json:"status,omitempty"`
+	// The counter the guest was called to.
+	AssignedTo string                 `protobuf:"bytes,3,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	JoinedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	// How many guests the ticket stands for.
+	PartySize     int32 `protobuf:"varint,5,opt,name=party_size,json=partySize,proto3" json:"party_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_redduck_v1_queue_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticket) ProtoMessage() {}
+
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_redduck_v1_queue_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_redduck_v1_queue_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Ticket) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Ticket) GetStatus() TicketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TicketStatus_TICKET_STATUS_UNSPECIFIED
+}
+
+func (x *Ticket) GetAssignedTo() string {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return ""
+}
+
+func (x *Ticket) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetPartySize() int32 {
+	if x != nil {
+		return x.PartySize
+	}
+	return 0
+}
+
+var File_redduck_v1_queue_proto protoreflect.FileDescriptor
+
+const file_redduck_v1_queue_proto_rawDesc = "" +
+	"\n" +
+	"\x16redduck/v1/queue.proto\x12\n" +
+	"redduck.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"P\n" +
+	"\x12CreateQueueRequest\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\"M\n" +
+	"\x13CreateQueueResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\"\x96\x01\n" +
+	"\x10JoinQueueRequest\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"party_size\x18\x04 \x01(\x05R\tpartySize\"\x94\x01\n" +
+	"\x11JoinQueueResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x124\n" +
+	"\x16estimated_wait_minutes\x18\x03 \x01(\x05R\x14estimatedWaitMinutes\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"x\n" +
+	"\x11LeaveQueueRequest\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"=\n" +
+	"\x12LeaveQueueResponse\x12'\n" +
+	"\x0fremaining_users\x18\x01 \x01(\x05R\x0eremainingUsers\"S\n" +
+	"\x15GetQueueStatusRequest\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\"I\n" +
+	"\x16GetQueueStatusResponse\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.redduck.v1.QueueStatusR\x06status\"O\n" +
+	"\x11WatchQueueRequest\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\"E\n" +
+	"\x12WatchQueueResponse\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.redduck.v1.QueueStatusR\x06status\"\xbe\x01\n" +
+	"\vQueueStatus\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\x12!\n" +
+	"\fqueue_length\x18\x03 \x01(\x05R\vqueueLength\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x124\n" +
+	"\x16estimated_wait_minutes\x18\x05 \x01(\x05R\x14estimatedWaitMinutes\"\xb1\x01\n" +
+	"\x0fCallNextRequest\x12\x1f\n" +
+	"\vbusiness_id\x18\x01 \x01(\tR\n" +
+	"businessId\x12\x19\n" +
+	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\x12\x1d\n" +
+	"\n" +
+	"counter_id\x18\x03 \x01(\tR\tcounterId\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\">\n" +
+	"\x10CallNextResponse\x12*\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x12.redduck.v1.TicketR\x06ticket\"\xcc\x01\n" +
+	"\x06Ticket\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.redduck.v1.TicketStatusR\x06status\x12\x1f\n" +
+	"\vassigned_to\x18\x03 \x01(\tR\n" +
+	"assignedTo\x127\n" +
+	"\tjoined_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\x12\x1d\n" +
+	"\n" +
+	"party_size\x18\x05 \x01(\x05R\tpartySize*\x9b\x01\n" +
+	"\fTicketStatus\x12\x1d\n" +
+	"\x19TICKET_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15TICKET_STATUS_WAITING\x10\x01\x12\x17\n" +
+	"\x13TICKET_STATUS_READY\x10\x02\x12\x1b\n" +
+	"\x17TICKET_STATUS_COMPLETED\x10\x03\x12\x1b\n" +
+	"\x17TICKET_STATUS_CANCELLED\x10\x042\xe4\x03\n" +
+	"\fQueueService\x12N\n" +
+	"\vCreateQueue\x12\x1e.redduck.v1.CreateQueueRequest\x1a\x1f.redduck.v1.CreateQueueResponse\x12H\n" +
+	"\tJoinQueue\x12\x1c.redduck.v1.JoinQueueRequest\x1a\x1d.redduck.v1.JoinQueueResponse\x12K\n" +
+	"\n" +
+	"LeaveQueue\x12\x1d.redduck.v1.LeaveQueueRequest\x1a\x1e.redduck.v1.LeaveQueueResponse\x12W\n" +
+	"\x0eGetQueueStatus\x12!.redduck.v1.GetQueueStatusRequest\x1a\".redduck.v1.GetQueueStatusResponse\x12E\n" +
+	"\bCallNext\x12\x1b.redduck.v1.CallNextRequest\x1a\x1c.redduck.v1.CallNextResponse\x12M\n" +
+	"\n" +
+	"WatchQueue\x12\x1d.redduck.v1.WatchQueueRequest\x1a\x1e.redduck.v1.WatchQueueResponse0\x01B)Z'red-duck/api/proto/redduck/v1;redduckv1b\x06proto3"
+
+var (
+	file_redduck_v1_queue_proto_rawDescOnce sync.Once
+	file_redduck_v1_queue_proto_rawDescData []byte
+)
+
+func file_redduck_v1_queue_proto_rawDescGZIP() []byte {
+	file_redduck_v1_queue_proto_rawDescOnce.Do(func() {
+		file_redduck_v1_queue_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_redduck_v1_queue_proto_rawDesc), len(file_redduck_v1_queue_proto_rawDesc)))
+	})
+	return file_redduck_v1_queue_proto_rawDescData
+}
+
+var file_redduck_v1_queue_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_redduck_v1_queue_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_redduck_v1_queue_proto_goTypes = []any{
+	(TicketStatus)(0),              // 0: redduck.v1.TicketStatus
+	(*CreateQueueRequest)(nil),     // 1: redduck.v1.CreateQueueRequest
+	(*CreateQueueResponse)(nil),    // 2: redduck.v1.CreateQueueResponse
+	(*JoinQueueRequest)(nil),       // 3: redduck.v1.JoinQueueRequest
+	(*JoinQueueResponse)(nil),      // 4: redduck.v1.JoinQueueResponse
+	(*LeaveQueueRequest)(nil),      // 5: redduck.v1.LeaveQueueRequest
+	(*LeaveQueueResponse)(nil),     // 6: redduck.v1.LeaveQueueResponse
+	(*GetQueueStatusRequest)(nil),  // 7: redduck.v1.GetQueueStatusRequest
+	(*GetQueueStatusResponse)(nil), // 8: redduck.v1.GetQueueStatusResponse
+	(*WatchQueueRequest)(nil),      // 9: redduck.v1.WatchQueueRequest
+	(*WatchQueueResponse)(nil),     // 10: redduck.v1.WatchQueueResponse
+	(*QueueStatus)(nil),            // 11: redduck.v1.QueueStatus
+	(*CallNextRequest)(nil),        // 12: redduck.v1.CallNextRequest
+	(*CallNextResponse)(nil),       // 13: redduck.v1.CallNextResponse
+	(*Ticket)(nil),                 // 14: redduck.v1.Ticket
+	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
+}
+var file_redduck_v1_queue_proto_depIdxs = []int32{
+	11, // 0: redduck.v1.GetQueueStatusResponse.status:type_name -> redduck.v1.QueueStatus
+	11, // 1: redduck.v1.WatchQueueResponse.status:type_name -> redduck.v1.QueueStatus
+	14, // 2: redduck.v1.CallNextResponse.ticket:type_name -> redduck.v1.Ticket
+	0,  // 3: redduck.v1.Ticket.status:type_name -> redduck.v1.TicketStatus
+	15, // 4: redduck.v1.Ticket.joined_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: redduck.v1.QueueService.CreateQueue:input_type -> redduck.v1.CreateQueueRequest
+	3,  // 6: redduck.v1.QueueService.JoinQueue:input_type -> redduck.v1.JoinQueueRequest
+	5,  // 7: redduck.v1.QueueService.LeaveQueue:input_type -> redduck.v1.LeaveQueueRequest
+	7,  // 8: redduck.v1.QueueService.GetQueueStatus:input_type -> redduck.v1.GetQueueStatusRequest
+	12, // 9: redduck.v1.QueueService.CallNext:input_type -> redduck.v1.CallNextRequest
+	9,  // 10: redduck.v1.QueueService.WatchQueue:input_type -> redduck.v1.WatchQueueRequest
+	2,  // 11: redduck.v1.QueueService.CreateQueue:output_type -> redduck.v1.CreateQueueResponse
+	4,  // 12: redduck.v1.QueueService.JoinQueue:output_type -> redduck.v1.JoinQueueResponse
+	6,  // 13: redduck.v1.QueueService.LeaveQueue:output_type -> redduck.v1.LeaveQueueResponse
+	8,  // 14: redduck.v1.QueueService.GetQueueStatus:output_type -> redduck.v1.GetQueueStatusResponse
+	13, // 15: redduck.v1.QueueService.CallNext:output_type -> redduck.v1.CallNextResponse
+	10, // 16: redduck.v1.QueueService.WatchQueue:output_type -> redduck.v1.WatchQueueResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_redduck_v1_queue_proto_init() }
+func file_redduck_v1_queue_proto_init() {
+	if File_redduck_v1_queue_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redduck_v1_queue_proto_rawDesc), len(file_redduck_v1_queue_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_redduck_v1_queue_proto_goTypes,
+		DependencyIndexes: file_redduck_v1_queue_proto_depIdxs,
+		EnumInfos:         file_redduck_v1_queue_proto_enumTypes,
+		MessageInfos:      file_redduck_v1_queue_proto_msgTypes,
+	}.Build()
+	File_redduck_v1_queue_proto = out.File
+	file_redduck_v1_queue_proto_goTypes = nil
+	file_redduck_v1_queue_proto_depIdxs = nil
+}
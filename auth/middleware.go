@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"red-duck/internal/pkg/actor"
+	"red-duck/internal/pkg/problem"
 )
 
 type key int
@@ -41,41 +44,49 @@ func WithAuth(next http.HandlerFunc) http.HandlerFunc {
 		// 1. Extract Header
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Missing Authorization header", http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, "Missing Authorization header")
 			return
 		}
 
 		// 2. Validate Format
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, "Invalid Authorization header format")
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
 		// 3. Parse Token
-		token, err := jwt.ParseWithClaims(tokenString, &RedDuckClaims{}, func(token *jwt.Token) (interface{}, error) {
-			// Validate the alg is what we expect:
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte("red-duck-secret-key-2026"), nil
-		})
-
+		claims, err := ParseToken(strings.TrimPrefix(authHeader, "Bearer "))
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid token: %v", err), http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, fmt.Sprintf("Invalid token: %v", err))
 			return
 		}
 
-		// 4. Verify
-		if claims, ok := token.Claims.(*RedDuckClaims); ok && token.Valid {
-			// 5. Context Injection
-			ctx := context.WithValue(r.Context(), UserKey, claims.UserID)
-			ctx = context.WithValue(ctx, RoleKey, claims.Role)
-			ctx = context.WithValue(ctx, BusinessIDKey, claims.BusinessID)
-			next(w, r.WithContext(ctx))
-		} else {
-			http.Error(w, "Invalid token claims", http.StatusUnauthorized)
+		// 4. Context Injection
+		next(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+	}
+}
+
+// ParseToken validates a staff token and returns its claims
+func ParseToken(tokenString string) (*RedDuckClaims, error) {
+	claims := &RedDuckClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// Validate the alg is what we expect:
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
+		return []byte("red-duck-secret-key-2026"), nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return claims, nil
+}
+
+// ContextWithClaims returns ctx carrying the caller identified by a staff token,
+// and as the actor of the workflow updates it sends.
+func ContextWithClaims(ctx context.Context, claims *RedDuckClaims) context.Context {
+	ctx = actor.With(ctx, actor.Actor{UserID: claims.UserID, Role: claims.Role, BusinessID: claims.BusinessID})
+	ctx = context.WithValue(ctx, UserKey, claims.UserID)
+	ctx = context.WithValue(ctx, RoleKey, claims.Role)
+	return context.WithValue(ctx, BusinessIDKey, claims.BusinessID)
 }
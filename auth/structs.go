@@ -4,6 +4,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Roles of staff users. Admins own their business and may read its audit log.
+const (
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+)
+
 // User Struct
 type User struct {
 	ID         string `json:"id"`
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"red-duck/internal/pkg/problem"
+)
+
+// ticketTTL bounds how long a guest can act on their place: one queue visit.
+const ticketTTL = 4 * time.Hour
+
+// ticketKey signs tickets. It differs from the session key so a ticket can
+// never pass WithAuth as a staff token, and the other way round.
+var ticketKey = []byte("red-duck-ticket-key-2026")
+
+// ErrTicketMismatch is returned when a ticket is used on another business or queue.
+var ErrTicketMismatch = errors.New("ticket is for another queue")
+
+// TicketClaims Struct
+type TicketClaims struct {
+	jwt.RegisteredClaims
+	UserID     string `json:"user_id"`
+	BusinessID string `json:"business_id"`
+	QueueID    string `json:"queue_id"`
+}
+
+// GenerateTicket creates a signed ticket for a guest's place in a queue
+func GenerateTicket(businessID, queueID, userID string) (string, error) {
+	return GenerateTicketUntil(businessID, queueID, userID, time.Now().Add(ticketTTL))
+}
+
+// GenerateTicketUntil is GenerateTicket for a ticket that expires at
+// expiresAt, such as one for an appointment days ahead.
+func GenerateTicketUntil(businessID, queueID, userID string, expiresAt time.Time) (string, error) {
+	claims := TicketClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		UserID:     userID,
+		BusinessID: businessID,
+		QueueID:    queueID,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(ticketKey)
+}
+
+// ParseTicket validates a ticket and returns its claims
+func ParseTicket(tokenString string) (*TicketClaims, error) {
+	claims := &TicketClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return ticketKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claims.UserID == "" || claims.BusinessID == "" || claims.QueueID == "" {
+		return nil, errors.New("incomplete ticket claims")
+	}
+	return claims, nil
+}
+
+// Matches reports an error unless the ticket is for the given queue. Empty IDs
+// are not checked.
+func (c *TicketClaims) Matches(businessID, queueID string) error {
+	if (businessID != "" && businessID != c.BusinessID) || (queueID != "" && queueID != c.QueueID) {
+		return ErrTicketMismatch
+	}
+	return nil
+}
+
+type ticketCtxKey struct{}
+
+// GetTicket retrieves the ticket claims from the context
+func GetTicket(ctx context.Context) (*TicketClaims, bool) {
+	claims, ok := ctx.Value(ticketCtxKey{}).(*TicketClaims)
+	return claims, ok
+}
+
+// WithTicket is a middleware that requires a guest ticket as the bearer token.
+// The ticket's user also becomes the request's UserID.
+func WithTicket(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			problem.Write(w, http.StatusUnauthorized, "Missing Authorization header")
+			return
+		}
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			problem.Write(w, http.StatusUnauthorized, "Invalid Authorization header format")
+			return
+		}
+
+		claims, err := ParseTicket(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			problem.Write(w, http.StatusUnauthorized, fmt.Sprintf("Invalid ticket: %v", err))
+			return
+		}
+
+		next(w, r.WithContext(ContextWithTicket(r.Context(), claims)))
+	}
+}
+
+// WithAuthOrTicket serves requests bearing a staff token with staff and all
+// others with guest, behind WithTicket. It lets staff and guests share a route.
+func WithAuthOrTicket(staff, guest http.HandlerFunc) http.HandlerFunc {
+	guest = WithTicket(guest)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			if claims, err := ParseToken(token); err == nil {
+				staff(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+				return
+			}
+		}
+		guest(w, r)
+	}
+}
+
+// ContextWithTicket returns ctx carrying a guest's ticket. The ticket's user
+// also becomes the UserID.
+func ContextWithTicket(ctx context.Context, claims *TicketClaims) context.Context {
+	ctx = context.WithValue(ctx, ticketCtxKey{}, claims)
+	return context.WithValue(ctx, UserKey, claims.UserID)
+}
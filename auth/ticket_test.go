@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTicket(t *testing.T) {
+	ticket, err := GenerateTicket("biz_123", "main", "guest-1")
+	assert.NoError(t, err)
+
+	claims, err := ParseTicket(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "guest-1", claims.UserID)
+	assert.Equal(t, "biz_123", claims.BusinessID)
+	assert.Equal(t, "main", claims.QueueID)
+
+	assert.NoError(t, claims.Matches("biz_123", "main"))
+	assert.NoError(t, claims.Matches("", ""))
+	assert.ErrorIs(t, claims.Matches("biz_123", "other"), ErrTicketMismatch)
+	assert.ErrorIs(t, claims.Matches("biz_999", "main"), ErrTicketMismatch)
+}
+
+func TestTicketAndSessionTokensAreNotInterchangeable(t *testing.T) {
+	ticket, err := GenerateTicket("biz_123", "main", "guest-1")
+	assert.NoError(t, err)
+	session, err := GenerateToken(t.Context(), User{ID: "staff-1", Role: "admin", BusinessID: "biz_123"})
+	assert.NoError(t, err)
+
+	_, err = ParseTicket(session)
+	assert.Error(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/queues/main/call-next", nil)
+	req.Header.Set("Authorization", "Bearer "+ticket)
+	rr := httptest.NewRecorder()
+	WithAuth(func(w http.ResponseWriter, r *http.Request) {})(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestWithTicket(t *testing.T) {
+	var gotUser string
+	var gotTicket *TicketClaims
+	handler := WithTicket(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = GetUserID(r.Context())
+		gotTicket, _ = GetTicket(r.Context())
+	})
+
+	t.Run("Valid Ticket", func(t *testing.T) {
+		ticket, _ := GenerateTicket("biz_123", "main", "guest-1")
+		req := httptest.NewRequest(http.MethodPost, "/leave_queue", nil)
+		req.Header.Set("Authorization", "Bearer "+ticket)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "guest-1", gotUser)
+		assert.Equal(t, "main", gotTicket.QueueID)
+	})
+
+	t.Run("No Header", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler(rr, httptest.NewRequest(http.MethodPost, "/leave_queue", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Tampered Ticket", func(t *testing.T) {
+		ticket, _ := GenerateTicket("biz_123", "main", "guest-1")
+		req := httptest.NewRequest(http.MethodPost, "/leave_queue", nil)
+		req.Header.Set("Authorization", "Bearer "+ticket+"x")
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestWithAuthOrTicket(t *testing.T) {
+	var served string
+	handler := WithAuthOrTicket(
+		func(w http.ResponseWriter, r *http.Request) {
+			served, _ = GetUserID(r.Context())
+		},
+		func(w http.ResponseWriter, r *http.Request) {
+			ticket, _ := GetTicket(r.Context())
+			served = "guest:" + ticket.UserID
+		},
+	)
+	ticket, _ := GenerateTicket("biz_123", "main", "guest-1")
+	session, _ := GenerateToken(t.Context(), User{ID: "staff-1", Role: "admin", BusinessID: "biz_123"})
+
+	for token, want := range map[string]string{session: "staff-1", ticket: "guest:guest-1"} {
+		served = ""
+		req := httptest.NewRequest(http.MethodDelete, "/v1/businesses/biz_123/queues/main/tickets/guest-1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, want, served)
+	}
+
+	served = ""
+	req := httptest.NewRequest(http.MethodDelete, "/v1/businesses/biz_123/queues/main/tickets/guest-1", nil)
+	req.Header.Set("Authorization", "Bearer garbage")
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Empty(t, served)
+}
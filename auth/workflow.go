@@ -7,8 +7,21 @@ import (
 	"time"
 
 	"go.temporal.io/sdk/workflow"
+
+	"red-duck/internal/pkg/update"
 )
 
+// SignalSubmitCode delivers the code the user typed in to LoginWorkflow.
+const SignalSubmitCode = "SubmitCode"
+
+// SubmitCodeSignal is the contract of SignalSubmitCode.
+var SubmitCodeSignal = update.NewSignal[string](SignalSubmitCode)
+
+// LoginWorkflowID is the ID of the login workflow for an email address.
+func LoginWorkflowID(email string) string {
+	return "auth-" + email
+}
+
 // LoginWorkflow orchestrates the login process
 func LoginWorkflow(ctx workflow.Context, email string) (string, error) {
 	// 1. Setup ActivityOptions
@@ -38,13 +51,11 @@ func LoginWorkflow(ctx workflow.Context, email string) (string, error) {
 
 	// 4. Wait for User Input (The Signal)
 	var userCode string
-	signalChan := workflow.GetSignalChannel(ctx, "SubmitCode")
-
 	selector := workflow.NewSelector(ctx)
 
 	// Case 1: Signal Received
-	selector.AddReceive(signalChan, func(c workflow.ReceiveChannel, more bool) {
-		c.Receive(ctx, &userCode)
+	SubmitCodeSignal.AddToSelector(ctx, selector, func(code string) {
+		userCode = code
 	})
 
 	// Case 2: Timeout
@@ -69,7 +80,7 @@ func LoginWorkflow(ctx workflow.Context, email string) (string, error) {
 	user := User{
 		ID:    "test-user-id",
 		Email: email,
-		Role:  "admin",
+		Role:  RoleAdmin,
 	}
 
 	var token string
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"red-duck/analytics"
+	"red-duck/auth"
+	"red-duck/db"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/core/ports"
+)
+
+func (a *app) eventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Work with analytics events",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "replay FILE",
+		Short: "Ingest newline-delimited JSON events into the analytics database",
+		Long: "Ingest newline-delimited JSON events, as published on events.>, into the analytics " +
+			"database. Reads standard input when FILE is -. Stops at the first event that fails.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			cfg, err := a.config()
+			if err != nil {
+				return err
+			}
+			pool, err := db.NewPool(cmd.Context(), cfg.Database.URL)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			n, err := replayEvents(cmd, in, analytics.NewPostgresRepository(pool))
+			if err != nil {
+				return err
+			}
+			result := map[string]int{"replayed": n}
+			return a.print(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Replayed %d events\n", n)
+			})
+		},
+	})
+	return cmd
+}
+
+// replayEvents feeds each non-blank line of in to the analytics consumer.
+func replayEvents(cmd *cobra.Command, in io.Reader, repo analytics.EventRepository) (int, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	n, line := 0, 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if err := analytics.ProcessMessage(cmd.Context(), data, repo); err != nil {
+			return n, fmt.Errorf("line %d: %w", line, err)
+		}
+		n++
+	}
+	return n, scanner.Err()
+}
+
+func (a *app) auditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Work with the audit log of staff changes",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "verify BUSINESS_ID",
+		Short: "Check the business's whole audit log against its hash chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := a.config()
+			if err != nil {
+				return err
+			}
+			pool, err := db.NewPool(cmd.Context(), cfg.Database.URL)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			n, err := verifyAuditLog(cmd.Context(), analytics.NewPostgresRepository(pool), args[0])
+			if err != nil {
+				return err
+			}
+			result := map[string]any{"business_id": args[0], "entries": n, "verified": true}
+			return a.print(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Verified %d audit log entries of %s\n", n, args[0])
+			})
+		},
+	})
+	return cmd
+}
+
+// verifyAuditLog walks the business's audit log from its first entry,
+// checking each page and the link between pages, and returns how many
+// entries it checked.
+func verifyAuditLog(ctx context.Context, log ports.AuditLogRepository, businessID string) (int, error) {
+	const pageSize = 500
+	q := domain.AuditQuery{BusinessID: businessID, Limit: pageSize}
+	n, prevHash := 0, ""
+	for {
+		entries, err := log.ListAuditEntries(ctx, q)
+		if err != nil {
+			return n, err
+		}
+		if len(entries) == 0 {
+			return n, nil
+		}
+		if entries[0].PrevHash != prevHash {
+			return n, fmt.Errorf("%w: entry %d does not follow the entry before it", domain.ErrAuditChainBroken, entries[0].ID)
+		}
+		if err := domain.VerifyAuditChain(entries); err != nil {
+			return n, err
+		}
+		n += len(entries)
+		last := entries[len(entries)-1]
+		prevHash, q.AfterID = last.Hash, last.ID
+		if len(entries) < pageSize {
+			return n, nil
+		}
+	}
+}
+
+func (a *app) migrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending analytics database migrations from " + db.MigrationsDir,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := a.config()
+			if err != nil {
+				return err
+			}
+			if err := db.Migrate(cfg.Database.URL); err != nil {
+				return err
+			}
+			version, err := db.LatestMigration(db.MigrationsDir)
+			if err != nil {
+				return err
+			}
+			result := map[string]uint{"version": version}
+			return a.print(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Schema at version %d\n", version)
+			})
+		},
+	}
+}
+
+func (a *app) tokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Mint development tokens",
+	}
+
+	var user auth.User
+	staff := &cobra.Command{
+		Use:   "staff --business BUSINESS_ID",
+		Short: "Mint a staff session JWT",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := auth.GenerateToken(cmd.Context(), user)
+			if err != nil {
+				return err
+			}
+			return a.printToken(token)
+		},
+	}
+	staff.Flags().StringVar(&user.BusinessID, "business", "", "business the staff member works for")
+	staff.Flags().StringVar(&user.ID, "user", "dev-staff", "staff user ID")
+	staff.Flags().StringVar(&user.Email, "email", "dev@redduck.local", "staff email")
+	staff.Flags().StringVar(&user.Role, "role", auth.RoleStaff, "staff role")
+	_ = staff.MarkFlagRequired("business")
+
+	ticket := &cobra.Command{
+		Use:   "ticket BUSINESS_ID QUEUE_ID USER_ID",
+		Short: "Mint a guest ticket",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := auth.GenerateTicket(args[0], args[1], args[2])
+			if err != nil {
+				return err
+			}
+			return a.printToken(token)
+		},
+	}
+
+	cmd.AddCommand(staff, ticket)
+	return cmd
+}
+
+func (a *app) printToken(token string) error {
+	return a.print(map[string]string{"token": token}, func(w io.Writer) {
+		fmt.Fprintln(w, token)
+	})
+}
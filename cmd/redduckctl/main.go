@@ -0,0 +1,144 @@
+// Command redduckctl administers Red Duck queues. Queue lifecycle and
+// ticket commands go to Temporal, staff actions go through the API server, and
+// both are configured from the same application.yaml as the services.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+
+	apiclient "red-duck/api/client"
+	"red-duck/auth"
+	"red-duck/internal/adapters/config"
+	"red-duck/internal/adapters/logging"
+	"red-duck/internal/pkg/actor"
+	"red-duck/internal/pkg/requestid"
+)
+
+func main() {
+	if err := newRootCmd(os.Stdout).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// app holds what the commands share. Config and clients are created on first
+// use, so commands that need neither, like token, run without a config file.
+type app struct {
+	out    io.Writer
+	output string
+	token  string
+
+	cfg      *config.Config
+	temporal client.Client
+}
+
+func newRootCmd(out io.Writer) *cobra.Command {
+	a := &app{out: out}
+
+	root := &cobra.Command{
+		Use:          "redduckctl",
+		Short:        "Administer Red Duck queues",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if a.output != outputTable && a.output != outputJSON {
+				return fmt.Errorf("unknown output %q: want %s or %s", a.output, outputTable, outputJSON)
+			}
+			// Changes made here go to the audit log as an admin named redduckctl
+			cmd.SetContext(actor.With(cmd.Context(), actor.Actor{UserID: staffID, Role: auth.RoleAdmin}))
+			return nil
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if a.temporal != nil {
+				a.temporal.Close()
+			}
+		},
+	}
+	root.SetOut(out)
+	root.PersistentFlags().StringVarP(&a.output, "output", "o", outputTable, "output format: table or json")
+	root.PersistentFlags().StringVar(&a.token, "token", "", "staff JWT for the API server (default api.token)")
+
+	root.AddCommand(
+		a.queueCmd(),
+		a.ticketCmd(),
+		a.eventsCmd(),
+		a.auditCmd(),
+		a.migrateCmd(),
+		a.tokenCmd(),
+	)
+	return root
+}
+
+func (a *app) config() (*config.Config, error) {
+	if a.cfg != nil {
+		return a.cfg, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	logging.Setup(cfg.Log)
+	a.cfg = cfg
+	return cfg, nil
+}
+
+func (a *app) temporalClient() (client.Client, error) {
+	if a.temporal != nil {
+		return a.temporal, nil
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.Dial(client.Options{
+		HostPort: fmt.Sprintf("%s:%d", cfg.Temporal.Host, cfg.Temporal.Port),
+		Logger:   logging.TemporalLogger(logging.New(cfg.Log)),
+		ContextPropagators: []workflow.ContextPropagator{
+			requestid.NewContextPropagator(),
+			actor.NewContextPropagator(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to Temporal: %w", err)
+	}
+	a.temporal = c
+	return c, nil
+}
+
+// apiClient returns a client for the API server authenticated as staff.
+func (a *app) apiClient() (*apiclient.ClientWithResponses, error) {
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	token := a.token
+	if token == "" {
+		token = cfg.API.Token
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no API token: set api.token, API_TOKEN or --token (see redduckctl token staff)")
+	}
+	return apiclient.NewClientWithResponses(cfg.API.URL,
+		apiclient.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}),
+	)
+}
+
+// apiError describes a failed API call, preferring the problem details.
+func apiError(status string, p *apiclient.Problem) error {
+	if p == nil {
+		return fmt.Errorf("API server replied %s", status)
+	}
+	if p.Detail != nil {
+		return fmt.Errorf("%s (%s): %s", p.Title, p.Code, *p.Detail)
+	}
+	return fmt.Errorf("%s (%s)", p.Title, p.Code)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"red-duck/analytics"
+	"red-duck/internal/adapters/config"
+	"red-duck/internal/core/domain"
+)
+
+type recordingRepository struct {
+	events []analytics.EventPayload
+}
+
+func (r *recordingRepository) InsertEvent(ctx context.Context, payload analytics.EventPayload) error {
+	r.events = append(r.events, payload)
+	return nil
+}
+
+func TestPrintQueue(t *testing.T) {
+	q := domain.Queue{
+		ID:         "q1",
+		BusinessID: "biz_123",
+		Closed:     true,
+		Tickets: []domain.Ticket{
+			{UserID: "u1", Status: domain.TicketStatusReady, AssignedTo: "Counter 3", JoinedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+			{UserID: "u2", Status: domain.TicketStatusWaiting, JoinedAt: time.Date(2026, 3, 1, 9, 5, 0, 0, time.UTC)},
+		},
+	}
+
+	t.Run("Table", func(t *testing.T) {
+		var out bytes.Buffer
+		a := &app{out: &out, output: outputTable}
+		require.NoError(t, a.printQueue(q))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, "Queue biz_123/q1 (closed, 2 tickets)", lines[0])
+		assert.Equal(t, []string{"1", "u1", "READY", "Counter", "3", "2026-03-01T09:00:00Z"}, strings.Fields(lines[2]))
+		assert.Equal(t, []string{"2", "u2", "WAITING", "-", "2026-03-01T09:05:00Z"}, strings.Fields(lines[3]))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		a := &app{out: &out, output: outputJSON}
+		require.NoError(t, a.printQueue(q))
+
+		var got domain.Queue
+		require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+		assert.Equal(t, q, got)
+	})
+}
+
+func TestRootCmd_RejectsUnknownOutput(t *testing.T) {
+	cmd := newRootCmd(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"token", "ticket", "biz_123", "q1", "u1", "-o", "yaml"})
+	assert.ErrorContains(t, cmd.Execute(), `unknown output "yaml"`)
+}
+
+func TestReplayEvents(t *testing.T) {
+	in := strings.NewReader(`{"type":"queue.joined","business_id":"biz_123","user_id":"u1","timestamp":"2026-03-01T09:00:00Z"}
+
+{"type":"queue.left","business_id":"biz_123","user_id":"u1","timestamp":"2026-03-01T09:10:00Z"}
+not json
+`)
+	repo := &recordingRepository{}
+	cmd := newRootCmd(&bytes.Buffer{})
+
+	n, err := replayEvents(cmd, in, repo)
+	assert.ErrorContains(t, err, "line 4")
+	assert.Equal(t, 2, n)
+	require.Len(t, repo.events, 2)
+	assert.Equal(t, "queue.left", repo.events[1].Type)
+}
+
+func TestQueueCreate_UsesAPIToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1/businesses/biz_123/queues/q1", r.URL.Path)
+		assert.Equal(t, "Bearer staff-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"workflow_id": "biz_123:q1", "run_id": "run-1"})
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	a := &app{
+		out:    &out,
+		output: outputTable,
+		cfg:    &config.Config{API: config.APIConfig{URL: srv.URL, Token: "staff-token"}},
+	}
+	cmd := a.queueCreateCmd()
+	cmd.SetArgs([]string{"biz_123", "q1"})
+	cmd.SetContext(context.Background())
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"WORKFLOW", "RUN", "biz_123:q1", "run-1"}, strings.Fields(out.String()))
+}
+
+type auditLog struct {
+	entries []domain.AuditEntry
+}
+
+func (l *auditLog) AppendAuditEntry(ctx context.Context, key string, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	panic("not used")
+}
+
+func (l *auditLog) ListAuditEntries(ctx context.Context, q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	var page []domain.AuditEntry
+	for _, e := range l.entries {
+		if e.ID > q.AfterID && len(page) < q.Limit {
+			page = append(page, e)
+		}
+	}
+	return page, nil
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	log := &auditLog{}
+	prev := ""
+	for i := 1; i <= 3; i++ {
+		e := domain.AuditEntry{ID: int64(i), BusinessID: "biz_123", ActorID: "staff-1", Action: domain.AuditCallNext}
+		require.NoError(t, e.Chain(prev))
+		prev = e.Hash
+		log.entries = append(log.entries, e)
+	}
+
+	n, err := verifyAuditLog(t.Context(), log, "biz_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	// Deleting the first entry leaves the rest linking to nothing
+	log.entries = log.entries[1:]
+	_, err = verifyAuditLog(t.Context(), log, "biz_123")
+	assert.ErrorIs(t, err, domain.ErrAuditChainBroken)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"red-duck/internal/core/domain"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// print writes v as indented JSON, or lets table lay it out in aligned,
+// tab-separated columns.
+func (a *app) print(v any, table func(w io.Writer)) error {
+	if a.output == outputJSON {
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	tw := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+// printQueue prints a queue snapshot with one row per ticket.
+func (a *app) printQueue(q domain.Queue) error {
+	return a.print(q, func(w io.Writer) {
+		state := "open"
+		if q.Closed {
+			state = "closed"
+		}
+		fmt.Fprintf(w, "Queue %s/%s (%s, %d tickets)\n", q.BusinessID, q.ID, state, len(q.Tickets))
+		fmt.Fprintln(w, "POS\tUSER\tSTATUS\tCOUNTER\tJOINED")
+		for i, t := range q.Tickets {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, t.UserID, t.Status, dash(t.AssignedTo), t.JoinedAt.Format(time.RFC3339))
+		}
+	})
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
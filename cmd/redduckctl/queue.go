@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	apiclient "red-duck/api/client"
+	"red-duck/internal/adapters/secondary"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+func (a *app) queueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Create, inspect and run queues",
+	}
+	cmd.AddCommand(
+		a.queueCreateCmd(),
+		a.queueSetOpenCmd("open", true),
+		a.queueSetOpenCmd("close", false),
+		a.queueDeleteCmd(),
+		a.queueListCmd(),
+		a.queueStateCmd(),
+		a.queueCallNextCmd(),
+	)
+	return cmd
+}
+
+func (a *app) queueCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create BUSINESS_ID QUEUE_ID",
+		Short: "Start a queue through the API server",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, err := a.apiClient()
+			if err != nil {
+				return err
+			}
+			resp, err := api.CreateQueueWithResponse(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if resp.JSON201 == nil {
+				return apiError(resp.Status(), firstProblem(resp.ApplicationproblemJSON401, resp.ApplicationproblemJSON403, resp.ApplicationproblemJSONDefault))
+			}
+			created := resp.JSON201
+			return a.print(created, func(w io.Writer) {
+				fmt.Fprintln(w, "WORKFLOW\tRUN")
+				fmt.Fprintf(w, "%s\t%s\n", created.WorkflowId, created.RunId)
+			})
+		},
+	}
+}
+
+func (a *app) queueSetOpenCmd(use string, open bool) *cobra.Command {
+	short := "Stop taking new joins; waiting tickets are still served"
+	if open {
+		short = "Take new joins again"
+	}
+	return &cobra.Command{
+		Use:   use + " BUSINESS_ID QUEUE_ID",
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			q, err := workflows.SetOpenUpdate.Execute(cmd.Context(), c, workflows.QueueWorkflowID(args[0], args[1]), open)
+			if err != nil {
+				return err
+			}
+			return a.printQueue(q)
+		},
+	}
+}
+
+func (a *app) queueDeleteCmd() *cobra.Command {
+	var reason string
+	cmd := &cobra.Command{
+		Use:   "delete BUSINESS_ID QUEUE_ID",
+		Short: "Shut a queue down and print its final report",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			queues := secondary.NewTemporalQueueClient(c, a.cfg.Temporal.TaskQueue)
+			report, err := queues.DeleteQueue(cmd.Context(), args[0], args[1], reason, "redduckctl")
+			if err != nil {
+				return err
+			}
+			return a.print(report, func(w io.Writer) {
+				fmt.Fprintln(w, "QUEUE\tSERVED\tABANDONED\tCANCELLED\tAVG WAIT")
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.0fs\n", report.QueueID, report.Served, report.Abandoned, report.Cancelled, report.AvgWaitSeconds)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "deleted by redduckctl", "why the queue is shut down, sent to cancelled guests")
+	return cmd
+}
+
+func (a *app) queueListCmd() *cobra.Command {
+	var businessID, state string
+	var pageSize int
+	cmd := &cobra.Command{
+		Use:   "list --business BUSINESS_ID",
+		Short: "List a business's running queues",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if state != "" && !domain.QueueState(state).Valid() {
+				return fmt.Errorf("state must be %s or %s", domain.QueueStateOpen, domain.QueueStateClosed)
+			}
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			queues := secondary.NewTemporalQueueClient(c, a.cfg.Temporal.TaskQueue)
+
+			all := []domain.QueueSummary{}
+			var token []byte
+			for {
+				page, next, err := queues.ListQueues(cmd.Context(), businessID, domain.QueueState(state), pageSize, token)
+				if err != nil {
+					return err
+				}
+				all = append(all, page...)
+				if len(next) == 0 {
+					break
+				}
+				token = next
+			}
+
+			return a.print(all, func(w io.Writer) {
+				fmt.Fprintln(w, "QUEUE\tSTATE\tLENGTH")
+				for _, q := range all {
+					fmt.Fprintf(w, "%s\t%s\t%d\n", q.ID, q.State, q.Length)
+				}
+			})
+		},
+	}
+	cmd.Flags().StringVar(&businessID, "business", "", "business whose queues to list")
+	cmd.Flags().StringVar(&state, "state", "", "only list OPEN or CLOSED queues")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "queues fetched per request")
+	_ = cmd.MarkFlagRequired("business")
+	return cmd
+}
+
+func (a *app) queueStateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "state BUSINESS_ID QUEUE_ID",
+		Short: "Dump a queue's state",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			q, err := workflows.GetStatusQuery.Execute(cmd.Context(), c, workflows.QueueWorkflowID(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+			return a.printQueue(q)
+		},
+	}
+}
+
+func (a *app) queueCallNextCmd() *cobra.Command {
+	var counterID, idempotencyKey string
+	var capacity int
+	cmd := &cobra.Command{
+		Use:   "call-next BUSINESS_ID QUEUE_ID --counter COUNTER_ID",
+		Short: "Call the next waiting guest to a counter through the API server",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, err := a.apiClient()
+			if err != nil {
+				return err
+			}
+			var params apiclient.CallNextParams
+			if idempotencyKey != "" {
+				params.IdempotencyKey = &idempotencyKey
+			}
+			body := apiclient.CallNextRequest{CounterId: counterID}
+			if capacity > 0 {
+				body.Capacity = &capacity
+			}
+			resp, err := api.CallNextWithResponse(cmd.Context(), args[0], args[1], &params, body)
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+				return apiError(resp.Status(), firstProblem(resp.ApplicationproblemJSON401, resp.ApplicationproblemJSON403,
+					resp.ApplicationproblemJSON404, resp.ApplicationproblemJSON409, resp.ApplicationproblemJSONDefault))
+			}
+			t := resp.JSON200
+			return a.print(t, func(w io.Writer) {
+				fmt.Fprintln(w, "USER\tSTATUS\tCOUNTER")
+				counter := ""
+				if t.AssignedTo != nil {
+					counter = *t.AssignedTo
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", t.UserId, t.Status, dash(counter))
+			})
+		},
+	}
+	cmd.Flags().StringVar(&counterID, "counter", "", "counter the guest is called to")
+	cmd.Flags().IntVar(&capacity, "capacity", 0, "seats at the counter or table; calls the first party that fits")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "retry key; the same key never calls twice")
+	_ = cmd.MarkFlagRequired("counter")
+	return cmd
+}
+
+func firstProblem(problems ...*apiclient.Problem) *apiclient.Problem {
+	for _, p := range problems {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"red-duck/auth"
+	"red-duck/internal/adapters/secondary"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+// staffID is who the audit trail records for changes made with redduckctl.
+const staffID = "redduckctl"
+
+func (a *app) ticketCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ticket",
+		Short: "Move, remove, transfer and add tickets",
+	}
+	cmd.AddCommand(a.ticketMoveCmd(), a.ticketRemoveCmd(), a.ticketTransferCmd(), a.ticketWalkInCmd())
+	return cmd
+}
+
+func (a *app) ticketMoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "move BUSINESS_ID QUEUE_ID USER_ID POSITION",
+		Short: "Move a ticket to a 1-based position",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			position, err := strconv.Atoi(args[3])
+			if err != nil || position < 1 {
+				return fmt.Errorf("position must be a positive number, got %q", args[3])
+			}
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			q, err := workflows.MoveTicketUpdate.Execute(cmd.Context(), c, workflows.QueueWorkflowID(args[0], args[1]),
+				workflows.MoveTicketRequest{UserID: args[2], Position: position, StaffID: staffID})
+			if err != nil {
+				return workflows.DomainError(err)
+			}
+			return a.printQueue(q)
+		},
+	}
+}
+
+func (a *app) ticketRemoveCmd() *cobra.Command {
+	var reason string
+	cmd := &cobra.Command{
+		Use:   "remove BUSINESS_ID QUEUE_ID USER_ID",
+		Short: "Remove a ticket from the queue",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			queues := secondary.NewTemporalQueueClient(c, a.cfg.Temporal.TaskQueue)
+			ticket, err := queues.RemoveTicket(cmd.Context(), args[0], args[1], args[2], staffID, reason)
+			if err != nil {
+				return err
+			}
+			return a.print(ticket, func(w io.Writer) {
+				fmt.Fprintf(w, "Removed %s (%s)\n", ticket.UserID, ticket.Status)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "removed by redduckctl", "why the ticket is removed, recorded in the audit trail")
+	return cmd
+}
+
+func (a *app) ticketTransferCmd() *cobra.Command {
+	var position int
+	var keepJoinTime bool
+	cmd := &cobra.Command{
+		Use:   "transfer BUSINESS_ID QUEUE_ID USER_ID TO_QUEUE_ID",
+		Short: "Move a ticket to another queue, returning it if the other queue refuses it",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if args[3] == args[1] {
+				return fmt.Errorf("ticket is already in %s; use ticket move", args[1])
+			}
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("keep-join-time") {
+				keepJoinTime = a.cfg.Queues.TransferKeepsJoinTime
+			}
+			queues := secondary.NewTemporalQueueClient(c, a.cfg.Temporal.TaskQueue)
+			landed, err := queues.TransferTicket(cmd.Context(), domain.TicketTransfer{
+				BusinessID:   args[0],
+				FromQueueID:  args[1],
+				ToQueueID:    args[3],
+				UserID:       args[2],
+				Position:     position,
+				KeepJoinTime: keepJoinTime,
+				StaffID:      staffID,
+			})
+			if err != nil {
+				return err
+			}
+			result := map[string]any{"user_id": args[2], "queue_id": args[3], "position": landed}
+			return a.print(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Transferred %s to %s at position %d\n", args[2], args[3], landed)
+			})
+		},
+	}
+	cmd.Flags().IntVar(&position, "position", 0, "1-based position in the target queue; 0 is the end")
+	cmd.Flags().BoolVar(&keepJoinTime, "keep-join-time", false, "keep the original join time (default from queues.transferKeepsJoinTime)")
+	return cmd
+}
+
+func (a *app) ticketWalkInCmd() *cobra.Command {
+	var position, partySize int
+	cmd := &cobra.Command{
+		Use:   "walk-in BUSINESS_ID QUEUE_ID",
+		Short: "Add a guest without a phone and print their ticket",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.temporalClient()
+			if err != nil {
+				return err
+			}
+			queues := secondary.NewTemporalQueueClient(c, a.cfg.Temporal.TaskQueue)
+			userID, landed, err := queues.AddWalkIn(cmd.Context(), args[0], args[1], position, partySize, staffID, "")
+			if err != nil {
+				return err
+			}
+			token, err := auth.GenerateTicket(args[0], args[1], userID)
+			if err != nil {
+				return err
+			}
+			result := map[string]any{"user_id": userID, "position": landed, "token": token}
+			return a.print(result, func(w io.Writer) {
+				fmt.Fprintln(w, "USER\tPOSITION\tTOKEN")
+				fmt.Fprintf(w, "%s\t%d\t%s\n", userID, landed, token)
+			})
+		},
+	}
+	cmd.Flags().IntVar(&position, "position", 0, "1-based position; 0 is the end")
+	cmd.Flags().IntVar(&partySize, "party", 0, "how many guests the ticket stands for; 0 is one")
+	return cmd
+}
@@ -1,52 +1,242 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/workflow"
 
+	"red-duck/analytics"
+	"red-duck/api"
 	"red-duck/auth"
+	"red-duck/db"
 	"red-duck/internal/adapters/config"
+	grpcAdapter "red-duck/internal/adapters/grpc"
+	"red-duck/internal/adapters/health"
 	httpAdapter "red-duck/internal/adapters/http"
+	"red-duck/internal/adapters/logging"
+	"red-duck/internal/adapters/metrics"
+	"red-duck/internal/adapters/objectstore"
+	"red-duck/internal/adapters/secondary"
+	"red-duck/internal/adapters/tracing"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/actor"
+	"red-duck/internal/pkg/lifecycle"
+	"red-duck/internal/pkg/requestid"
 )
 
+// shutdownTimeout bounds draining in-flight requests and closing clients on SIGTERM.
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// 1. Load Configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("Failed to load config", err)
+	}
+	logger := logging.Setup(cfg.Log)
+
+	// Components are stopped in reverse order on SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	app := lifecycle.New(shutdownTimeout)
+
+	// Tracing comes first so the Temporal client, NATS and Postgres pick up the global provider
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, "red-duck-server")
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+	app.Add(lifecycle.Component{Name: "tracing", Stop: shutdownTracing})
+	tracingInterceptor, err := tracing.TemporalInterceptor()
+	if err != nil {
+		fatal("Failed to create tracing interceptor", err)
 	}
 
 	// 2. Initialize Temporal Client
 	c, err := client.Dial(client.Options{
-		HostPort: fmt.Sprintf("%s:%d", cfg.Temporal.Host, cfg.Temporal.Port),
+		HostPort:           fmt.Sprintf("%s:%d", cfg.Temporal.Host, cfg.Temporal.Port),
+		MetricsHandler:     metrics.NewTemporalHandler(prometheus.DefaultRegisterer),
+		Interceptors:       []interceptor.ClientInterceptor{tracingInterceptor},
+		ContextPropagators: []workflow.ContextPropagator{requestid.NewContextPropagator(), actor.NewContextPropagator()},
+		Logger:             logging.TemporalLogger(logger),
 	})
 	if err != nil {
-		log.Fatalf("Unable to create client: %v", err)
+		fatal("Unable to create client", err)
+	}
+	app.Add(lifecycle.Closer("temporal-client", c.Close))
+
+	// 3. Connect to the Analytics Database (migrations are owned by the worker)
+	dbURL := cfg.Database.URL
+	dbPool, err := db.NewPool(ctx, dbURL)
+	if err != nil {
+		fatal("Unable to create connection pool", err)
 	}
-	defer c.Close()
+	app.Add(lifecycle.Closer("postgres", dbPool.Close))
 
-	// 3. Initialize HTTP Handler
+	// 4. Initialize HTTP Handlers, sharing the queue service with gRPC
+	queues := secondary.NewTemporalQueueClient(c, cfg.Temporal.TaskQueue)
+	queues.Confirm = domain.ConfirmPolicy{
+		Ahead:     cfg.Queues.Confirm.Ahead,
+		Within:    cfg.Queues.Confirm.Within,
+		MoveBack:  cfg.Queues.Confirm.MoveBack,
+		MaxMisses: cfg.Queues.Confirm.MaxMisses,
+	}
+	queues.MaxSkips = cfg.Queues.MaxSkips
+	queues.Capacity = cfg.Queues.Capacity
+	queues.Geofences = make(map[string]domain.Geofence, len(cfg.Queues.Geofences))
+	for _, g := range cfg.Queues.Geofences {
+		queues.Geofences[g.BusinessID] = domain.Geofence{
+			Center:       domain.Location{Latitude: g.Latitude, Longitude: g.Longitude},
+			RadiusMeters: g.RadiusMeters,
+		}
+	}
 	queueHandler := &httpAdapter.QueueHandler{
+		Queues:                 queues,
+		KeepJoinTimeOnTransfer: cfg.Queues.TransferKeepsJoinTime,
+	}
+	appointmentHandler := &httpAdapter.AppointmentHandler{
+		Appointments: secondary.NewTemporalAppointmentClient(c, cfg.Temporal.TaskQueue, domain.AppointmentPolicy{
+			SlotLength:   time.Duration(cfg.Appointments.SlotMinutes) * time.Minute,
+			Capacity:     cfg.Appointments.Capacity,
+			CheckInEarly: cfg.Appointments.CheckInEarly,
+			NoShowAfter:  cfg.Appointments.NoShowAfter,
+			Reminders:    cfg.Appointments.Reminders,
+			Horizon:      cfg.Appointments.Horizon,
+		}),
+	}
+	authHandler := &httpAdapter.AuthHandler{
+		Client:    c,
+		TaskQueue: cfg.Temporal.TaskQueue,
+	}
+	repo := analytics.NewPostgresRepository(dbPool)
+	analyticsHandler := &httpAdapter.AnalyticsHandler{
+		Reports: repo,
+	}
+	auditHandler := &httpAdapter.AuditHandler{
+		Log: repo,
+	}
+
+	// Exports are written by the worker; the server only hands out download links
+	exportStore, err := objectstore.New(ctx, cfg.Storage)
+	if err != nil {
+		slog.Warn("Failed to initialize object storage, export downloads disabled", "error", err)
+	}
+	exportHandler := &httpAdapter.ExportHandler{
 		Client:    c,
 		TaskQueue: cfg.Temporal.TaskQueue,
+		Store:     exportStore,
 	}
 
-	// 4. Setup Routes
-	http.HandleFunc("/create_queue", queueHandler.CreateQueue)
-	http.HandleFunc("/join_queue", queueHandler.JoinQueue)
-	http.HandleFunc("/leave_queue", queueHandler.LeaveQueue)
-	http.HandleFunc("/queue_status", queueHandler.GetQueueStatus)
+	// 5. Setup Routes
+	validator, err := httpAdapter.NewValidator(api.Spec)
+	if err != nil {
+		fatal("Failed to load OpenAPI spec", err)
+	}
+	v1 := validator.Validate
+	staff := auth.WithAuth
+	business := func(next http.HandlerFunc) http.HandlerFunc {
+		return auth.WithAuth(httpAdapter.RequireBusiness(next))
+	}
+
+	http.HandleFunc("GET /v1/openapi.yaml", httpAdapter.ServeSpec(api.Spec))
+
+	// Queues and guest tickets
+	http.HandleFunc("GET /v1/businesses/{business_id}/queues", v1(business(queueHandler.ListQueues)))
+	http.HandleFunc("PUT /v1/businesses/{business_id}/queues/{queue_id}", v1(business(queueHandler.CreateQueue)))
+	http.HandleFunc("GET /v1/businesses/{business_id}/queues/{queue_id}", v1(auth.WithTicket(queueHandler.GetQueueStatus)))
+	http.HandleFunc("DELETE /v1/businesses/{business_id}/queues/{queue_id}", v1(business(queueHandler.DeleteQueue)))
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/tickets", v1(queueHandler.CreateTicket))
+	http.HandleFunc("PATCH /v1/businesses/{business_id}/queues/{queue_id}/tickets/{user_id}", v1(business(queueHandler.MoveTicket)))
+	// Guests leave with their ticket; staff remove anyone with theirs
+	http.HandleFunc("DELETE /v1/businesses/{business_id}/queues/{queue_id}/tickets/{user_id}",
+		v1(auth.WithAuthOrTicket(httpAdapter.RequireBusiness(queueHandler.RemoveTicket), queueHandler.LeaveQueue)))
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/tickets/{user_id}/confirm", v1(auth.WithTicket(queueHandler.ConfirmTicket)))
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/tickets/{user_id}/transfer", v1(business(queueHandler.TransferTicket)))
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/walk-ins", v1(business(queueHandler.AddWalkIn)))
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/calls", v1(business(queueHandler.CallNext)))
+	// Appointments are booked without an account; their guests and staff act on them
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/appointments", v1(appointmentHandler.BookAppointment))
+	http.HandleFunc("GET /v1/businesses/{business_id}/queues/{queue_id}/appointments/{appointment_id}",
+		v1(auth.WithAuthOrTicket(httpAdapter.RequireBusiness(appointmentHandler.GetAppointment), appointmentHandler.GetAppointment)))
+	http.HandleFunc("DELETE /v1/businesses/{business_id}/queues/{queue_id}/appointments/{appointment_id}",
+		v1(auth.WithAuthOrTicket(httpAdapter.RequireBusiness(appointmentHandler.CancelAppointment), appointmentHandler.CancelAppointment)))
+	http.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/appointments/{appointment_id}/check-in",
+		v1(auth.WithAuthOrTicket(httpAdapter.RequireBusiness(appointmentHandler.CheckIn), appointmentHandler.CheckIn)))
+	// Only business owners read who did what
+	http.HandleFunc("GET /v1/businesses/{business_id}/audit-log", v1(business(httpAdapter.RequireRole(auth.RoleAdmin, auditHandler.ListAuditLog))))
 
-	// Admin/Staff Route with Auth
-	http.HandleFunc("POST /queues/{id}/call-next", auth.WithAuth(queueHandler.CallNext))
+	// Magic-code Login
+	http.HandleFunc("POST /v1/auth/login", v1(authHandler.Login))
+	http.HandleFunc("POST /v1/auth/verify", v1(authHandler.Verify))
+	http.HandleFunc("GET /v1/me", v1(staff(authHandler.Me)))
 
-	// 5. Start Server
+	// Business Owner Reports, scoped by the BusinessID claim
+	http.HandleFunc("GET /v1/analytics/throughput", v1(staff(analyticsHandler.Throughput)))
+	http.HandleFunc("GET /v1/analytics/wait-times", v1(staff(analyticsHandler.WaitTimes)))
+	http.HandleFunc("GET /v1/analytics/busiest-hours", v1(staff(analyticsHandler.BusiestHours)))
+	http.HandleFunc("GET /v1/analytics/counters", v1(staff(analyticsHandler.Counters)))
+	http.HandleFunc("POST /v1/exports", v1(staff(exportHandler.StartExport)))
+	http.HandleFunc("GET /v1/exports/{id}", v1(staff(exportHandler.GetExport)))
+
+	// Legacy routes, kept as deprecated aliases of /v1
+	legacy := httpAdapter.Deprecated
+	http.HandleFunc("/create_queue", legacy(business(queueHandler.CreateQueue)))
+	http.HandleFunc("/join_queue", legacy(queueHandler.JoinQueue))
+	http.HandleFunc("/leave_queue", legacy(auth.WithTicket(queueHandler.LeaveQueue)))
+	http.HandleFunc("/queue_status", legacy(auth.WithTicket(queueHandler.GetQueueStatus)))
+	http.HandleFunc("POST /queues/join", legacy(queueHandler.GuestJoin))
+	http.HandleFunc("POST /auth/login", legacy(authHandler.Login))
+	http.HandleFunc("POST /auth/verify", legacy(authHandler.Verify))
+	http.HandleFunc("GET /req/me", legacy(staff(authHandler.Me)))
+	http.HandleFunc("POST /queues/{id}/call-next", legacy(staff(queueHandler.CallNext)))
+	http.HandleFunc("GET /analytics/throughput", legacy(staff(analyticsHandler.Throughput)))
+	http.HandleFunc("GET /analytics/wait-times", legacy(staff(analyticsHandler.WaitTimes)))
+	http.HandleFunc("GET /analytics/busiest-hours", legacy(staff(analyticsHandler.BusiestHours)))
+	http.HandleFunc("GET /analytics/counters", legacy(staff(analyticsHandler.Counters)))
+	http.HandleFunc("POST /exports", legacy(staff(exportHandler.StartExport)))
+	http.HandleFunc("GET /exports/{id}", legacy(staff(exportHandler.GetExport)))
+
+	// Prometheus scrape endpoint
+	http.Handle("GET /metrics", metrics.Handler())
+
+	// Liveness & Readiness Probes
+	checker := health.NewChecker()
+	checker.Add("temporal", health.Temporal(c))
+	checker.Add("postgres", health.Postgres(dbPool))
+	checker.Add("migrations", func(ctx context.Context) error {
+		return db.CheckMigrations(ctx, dbPool, db.MigrationsDir)
+	})
+	http.HandleFunc("GET /healthz", health.Liveness)
+	http.HandleFunc("GET /readyz", checker.Readiness)
+
+	// 6. Start Server and run until SIGTERM, letting in-flight requests finish
 	port := 8081
-	log.Printf("Starting HTTP server on port %d...", port)
-	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	app.Add(lifecycle.HTTPServer("http", &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: tracing.Middleware(requestid.Middleware(metrics.Middleware(http.DefaultServeMux))),
+	}))
+
+	// gRPC API for kiosks and POS systems, on the same queue service as HTTP
+	grpcPort := 9090
+	grpcServer := grpcAdapter.NewServer(queues, tracing.GRPCServerOption())
+	app.Add(lifecycle.GRPCServer("grpc", fmt.Sprintf(":%d", grpcPort), grpcServer))
+
+	if err := app.Run(ctx); err != nil {
+		fatal("Server shut down with errors", err)
 	}
 }
+
+// fatal logs err and exits, like log.Fatalf.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -2,28 +2,45 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/exaring/otelpgx"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// ConnectAndMigrate connects to the database and runs migrations
-func ConnectAndMigrate(connString string) (*pgx.Conn, error) {
-	// Run migrations
-	m, err := migrate.New("file://db/migrations", connString)
+// MigrationsDir holds the SQL migrations, relative to the working directory.
+const MigrationsDir = "db/migrations"
+
+// Migrate applies any pending migrations from MigrationsDir.
+func Migrate(connString string) error {
+	m, err := migrate.New("file://"+MigrationsDir, connString)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+		return fmt.Errorf("failed to create migrate instance: %w", err)
 	}
+	defer m.Close()
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
+		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	log.Println("Migrations applied successfully")
+	slog.Info("Migrations applied successfully")
+	return nil
+}
+
+// ConnectAndMigrate connects to the database and runs migrations
+func ConnectAndMigrate(connString string) (*pgx.Conn, error) {
+	if err := Migrate(connString); err != nil {
+		return nil, err
+	}
 
 	// Connect to database using pgx
 	conn, err := pgx.Connect(context.Background(), connString)
@@ -33,3 +50,64 @@ func ConnectAndMigrate(connString string) (*pgx.Conn, error) {
 
 	return conn, nil
 }
+
+// NewPool creates a connection pool whose queries are traced as child spans of
+// the caller's context.
+func NewPool(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database URL: %w", err)
+	}
+	cfg.ConnConfig.Tracer = otelpgx.NewTracer()
+	return pgxpool.NewWithConfig(ctx, cfg)
+}
+
+// LatestMigration returns the highest migration version found in dir.
+func LatestMigration(dir string) (uint, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".up.sql") {
+			continue
+		}
+		prefix, _, _ := strings.Cut(e.Name(), "_")
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, uint(v))
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", dir)
+	}
+	return latest, nil
+}
+
+// CheckMigrations returns an error unless the schema is at the latest
+// migration in dir and no migration was left half applied.
+func CheckMigrations(ctx context.Context, pool *pgxpool.Pool, dir string) error {
+	want, err := LatestMigration(dir)
+	if err != nil {
+		return err
+	}
+
+	var version int64
+	var dirty bool
+	err = pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("no migrations applied")
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if uint(version) != want {
+		return fmt.Errorf("schema at version %d, want %d", version, want)
+	}
+	return nil
+}
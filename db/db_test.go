@@ -0,0 +1,34 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"000001_init.up.sql", "000001_init.down.sql",
+		"000012_indexes.up.sql", "000012_indexes.down.sql",
+		"000003_rollups.up.sql", "README.md",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	v, err := LatestMigration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, uint(12), v)
+
+	_, err = LatestMigration(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLatestMigration_RepoMigrations(t *testing.T) {
+	v, err := LatestMigration("migrations")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, v, uint(4))
+}
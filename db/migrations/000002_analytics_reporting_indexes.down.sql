@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_analytics_business_queue_time;
+DROP INDEX IF EXISTS idx_analytics_business_user_time;
+DROP INDEX IF EXISTS idx_analytics_business_type_time;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_analytics_business_type_time ON analytics_events (business_id, event_type, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_user_time ON analytics_events (business_id, user_id, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_queue_time ON analytics_events (business_id, (properties->>'queue_id'), timestamp);
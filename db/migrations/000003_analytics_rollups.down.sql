@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS analytics_rollup_state;
+DROP TABLE IF EXISTS analytics_rollups_daily;
+DROP TABLE IF EXISTS analytics_rollups_hourly;
+DROP INDEX IF EXISTS idx_analytics_ingested_at;
+ALTER TABLE analytics_events DROP COLUMN IF EXISTS ingested_at;
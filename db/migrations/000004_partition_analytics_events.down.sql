@@ -0,0 +1,25 @@
+ALTER TABLE analytics_events RENAME TO analytics_events_partitioned;
+ALTER TABLE analytics_events_partitioned RENAME CONSTRAINT analytics_events_pkey TO analytics_events_partitioned_pkey;
+
+CREATE TABLE analytics_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(255) NOT NULL,
+    business_id VARCHAR(255),
+    user_id VARCHAR(255),
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    properties JSONB,
+    ingested_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO analytics_events (id, event_type, business_id, user_id, timestamp, properties, ingested_at)
+SELECT id, event_type, business_id, user_id, timestamp, properties, ingested_at
+FROM analytics_events_partitioned;
+
+DROP TABLE analytics_events_partitioned;
+DROP FUNCTION IF EXISTS analytics_events_ensure_partition(DATE);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_type_time ON analytics_events (event_type, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_type_time ON analytics_events (business_id, event_type, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_user_time ON analytics_events (business_id, user_id, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_queue_time ON analytics_events (business_id, (properties->>'queue_id'), timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_ingested_at ON analytics_events (ingested_at);
@@ -0,0 +1,55 @@
+-- Rebuild analytics_events as a table range-partitioned by month on timestamp.
+-- Partitions are named analytics_events_YYYY_MM and are created ahead of time by
+-- the partition maintenance workflow through analytics_events_ensure_partition.
+ALTER TABLE analytics_events RENAME TO analytics_events_unpartitioned;
+ALTER TABLE analytics_events_unpartitioned RENAME CONSTRAINT analytics_events_pkey TO analytics_events_unpartitioned_pkey;
+
+CREATE TABLE analytics_events (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    event_type VARCHAR(255) NOT NULL,
+    business_id VARCHAR(255),
+    user_id VARCHAR(255),
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    properties JSONB,
+    ingested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, timestamp)
+) PARTITION BY RANGE (timestamp);
+
+CREATE OR REPLACE FUNCTION analytics_events_ensure_partition(month DATE) RETURNS TEXT AS $$
+DECLARE
+    start_at DATE := date_trunc('month', month)::DATE;
+    partition_name TEXT := format('analytics_events_%s', to_char(start_at, 'YYYY_MM'));
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF analytics_events FOR VALUES FROM (%L) TO (%L)',
+        partition_name,
+        start_at::TIMESTAMP AT TIME ZONE 'UTC',
+        (start_at + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC'
+    );
+    RETURN partition_name;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    m DATE;
+BEGIN
+    m := date_trunc('month', COALESCE((SELECT MIN(timestamp) FROM analytics_events_unpartitioned), NOW()) AT TIME ZONE 'UTC')::DATE;
+    WHILE m <= (date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '2 months')::DATE LOOP
+        PERFORM analytics_events_ensure_partition(m);
+        m := (m + INTERVAL '1 month')::DATE;
+    END LOOP;
+END $$;
+
+INSERT INTO analytics_events (id, event_type, business_id, user_id, timestamp, properties, ingested_at)
+SELECT id, event_type, business_id, user_id, timestamp, properties, ingested_at
+FROM analytics_events_unpartitioned;
+
+DROP TABLE analytics_events_unpartitioned;
+
+-- Indexes on the parent are created on every partition, current and future.
+CREATE INDEX IF NOT EXISTS idx_analytics_type_time ON analytics_events (event_type, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_type_time ON analytics_events (business_id, event_type, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_user_time ON analytics_events (business_id, user_id, timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_business_queue_time ON analytics_events (business_id, (properties->>'queue_id'), timestamp);
+CREATE INDEX IF NOT EXISTS idx_analytics_ingested_at ON analytics_events (ingested_at);
@@ -0,0 +1,13 @@
+-- Final report of each queue run, written when the queue shuts down
+CREATE TABLE IF NOT EXISTS queue_reports (
+    run_id VARCHAR(255) PRIMARY KEY,
+    business_id VARCHAR(255) NOT NULL,
+    queue_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    closed_at TIMESTAMPTZ NOT NULL,
+    served INTEGER NOT NULL,
+    abandoned INTEGER NOT NULL,
+    cancelled INTEGER NOT NULL,
+    avg_wait_seconds DOUBLE PRECISION NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_queue_reports_business_closed ON queue_reports (business_id, closed_at);
@@ -0,0 +1,32 @@
+-- Staff changes to queues, hash chained per business. The log is append-only:
+-- rows can't be updated or deleted, and editing one behind the trigger's back
+-- breaks the chain from that row on.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    -- Identifies the write so a retried write stores the entry once
+    entry_key VARCHAR(255) NOT NULL UNIQUE,
+    business_id VARCHAR(255) NOT NULL,
+    queue_id VARCHAR(255) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL,
+    actor_role VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target_user_id VARCHAR(255) NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL,
+    hash VARCHAR(64) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_business ON audit_log (business_id, id);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,28 @@
+DROP FUNCTION IF EXISTS analytics_events_drain_default(DATE);
+
+-- Give the events in the default partition months of their own before it goes
+DO $$
+DECLARE
+    m DATE;
+BEGIN
+    FOR m IN SELECT DISTINCT date_trunc('month', timestamp AT TIME ZONE 'UTC')::DATE FROM analytics_events_default LOOP
+        PERFORM analytics_events_ensure_partition(m);
+    END LOOP;
+END $$;
+
+DROP TABLE IF EXISTS analytics_events_default;
+
+CREATE OR REPLACE FUNCTION analytics_events_ensure_partition(month DATE) RETURNS TEXT AS $$
+DECLARE
+    start_at DATE := date_trunc('month', month)::DATE;
+    partition_name TEXT := format('analytics_events_%s', to_char(start_at, 'YYYY_MM'));
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF analytics_events FOR VALUES FROM (%L) TO (%L)',
+        partition_name,
+        start_at::TIMESTAMP AT TIME ZONE 'UTC',
+        (start_at + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC'
+    );
+    RETURN partition_name;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,54 @@
+-- Events whose month has no partition yet (late, backdated or far-future
+-- timestamps) land in analytics_events_default instead of failing the insert.
+-- Creating a month's partition moves its events out of the default partition
+-- first, since a partition can't be attached while the default holds rows of
+-- its range. Partition maintenance does so through
+-- analytics_events_drain_default.
+CREATE TABLE IF NOT EXISTS analytics_events_default PARTITION OF analytics_events DEFAULT;
+
+CREATE OR REPLACE FUNCTION analytics_events_ensure_partition(month DATE) RETURNS TEXT AS $$
+DECLARE
+    start_at DATE := date_trunc('month', month)::DATE;
+    partition_name TEXT := format('analytics_events_%s', to_char(start_at, 'YYYY_MM'));
+    from_ts TIMESTAMPTZ := start_at::TIMESTAMP AT TIME ZONE 'UTC';
+    to_ts TIMESTAMPTZ := (start_at + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC';
+BEGIN
+    -- A partition detached for archival keeps its name until it is dropped
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN partition_name;
+    END IF;
+
+    EXECUTE format('CREATE TABLE %I (LIKE analytics_events INCLUDING DEFAULTS)', partition_name);
+    EXECUTE format(
+        'WITH moved AS (DELETE FROM analytics_events_default WHERE timestamp >= %L AND timestamp < %L RETURNING *) INSERT INTO %I SELECT * FROM moved',
+        from_ts, to_ts, partition_name
+    );
+    EXECUTE format(
+        'ALTER TABLE analytics_events ATTACH PARTITION %I FOR VALUES FROM (%L) TO (%L)',
+        partition_name, from_ts, to_ts
+    );
+    RETURN partition_name;
+END;
+$$ LANGUAGE plpgsql;
+
+-- analytics_events_drain_default moves the events in the default partition
+-- into partitions of their own months, from since onward, and returns the
+-- partitions it created. Months whose partition exists but is detached, and
+-- months before since, are left in place.
+CREATE OR REPLACE FUNCTION analytics_events_drain_default(since DATE) RETURNS SETOF TEXT AS $$
+DECLARE
+    months DATE[];
+    m DATE;
+BEGIN
+    SELECT array_agg(DISTINCT date_trunc('month', timestamp AT TIME ZONE 'UTC')::DATE)
+    INTO months
+    FROM analytics_events_default
+    WHERE timestamp >= date_trunc('month', since)::DATE::TIMESTAMP AT TIME ZONE 'UTC';
+
+    FOREACH m IN ARRAY COALESCE(months, '{}') LOOP
+        IF to_regclass(format('analytics_events_%s', to_char(m, 'YYYY_MM'))) IS NULL THEN
+            RETURN NEXT analytics_events_ensure_partition(m);
+        END IF;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
@@ -3,13 +3,123 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Temporal TemporalConfig
-	Nats     NatsConfig
+	Temporal     TemporalConfig
+	Nats         NatsConfig
+	Database     DatabaseConfig
+	API          APIConfig
+	Queues       QueuesConfig
+	Appointments AppointmentsConfig
+	Analytics    AnalyticsConfig
+	Storage      StorageConfig
+	Tracing      TracingConfig
+	Log          LogConfig
+}
+
+type AnalyticsConfig struct {
+	// RetentionMonths is how many whole months of raw events to keep. Zero keeps everything.
+	RetentionMonths int
+	PartitionsAhead int
+	// Archive uploads expired partitions to object storage before they are dropped.
+	Archive bool
+	// RollupInterval is how often the rollups are brought up to date. It
+	// applies when the worker first creates the schedule.
+	RollupInterval time.Duration
+}
+
+// StorageConfig selects the object store. LocalDir, when set, takes precedence over MinIO.
+type StorageConfig struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	UseSSL    bool
+	LocalDir  string
+}
+
+// LogConfig selects the log output: "json" in production, "text" in development.
+type LogConfig struct {
+	Format string
+	Level  string // debug, info, warn or error
+}
+
+// TracingConfig selects where spans go: "otlp", "stdout" or "none".
+type TracingConfig struct {
+	Exporter string
+	// Endpoint is the OTLP gRPC collector address, e.g. "localhost:4317".
+	Endpoint string
+	Insecure bool
+}
+
+// DatabaseConfig points at the analytics database. DB_URL overrides URL.
+type DatabaseConfig struct {
+	URL string
+}
+
+// APIConfig is how redduckctl reaches the API server. Token is a staff JWT;
+// API_TOKEN overrides it.
+type APIConfig struct {
+	URL   string
+	Token string
+}
+
+// QueuesConfig holds defaults for the queue routes.
+type QueuesConfig struct {
+	// TransferKeepsJoinTime is whether guests transferred to another queue
+	// keep their original join time when the request doesn't say.
+	TransferKeepsJoinTime bool
+	// Confirm is how new queues ask guests who joined remotely to confirm
+	// they are on their way.
+	Confirm ConfirmConfig
+	// Geofences are where guests of a business count as arrived.
+	Geofences []GeofenceConfig
+	// MaxSkips is how often a waiting party can be passed over by calls for
+	// smaller tables before it holds the line.
+	MaxSkips int
+	// Capacity is how many tickets may wait in a new queue at once; zero is
+	// no limit.
+	Capacity int
+}
+
+// ConfirmConfig asks the guests among the first Ahead waiting to confirm
+// within Within. A miss drops a guest MoveBack places, and the MaxMisses-th
+// miss expires their ticket; 0 expires it at the first, as 1 does. Zero
+// Ahead asks nobody.
+type ConfirmConfig struct {
+	Ahead     int
+	Within    time.Duration
+	MoveBack  int
+	MaxMisses int
+}
+
+// GeofenceConfig is the circle around a business where its guests count as
+// arrived.
+type GeofenceConfig struct {
+	BusinessID   string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+}
+
+// AppointmentsConfig is how queues take appointments. Durations are Go
+// durations, e.g. "30m".
+type AppointmentsConfig struct {
+	SlotMinutes int
+	// Capacity is how many guests can book the same slot.
+	Capacity     int
+	CheckInEarly time.Duration
+	// NoShowAfter is how long after the slot starts a guest who hasn't
+	// checked in loses their seat.
+	NoShowAfter time.Duration
+	// Reminders are sent this long before the slot.
+	Reminders []time.Duration
+	// Horizon is how far ahead slots can be booked.
+	Horizon time.Duration
 }
 
 type NatsConfig struct {
@@ -28,6 +138,10 @@ func Load() (*Config, error) {
 	viper.AddConfigPath(".")
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
+	// DB_URL predates the config file and is what the deployments set
+	if err := viper.BindEnv("database.url", "DB_URL"); err != nil {
+		return nil, err
+	}
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"red-duck/auth"
+)
+
+// authorizationKey is the metadata key carrying "Bearer <jwt>".
+const authorizationKey = "authorization"
+
+// authenticate puts the caller's credentials into ctx with the same keys as
+// the HTTP middleware: a staff token as with auth.WithAuth, a guest ticket as
+// with auth.WithTicket. Calls without credentials pass through; each RPC
+// decides what it requires.
+func authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+
+	if claims, err := auth.ParseToken(token); err == nil {
+		return auth.ContextWithClaims(ctx, claims), nil
+	}
+	if ticket, err := auth.ParseTicket(token); err == nil {
+		return auth.ContextWithTicket(ctx, ticket), nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid token")
+}
+
+// UnaryAuthInterceptor authenticates unary calls.
+func UnaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamAuthInterceptor authenticates streaming calls.
+func StreamAuthInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream is a ServerStream with the authenticated context.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// requireStaff checks that the caller holds a staff token for businessID and
+// returns their user ID.
+func requireStaff(ctx context.Context, businessID string) (string, error) {
+	claimed, ok := auth.GetBusinessID(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "staff token required")
+	}
+	if claimed == "" || claimed != businessID {
+		return "", status.Error(codes.PermissionDenied, "token is not for this business")
+	}
+	userID, _ := auth.GetUserID(ctx)
+	return userID, nil
+}
+
+// requireTicket returns the caller's ticket, checking it is for the queue.
+func requireTicket(ctx context.Context, businessID, queueID string) (*auth.TicketClaims, error) {
+	ticket, ok := auth.GetTicket(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "ticket required")
+	}
+	if err := ticket.Matches(businessID, queueID); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return ticket, nil
+}
+
+// requireViewer lets a ticket holder watch their own queue, and staff watch
+// any queue of their business. It returns the ticket, nil for staff.
+func requireViewer(ctx context.Context, businessID, queueID string) (*auth.TicketClaims, error) {
+	if _, ok := auth.GetTicket(ctx); ok {
+		return requireTicket(ctx, businessID, queueID)
+	}
+	if _, err := requireStaff(ctx, businessID); err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, status.Error(codes.Unauthenticated, "ticket or staff token required")
+		}
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"red-duck/internal/core/domain"
+)
+
+// errorDomain qualifies the error codes in ErrorInfo details.
+const errorDomain = "red-duck"
+
+// domainCodes is the gRPC code of each domain error code.
+var domainCodes = map[domain.ErrorCode]codes.Code{
+	domain.CodeUserAlreadyInQueue:  codes.AlreadyExists,
+	domain.CodeUserNotFound:        codes.NotFound,
+	domain.CodeQueueEmpty:          codes.FailedPrecondition,
+	domain.CodeQueueNotFound:       codes.NotFound,
+	domain.CodeQueueClosed:         codes.FailedPrecondition,
+	domain.CodeCapacityReached:     codes.ResourceExhausted,
+	domain.CodeInvalidSlot:         codes.InvalidArgument,
+	domain.CodeSlotUnavailable:     codes.ResourceExhausted,
+	domain.CodeAppointmentNotFound: codes.NotFound,
+	domain.CodeCheckInNotOpen:      codes.FailedPrecondition,
+	domain.CodeAppointmentClosed:   codes.FailedPrecondition,
+	domain.CodeOutsideGeofence:     codes.FailedPrecondition,
+	domain.CodeTicketNotWaiting:    codes.FailedPrecondition,
+	domain.CodeInvalidPartySize:    codes.InvalidArgument,
+	domain.CodeNoFittingParty:      codes.FailedPrecondition,
+	domain.CodeMissingUserID:       codes.InvalidArgument,
+}
+
+// toStatus is writeError of the HTTP adapter for gRPC: domain errors are
+// reported with their code as ErrorInfo reason, anything else is logged and
+// reported as an internal error without details.
+func toStatus(ctx context.Context, err error) error {
+	if code := domain.CodeOf(err); code != "" {
+		st := status.New(domainCodes[code], err.Error())
+		if withInfo, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: string(code), Domain: errorDomain}); detailErr == nil {
+			st = withInfo
+		}
+		return st.Err()
+	}
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	slog.ErrorContext(ctx, "RPC failed", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
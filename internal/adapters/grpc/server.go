@@ -0,0 +1,212 @@
+// Package grpc serves the queue API over gRPC for kiosk and POS integrations.
+// It shares ports.QueueService, and so the queue behaviour, with the HTTP API.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	redduckv1 "red-duck/api/proto/redduck/v1"
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/core/ports"
+	"red-duck/internal/workflows"
+)
+
+// Server implements redduckv1.QueueService.
+type Server struct {
+	redduckv1.UnimplementedQueueServiceServer
+	Queues ports.QueueService
+}
+
+// NewServer returns a gRPC server exposing queues, authenticating callers from
+// their metadata. opts are added to the server's.
+func NewServer(queues ports.QueueService, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor),
+	)
+	srv := grpc.NewServer(opts...)
+	redduckv1.RegisterQueueServiceServer(srv, &Server{Queues: queues})
+	return srv
+}
+
+func (s *Server) CreateQueue(ctx context.Context, req *redduckv1.CreateQueueRequest) (*redduckv1.CreateQueueResponse, error) {
+	if err := validateQueue(req.GetBusinessId(), req.GetQueueId()); err != nil {
+		return nil, err
+	}
+	if _, err := requireStaff(ctx, req.GetBusinessId()); err != nil {
+		return nil, err
+	}
+
+	runID, err := s.Queues.CreateQueue(ctx, req.GetBusinessId(), req.GetQueueId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &redduckv1.CreateQueueResponse{
+		WorkflowId: workflows.QueueWorkflowID(req.GetBusinessId(), req.GetQueueId()),
+		RunId:      runID,
+	}, nil
+}
+
+func (s *Server) JoinQueue(ctx context.Context, req *redduckv1.JoinQueueRequest) (*redduckv1.JoinQueueResponse, error) {
+	if err := validateQueue(req.GetBusinessId(), req.GetQueueId()); err != nil {
+		return nil, err
+	}
+	if err := validateIdempotencyKey(req.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+	partySize := int(req.GetPartySize())
+	if err := domain.ValidPartySize(partySize); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	joined, err := s.Queues.JoinQueue(ctx, req.GetBusinessId(), req.GetQueueId(), partySize, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	// Replayed joins get a fresh ticket for the same guest, as over HTTP
+	token, err := auth.GenerateTicket(req.GetBusinessId(), req.GetQueueId(), joined.UserID)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &redduckv1.JoinQueueResponse{
+		UserId:               joined.UserID,
+		Position:             int32(joined.Position),
+		EstimatedWaitMinutes: int32(joined.WaitMinutes),
+		Token:                token,
+	}, nil
+}
+
+// LeaveQueue removes the ticket holder; the request only names the queue.
+func (s *Server) LeaveQueue(ctx context.Context, req *redduckv1.LeaveQueueRequest) (*redduckv1.LeaveQueueResponse, error) {
+	if err := validateIdempotencyKey(req.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+	ticket, err := requireTicket(ctx, req.GetBusinessId(), req.GetQueueId())
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := s.Queues.LeaveQueue(ctx, ticket.BusinessID, ticket.QueueID, ticket.UserID, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &redduckv1.LeaveQueueResponse{RemainingUsers: int32(remaining)}, nil
+}
+
+func (s *Server) GetQueueStatus(ctx context.Context, req *redduckv1.GetQueueStatusRequest) (*redduckv1.GetQueueStatusResponse, error) {
+	if err := validateQueue(req.GetBusinessId(), req.GetQueueId()); err != nil {
+		return nil, err
+	}
+	ticket, err := requireViewer(ctx, req.GetBusinessId(), req.GetQueueId())
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.Queues.GetQueueStatus(ctx, req.GetBusinessId(), req.GetQueueId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &redduckv1.GetQueueStatusResponse{Status: queueStatus(q, ticket)}, nil
+}
+
+func (s *Server) CallNext(ctx context.Context, req *redduckv1.CallNextRequest) (*redduckv1.CallNextResponse, error) {
+	if err := validateQueue(req.GetBusinessId(), req.GetQueueId()); err != nil {
+		return nil, err
+	}
+	if req.GetCounterId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing counter_id")
+	}
+	if req.GetCapacity() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "capacity must not be negative")
+	}
+	if err := validateIdempotencyKey(req.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+	staffID, err := requireStaff(ctx, req.GetBusinessId())
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.Queues.CallNext(ctx, req.GetBusinessId(), req.GetQueueId(), req.GetCounterId(), int(req.GetCapacity()), staffID, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &redduckv1.CallNextResponse{Ticket: ticketMessage(t)}, nil
+}
+
+func (s *Server) WatchQueue(req *redduckv1.WatchQueueRequest, stream grpc.ServerStreamingServer[redduckv1.WatchQueueResponse]) error {
+	ctx := stream.Context()
+	if err := validateQueue(req.GetBusinessId(), req.GetQueueId()); err != nil {
+		return err
+	}
+	ticket, err := requireViewer(ctx, req.GetBusinessId(), req.GetQueueId())
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = s.Queues.WatchQueue(ctx, req.GetBusinessId(), req.GetQueueId(), func(q *domain.Queue) error {
+		sendErr = stream.Send(&redduckv1.WatchQueueResponse{Status: queueStatus(q, ticket)})
+		return sendErr
+	})
+	switch {
+	case sendErr != nil:
+		return sendErr
+	case err != nil:
+		return toStatus(ctx, err)
+	}
+	return nil
+}
+
+func validateQueue(businessID, queueID string) error {
+	if businessID == "" || queueID == "" {
+		return status.Error(codes.InvalidArgument, "missing business_id or queue_id")
+	}
+	return nil
+}
+
+func validateIdempotencyKey(key string) error {
+	if len(key) > ports.MaxIdempotencyKeyLength {
+		return status.Errorf(codes.InvalidArgument, "idempotency_key longer than %d characters", ports.MaxIdempotencyKeyLength)
+	}
+	return nil
+}
+
+// queueStatus reports q to a viewer; ticket is nil for staff.
+func queueStatus(q *domain.Queue, ticket *auth.TicketClaims) *redduckv1.QueueStatus {
+	st := &redduckv1.QueueStatus{
+		BusinessId:           q.BusinessID,
+		QueueId:              q.ID,
+		QueueLength:          int32(q.Len()),
+		EstimatedWaitMinutes: int32(q.NewcomerWaitMinutes(1)),
+	}
+	if ticket != nil {
+		position := q.GetPosition(ticket.UserID)
+		st.Position = int32(position)
+		st.EstimatedWaitMinutes = int32(q.WaitMinutes(position))
+	}
+	return st
+}
+
+var ticketStatuses = map[domain.TicketStatus]redduckv1.TicketStatus{
+	domain.TicketStatusWaiting:   redduckv1.TicketStatus_TICKET_STATUS_WAITING,
+	domain.TicketStatusReady:     redduckv1.TicketStatus_TICKET_STATUS_READY,
+	domain.TicketStatusCompleted: redduckv1.TicketStatus_TICKET_STATUS_COMPLETED,
+	domain.TicketStatusCancelled: redduckv1.TicketStatus_TICKET_STATUS_CANCELLED,
+}
+
+func ticketMessage(t *domain.Ticket) *redduckv1.Ticket {
+	return &redduckv1.Ticket{
+		UserId:     t.UserID,
+		Status:     ticketStatuses[t.Status],
+		AssignedTo: t.AssignedTo,
+		JoinedAt:   timestamppb.New(t.JoinedAt),
+		PartySize:  int32(t.Guests()),
+	}
+}
@@ -0,0 +1,290 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	redduckv1 "red-duck/api/proto/redduck/v1"
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+)
+
+type MockQueueService struct {
+	mock.Mock
+}
+
+func (m *MockQueueService) CreateQueue(ctx context.Context, businessID, queueID string) (string, error) {
+	args := m.Called(ctx, businessID, queueID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQueueService) DeleteQueue(ctx context.Context, businessID, queueID, reason, requestedBy string) (*domain.QueueReport, error) {
+	args := m.Called(ctx, businessID, queueID, reason, requestedBy)
+	r, _ := args.Get(0).(*domain.QueueReport)
+	return r, args.Error(1)
+}
+
+func (m *MockQueueService) JoinQueue(ctx context.Context, businessID, queueID string, partySize int, idempotencyKey string) (domain.Joined, error) {
+	args := m.Called(ctx, businessID, queueID, partySize, idempotencyKey)
+	return args.Get(0).(domain.Joined), args.Error(1)
+}
+
+func (m *MockQueueService) LeaveQueue(ctx context.Context, businessID, queueID, userID, idempotencyKey string) (int, error) {
+	args := m.Called(ctx, businessID, queueID, userID, idempotencyKey)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQueueService) GetQueueStatus(ctx context.Context, businessID, queueID string) (*domain.Queue, error) {
+	args := m.Called(ctx, businessID, queueID)
+	q, _ := args.Get(0).(*domain.Queue)
+	return q, args.Error(1)
+}
+
+func (m *MockQueueService) ListQueues(ctx context.Context, businessID string, state domain.QueueState, pageSize int, pageToken []byte) ([]domain.QueueSummary, []byte, error) {
+	args := m.Called(ctx, businessID, state, pageSize, pageToken)
+	next, _ := args.Get(1).([]byte)
+	return args.Get(0).([]domain.QueueSummary), next, args.Error(2)
+}
+
+func (m *MockQueueService) MoveTicket(ctx context.Context, businessID, queueID, userID string, position int, staffID string) (int, error) {
+	args := m.Called(ctx, businessID, queueID, userID, position, staffID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQueueService) RemoveTicket(ctx context.Context, businessID, queueID, userID, staffID, reason string) (*domain.Ticket, error) {
+	args := m.Called(ctx, businessID, queueID, userID, staffID, reason)
+	t, _ := args.Get(0).(*domain.Ticket)
+	return t, args.Error(1)
+}
+
+func (m *MockQueueService) ConfirmTicket(ctx context.Context, businessID, queueID, userID string, location *domain.Location) (*domain.Ticket, error) {
+	args := m.Called(ctx, businessID, queueID, userID, location)
+	ticket, _ := args.Get(0).(*domain.Ticket)
+	return ticket, args.Error(1)
+}
+
+func (m *MockQueueService) AddWalkIn(ctx context.Context, businessID, queueID string, position, partySize int, staffID, idempotencyKey string) (string, int, error) {
+	args := m.Called(ctx, businessID, queueID, position, partySize, staffID, idempotencyKey)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockQueueService) TransferTicket(ctx context.Context, transfer domain.TicketTransfer) (int, error) {
+	args := m.Called(ctx, transfer)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQueueService) CallNext(ctx context.Context, businessID, queueID, counterID string, capacity int, staffID, idempotencyKey string) (*domain.Ticket, error) {
+	args := m.Called(ctx, businessID, queueID, counterID, capacity, staffID, idempotencyKey)
+	t, _ := args.Get(0).(*domain.Ticket)
+	return t, args.Error(1)
+}
+
+// WatchQueue reports each of the queues it was set up with, then returns.
+func (m *MockQueueService) WatchQueue(ctx context.Context, businessID, queueID string, fn func(*domain.Queue) error) error {
+	args := m.Called(ctx, businessID, queueID)
+	for _, q := range args.Get(0).([]*domain.Queue) {
+		if err := fn(q); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// dial serves queues over an in-memory connection.
+func dial(t *testing.T, queues *MockQueueService) redduckv1.QueueServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(queues)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return redduckv1.NewQueueServiceClient(conn)
+}
+
+func bearer(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer "+token)
+}
+
+func staffContext(t *testing.T, businessID string) context.Context {
+	t.Helper()
+	token, err := auth.GenerateToken(context.Background(), auth.User{ID: "staff-1", Role: "staff", BusinessID: businessID})
+	require.NoError(t, err)
+	return bearer(token)
+}
+
+func ticketContext(t *testing.T, businessID, queueID, userID string) context.Context {
+	t.Helper()
+	token, err := auth.GenerateTicket(businessID, queueID, userID)
+	require.NoError(t, err)
+	return bearer(token)
+}
+
+func TestJoinQueue_IssuesTicket(t *testing.T) {
+	queues := new(MockQueueService)
+	queues.On("JoinQueue", mock.Anything, "biz_123", "main", 0, "kiosk-7").Return(domain.Joined{UserID: "guest-1", Position: 3, WaitMinutes: 15}, nil)
+	c := dial(t, queues)
+
+	resp, err := c.JoinQueue(context.Background(), &redduckv1.JoinQueueRequest{BusinessId: "biz_123", QueueId: "main", IdempotencyKey: "kiosk-7"})
+	require.NoError(t, err)
+	assert.Equal(t, "guest-1", resp.GetUserId())
+	assert.EqualValues(t, 3, resp.GetPosition())
+	assert.EqualValues(t, 15, resp.GetEstimatedWaitMinutes())
+
+	ticket, err := auth.ParseTicket(resp.GetToken())
+	require.NoError(t, err)
+	assert.Equal(t, "guest-1", ticket.UserID)
+	assert.NoError(t, ticket.Matches("biz_123", "main"))
+}
+
+func TestLeaveQueue(t *testing.T) {
+	t.Run("Leaves as the ticket holder", func(t *testing.T) {
+		queues := new(MockQueueService)
+		queues.On("LeaveQueue", mock.Anything, "biz_123", "main", "guest-1", "").Return(2, nil)
+		c := dial(t, queues)
+
+		resp, err := c.LeaveQueue(ticketContext(t, "biz_123", "main", "guest-1"), &redduckv1.LeaveQueueRequest{BusinessId: "biz_123", QueueId: "main"})
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, resp.GetRemainingUsers())
+	})
+
+	t.Run("Requires a ticket", func(t *testing.T) {
+		queues := new(MockQueueService)
+		c := dial(t, queues)
+
+		_, err := c.LeaveQueue(context.Background(), &redduckv1.LeaveQueueRequest{BusinessId: "biz_123", QueueId: "main"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		// A staff token is not a ticket
+		_, err = c.LeaveQueue(staffContext(t, "biz_123"), &redduckv1.LeaveQueueRequest{BusinessId: "biz_123", QueueId: "main"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		queues.AssertNotCalled(t, "LeaveQueue")
+	})
+
+	t.Run("Ticket for another queue", func(t *testing.T) {
+		queues := new(MockQueueService)
+		c := dial(t, queues)
+
+		_, err := c.LeaveQueue(ticketContext(t, "biz_123", "main", "guest-1"), &redduckv1.LeaveQueueRequest{BusinessId: "biz_123", QueueId: "other"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		queues.AssertNotCalled(t, "LeaveQueue")
+	})
+}
+
+func TestInvalidToken(t *testing.T) {
+	c := dial(t, new(MockQueueService))
+
+	_, err := c.GetQueueStatus(bearer("not-a-jwt"), &redduckv1.GetQueueStatusRequest{BusinessId: "biz_123", QueueId: "main"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestCallNext(t *testing.T) {
+	req := &redduckv1.CallNextRequest{BusinessId: "biz_123", QueueId: "main", CounterId: "Counter 3", IdempotencyKey: "tap-1"}
+
+	t.Run("Returns the called ticket", func(t *testing.T) {
+		queues := new(MockQueueService)
+		joinedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+		queues.On("CallNext", mock.Anything, "biz_123", "main", "Counter 3", 0, "staff-1", "tap-1").
+			Return(&domain.Ticket{UserID: "guest-1", Status: domain.TicketStatusReady, AssignedTo: "Counter 3", JoinedAt: joinedAt}, nil)
+		c := dial(t, queues)
+
+		resp, err := c.CallNext(staffContext(t, "biz_123"), req)
+		require.NoError(t, err)
+		assert.Equal(t, "guest-1", resp.GetTicket().GetUserId())
+		assert.Equal(t, redduckv1.TicketStatus_TICKET_STATUS_READY, resp.GetTicket().GetStatus())
+		assert.Equal(t, joinedAt, resp.GetTicket().GetJoinedAt().AsTime())
+	})
+
+	t.Run("Requires staff of the business", func(t *testing.T) {
+		queues := new(MockQueueService)
+		c := dial(t, queues)
+
+		_, err := c.CallNext(ticketContext(t, "biz_123", "main", "guest-1"), req)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = c.CallNext(staffContext(t, "biz_other"), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		queues.AssertNotCalled(t, "CallNext")
+	})
+
+	t.Run("Reports domain errors by code", func(t *testing.T) {
+		queues := new(MockQueueService)
+		queues.On("CallNext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, domain.ErrQueueEmpty)
+		c := dial(t, queues)
+
+		_, err := c.CallNext(staffContext(t, "biz_123"), req)
+		st := status.Convert(err)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		require.Len(t, st.Details(), 1)
+		assert.Equal(t, "queue_empty", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+	})
+}
+
+func TestWatchQueue(t *testing.T) {
+	queues := new(MockQueueService)
+	q1 := domain.NewQueue("main", "biz_123")
+	q1.AddUser("someone", time.Now())
+	q1.AddUser("guest-1", time.Now())
+	q1.AddUser("behind", time.Now())
+	q2 := domain.NewQueue("main", "biz_123")
+	q2.AddUser("guest-1", time.Now())
+	queues.On("WatchQueue", mock.Anything, "biz_123", "main").Return([]*domain.Queue{q1, q2}, nil)
+	c := dial(t, queues)
+
+	t.Run("Ticket holders see their place", func(t *testing.T) {
+		stream, err := c.WatchQueue(ticketContext(t, "biz_123", "main", "guest-1"), &redduckv1.WatchQueueRequest{BusinessId: "biz_123", QueueId: "main"})
+		require.NoError(t, err)
+
+		var positions, waits []int32
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			positions = append(positions, resp.GetStatus().GetPosition())
+			waits = append(waits, resp.GetStatus().GetEstimatedWaitMinutes())
+		}
+		assert.Equal(t, []int32{2, 1}, positions)
+		// Whoever is behind doesn't hold them up
+		assert.Equal(t, []int32{10, 5}, waits)
+	})
+
+	t.Run("Staff watch the whole queue", func(t *testing.T) {
+		stream, err := c.WatchQueue(staffContext(t, "biz_123"), &redduckv1.WatchQueueRequest{BusinessId: "biz_123", QueueId: "main"})
+		require.NoError(t, err)
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, resp.GetStatus().GetQueueLength())
+		assert.Zero(t, resp.GetStatus().GetPosition())
+		// Staff see the wait of a guest joining now
+		assert.EqualValues(t, 20, resp.GetStatus().GetEstimatedWaitMinutes())
+	})
+
+	t.Run("Requires credentials", func(t *testing.T) {
+		stream, err := c.WatchQueue(context.Background(), &redduckv1.WatchQueueRequest{BusinessId: "biz_123", QueueId: "main"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -0,0 +1,143 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go"
+	"go.temporal.io/sdk/client"
+)
+
+// checkTimeout bounds every dependency check so a hung dependency reports as
+// down instead of hanging the probe.
+const checkTimeout = 2 * time.Second
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports a dependency as healthy by returning nil.
+type Check func(ctx context.Context) error
+
+// Checker runs the readiness checks of a binary.
+type Checker struct {
+	mu     sync.RWMutex
+	names  []string
+	checks map[string]Check
+}
+
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// Add registers a named dependency check. Adding a name twice replaces the check.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// Run checks every dependency concurrently. The report is up only if all of them are.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	names := append([]string(nil), c.names...)
+	checks := make([]Check, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.RUnlock()
+
+	results := make([]DependencyStatus, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			if err := checks[i](checkCtx); err != nil {
+				results[i] = DependencyStatus{Status: StatusDown, Error: err.Error()}
+				return
+			}
+			results[i] = DependencyStatus{Status: StatusUp}
+		}(i)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Dependencies: make(map[string]DependencyStatus, len(names))}
+	for i, name := range names {
+		report.Dependencies[name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// Liveness answers /healthz: the process is up and serving HTTP. It doesn't
+// look at dependencies, so an outage never gets the container restarted.
+func Liveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": StatusUp})
+}
+
+// Readiness answers /readyz with every dependency's state, and 503 while any is down.
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := c.Run(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != StatusUp {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// Temporal checks the frontend service the client is connected to.
+func Temporal(c client.Client) Check {
+	return func(ctx context.Context) error {
+		_, err := c.CheckHealth(ctx, &client.CheckHealthRequest{})
+		return err
+	}
+}
+
+// NATS checks that the connection is established. A nil connection means the
+// initial connect failed.
+func NATS(nc *nats.Conn) Check {
+	return func(ctx context.Context) error {
+		if nc == nil {
+			return errors.New("not connected")
+		}
+		if status := nc.Status(); status != nats.CONNECTED {
+			return fmt.Errorf("connection %s", status)
+		}
+		return nil
+	}
+}
+
+// Postgres pings a pooled connection.
+func Postgres(pool *pgxpool.Pool) Check {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+)
+
+func up(context.Context) error { return nil }
+
+func TestReadiness_AllUp(t *testing.T) {
+	c := NewChecker()
+	c.Add("postgres", up)
+	c.Add("temporal", up)
+
+	rr := httptest.NewRecorder()
+	c.Readiness(rr, httptest.NewRequest("GET", "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var report Report
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, DependencyStatus{Status: StatusUp}, report.Dependencies["postgres"])
+	assert.Equal(t, DependencyStatus{Status: StatusUp}, report.Dependencies["temporal"])
+}
+
+func TestReadiness_OneDown(t *testing.T) {
+	c := NewChecker()
+	c.Add("postgres", up)
+	c.Add("nats", NATS(nil))
+
+	rr := httptest.NewRecorder()
+	c.Readiness(rr, httptest.NewRequest("GET", "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var report Report
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusUp, report.Dependencies["postgres"].Status)
+	assert.Equal(t, DependencyStatus{Status: StatusDown, Error: "not connected"}, report.Dependencies["nats"])
+}
+
+func TestRun_StuckCheckReportsDown(t *testing.T) {
+	c := NewChecker()
+	c.Add("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	report := c.Run(ctx)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Dependencies["stuck"].Error)
+}
+
+func TestTemporal(t *testing.T) {
+	mockClient := &mocks.Client{}
+	mockClient.On("CheckHealth", mock.Anything, mock.Anything).Return(&client.CheckHealthResponse{}, nil).Once()
+	mockClient.On("CheckHealth", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+	check := Temporal(mockClient)
+	assert.NoError(t, check(context.Background()))
+	assert.EqualError(t, check(context.Background()), "connection refused")
+	mockClient.AssertExpectations(t)
+}
+
+func TestLiveness(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Liveness(rr, httptest.NewRequest("GET", "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"up"}`, rr.Body.String())
+}
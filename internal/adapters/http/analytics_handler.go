@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"red-duck/analytics"
+	"red-duck/auth"
+	"red-duck/internal/pkg/problem"
+)
+
+const (
+	reportDateLayout   = "2006-01-02"
+	defaultReportDays  = 7
+	maxReportRangeDays = 366
+)
+
+type AnalyticsHandler struct {
+	Reports analytics.ReportRepository
+}
+
+func (h *AnalyticsHandler) Throughput(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.reportQuery(w, r)
+	if !ok {
+		return
+	}
+	days, err := h.Reports.DailyThroughput(r.Context(), q)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("failed to load throughput: %w", err))
+		return
+	}
+	writeReport(w, q, "days", days)
+}
+
+func (h *AnalyticsHandler) WaitTimes(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.reportQuery(w, r)
+	if !ok {
+		return
+	}
+	stats, err := h.Reports.WaitTimes(r.Context(), q)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("failed to load wait times: %w", err))
+		return
+	}
+	writeReport(w, q, "wait", stats)
+}
+
+func (h *AnalyticsHandler) BusiestHours(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.reportQuery(w, r)
+	if !ok {
+		return
+	}
+	hours, err := h.Reports.BusiestHours(r.Context(), q)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("failed to load busiest hours: %w", err))
+		return
+	}
+	writeReport(w, q, "hours", hours)
+}
+
+func (h *AnalyticsHandler) Counters(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.reportQuery(w, r)
+	if !ok {
+		return
+	}
+	counters, err := h.Reports.CounterThroughput(r.Context(), q)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("failed to load counter throughput: %w", err))
+		return
+	}
+	writeReport(w, q, "counters", counters)
+}
+
+// reportQuery builds the report scope from the caller's BusinessID claim and the
+// optional queue_id, from and to (YYYY-MM-DD, inclusive) query parameters.
+// It writes the error response itself and returns false when the request is invalid.
+func (h *AnalyticsHandler) reportQuery(w http.ResponseWriter, r *http.Request) (analytics.ReportQuery, bool) {
+	businessID, ok := auth.GetBusinessID(r.Context())
+	if !ok || businessID == "" {
+		problem.Write(w, http.StatusUnauthorized, "unauthorized: missing business context")
+		return analytics.ReportQuery{}, false
+	}
+
+	from, to, err := parseDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return analytics.ReportQuery{}, false
+	}
+
+	return analytics.ReportQuery{
+		BusinessID: businessID,
+		QueueID:    r.URL.Query().Get("queue_id"),
+		From:       from,
+		To:         to,
+	}, true
+}
+
+// parseDateRange parses an inclusive YYYY-MM-DD range, either end of which may be
+// empty, and returns it as [from, to) with to moved to the start of the next day.
+func parseDateRange(fromParam, toParam string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if toParam != "" {
+		t, err := time.Parse(reportDateLayout, toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid to date, expected YYYY-MM-DD")
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -(defaultReportDays - 1))
+	if fromParam != "" {
+		t, err := time.Parse(reportDateLayout, fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid from date, expected YYYY-MM-DD")
+		}
+		from = t
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	if to.Sub(from) > maxReportRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range must not exceed %d days", maxReportRangeDays)
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func writeReport(w http.ResponseWriter, q analytics.ReportQuery, key string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"business_id": q.BusinessID,
+		"queue_id":    q.QueueID,
+		"from":        q.From.Format(reportDateLayout),
+		"to":          q.To.AddDate(0, 0, -1).Format(reportDateLayout),
+		key:           data,
+	})
+}
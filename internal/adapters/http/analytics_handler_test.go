@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"red-duck/analytics"
+	"red-duck/auth"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) DailyThroughput(ctx context.Context, q analytics.ReportQuery) ([]analytics.DailyThroughput, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]analytics.DailyThroughput), args.Error(1)
+}
+
+func (m *MockReportRepository) WaitTimes(ctx context.Context, q analytics.ReportQuery) (analytics.WaitStats, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(analytics.WaitStats), args.Error(1)
+}
+
+func (m *MockReportRepository) BusiestHours(ctx context.Context, q analytics.ReportQuery) ([]analytics.HourlyLoad, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]analytics.HourlyLoad), args.Error(1)
+}
+
+func (m *MockReportRepository) CounterThroughput(ctx context.Context, q analytics.ReportQuery) ([]analytics.CounterThroughput, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]analytics.CounterThroughput), args.Error(1)
+}
+
+func withBusiness(r *http.Request, businessID string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), auth.BusinessIDKey, businessID))
+}
+
+func TestAnalyticsHandler_WaitTimes_ScopedToClaim(t *testing.T) {
+	repo := new(MockReportRepository)
+	h := &AnalyticsHandler{Reports: repo}
+
+	expected := analytics.ReportQuery{
+		BusinessID: "biz_123",
+		QueueID:    "q1",
+		From:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+	}
+	repo.On("WaitTimes", mock.Anything, expected).
+		Return(analytics.WaitStats{Samples: 3, AvgWaitSeconds: 120, P90WaitSeconds: 300}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/wait-times?queue_id=q1&from=2026-03-01&to=2026-03-07", nil)
+	rr := httptest.NewRecorder()
+	h.WaitTimes(rr, withBusiness(req, "biz_123"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var body struct {
+		BusinessID string              `json:"business_id"`
+		To         string              `json:"to"`
+		Wait       analytics.WaitStats `json:"wait"`
+	}
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, "biz_123", body.BusinessID)
+	assert.Equal(t, "2026-03-07", body.To)
+	assert.Equal(t, 300.0, body.Wait.P90WaitSeconds)
+	repo.AssertExpectations(t)
+}
+
+func TestAnalyticsHandler_RejectsBadRequests(t *testing.T) {
+	repo := new(MockReportRepository)
+	h := &AnalyticsHandler{Reports: repo}
+
+	t.Run("No Business Claim", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/analytics/throughput", nil)
+		rr := httptest.NewRecorder()
+		h.Throughput(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Bad Date", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/analytics/throughput?from=yesterday", nil)
+		rr := httptest.NewRecorder()
+		h.Throughput(rr, withBusiness(req, "biz_123"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Inverted Range", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/analytics/counters?from=2026-03-07&to=2026-03-01", nil)
+		rr := httptest.NewRecorder()
+		h.Counters(rr, withBusiness(req, "biz_123"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	repo.AssertNotCalled(t, "DailyThroughput", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CounterThroughput", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/core/ports"
+	"red-duck/internal/pkg/problem"
+)
+
+// AppointmentHandler serves the appointment routes on top of ports.AppointmentService.
+type AppointmentHandler struct {
+	Appointments ports.AppointmentService
+}
+
+// BookAppointmentRequest is the body of the booking route.
+type BookAppointmentRequest struct {
+	SlotStart time.Time `json:"slot_start"`
+}
+
+// AppointmentResponse is an appointment with, where the route issues one, the
+// ticket its guest acts with: on booking one valid until the no-show
+// deadline, on check-in one for the queue visit.
+type AppointmentResponse struct {
+	domain.Appointment
+	Token string `json:"token,omitempty"`
+}
+
+// BookAppointment reserves a seat in a slot for a new guest. Like joining,
+// it needs no account, and a retry with the same Idempotency-Key gets the
+// same appointment back with a fresh ticket.
+func (h *AppointmentHandler) BookAppointment(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+
+	var req BookAppointmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.SlotStart.IsZero() {
+		problem.Write(w, http.StatusBadRequest, "missing slot_start")
+		return
+	}
+	key, err := idempotencyKey(r)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	appt, _, err := h.Appointments.Book(r.Context(), businessID, queueID, req.SlotStart, key)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// As for joins, a replayed booking gets its ticket again: the response
+	// to the first may never have arrived
+	token, err := auth.GenerateTicketUntil(businessID, queueID, appt.UserID, appt.NoShowAt)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, "failed to issue ticket")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(AppointmentResponse{Appointment: *appt, Token: token})
+}
+
+// GetAppointment answers with an appointment. Requires
+// auth.WithAuthOrTicket, with RequireBusiness for staff.
+func (h *AppointmentHandler) GetAppointment(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	appt, err := h.Appointments.GetAppointment(r.Context(), businessID, queueID, r.PathValue("appointment_id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if !guestHolds(w, r, appt) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AppointmentResponse{Appointment: *appt})
+}
+
+// CheckIn puts the guest of an appointment in its queue, ahead of the waiting
+// walk-ins, and issues them a ticket for their place. Guests check themselves
+// in, staff anyone. Requires auth.WithAuthOrTicket, with RequireBusiness for
+// staff.
+func (h *AppointmentHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	appointmentID := r.PathValue("appointment_id")
+	staffID, ok := h.appointmentActor(w, r)
+	if !ok {
+		return
+	}
+	key, err := idempotencyKey(r)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	appt, err := h.Appointments.CheckIn(r.Context(), businessID, queueID, appointmentID, staffID, key)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	token, err := auth.GenerateTicket(businessID, queueID, appt.UserID)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, "failed to issue ticket")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AppointmentResponse{Appointment: *appt, Token: token})
+}
+
+// CancelAppointment gives a booked seat back. Requires auth.WithAuthOrTicket,
+// with RequireBusiness for staff.
+func (h *AppointmentHandler) CancelAppointment(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	appointmentID := r.PathValue("appointment_id")
+	staffID, ok := h.appointmentActor(w, r)
+	if !ok {
+		return
+	}
+
+	appt, err := h.Appointments.CancelAppointment(r.Context(), businessID, queueID, appointmentID, staffID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AppointmentResponse{Appointment: *appt})
+}
+
+// appointmentActor returns the staff member acting on the route's
+// appointment, or "" for its guest. Guests holding a ticket for someone
+// else's appointment are rejected.
+func (h *AppointmentHandler) appointmentActor(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if _, isGuest := auth.GetTicket(r.Context()); !isGuest {
+		staffID, _ := auth.GetUserID(r.Context())
+		return staffID, true
+	}
+	businessID, queueID := queueParams(r)
+	appt, err := h.Appointments.GetAppointment(r.Context(), businessID, queueID, r.PathValue("appointment_id"))
+	if err != nil {
+		writeError(w, r, err)
+		return "", false
+	}
+	return "", guestHolds(w, r, appt)
+}
+
+// guestHolds rejects guests whose ticket is not for appt. Staff pass.
+func guestHolds(w http.ResponseWriter, r *http.Request, appt *domain.Appointment) bool {
+	ticket, isGuest := auth.GetTicket(r.Context())
+	if !isGuest {
+		return true
+	}
+	if err := ticket.Matches(appt.BusinessID, appt.QueueID); err != nil || ticket.UserID != appt.UserID {
+		problem.Write(w, http.StatusForbidden, "ticket is for another appointment")
+		return false
+	}
+	return true
+}
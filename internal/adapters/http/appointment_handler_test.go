@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+)
+
+type MockAppointments struct {
+	mock.Mock
+}
+
+func (m *MockAppointments) Book(ctx context.Context, businessID, queueID string, slotStart time.Time, idempotencyKey string) (*domain.Appointment, bool, error) {
+	args := m.Called(businessID, queueID, slotStart, idempotencyKey)
+	appt, _ := args.Get(0).(*domain.Appointment)
+	return appt, args.Bool(1), args.Error(2)
+}
+
+func (m *MockAppointments) GetAppointment(ctx context.Context, businessID, queueID, appointmentID string) (*domain.Appointment, error) {
+	args := m.Called(businessID, queueID, appointmentID)
+	appt, _ := args.Get(0).(*domain.Appointment)
+	return appt, args.Error(1)
+}
+
+func (m *MockAppointments) CheckIn(ctx context.Context, businessID, queueID, appointmentID, staffID, idempotencyKey string) (*domain.Appointment, error) {
+	args := m.Called(businessID, queueID, appointmentID, staffID, idempotencyKey)
+	appt, _ := args.Get(0).(*domain.Appointment)
+	return appt, args.Error(1)
+}
+
+func (m *MockAppointments) CancelAppointment(ctx context.Context, businessID, queueID, appointmentID, staffID string) (*domain.Appointment, error) {
+	args := m.Called(businessID, queueID, appointmentID, staffID)
+	appt, _ := args.Get(0).(*domain.Appointment)
+	return appt, args.Error(1)
+}
+
+func bookedAppointment() *domain.Appointment {
+	slot := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	return &domain.Appointment{
+		ID: "20260302T1000Z-1", BusinessID: "biz_123", QueueID: "main", UserID: "guest-1",
+		SlotStart: slot, Seat: 1, Status: domain.AppointmentBooked,
+		CheckInFrom: slot.Add(-30 * time.Minute), NoShowAt: slot.Add(10 * time.Minute),
+	}
+}
+
+func TestAppointmentHandler_BookAppointment(t *testing.T) {
+	appointments := new(MockAppointments)
+	h := &AppointmentHandler{Appointments: appointments}
+	appt := bookedAppointment()
+	appt.NoShowAt = time.Now().Add(time.Hour).Truncate(time.Second)
+	appointments.On("Book", "biz_123", "main", appt.SlotStart, "key-1").Return(appt, false, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/appointments", strings.NewReader(`{"slot_start": "2026-03-02T10:00:00Z"}`))
+	req.SetPathValue("business_id", "biz_123")
+	req.SetPathValue("queue_id", "main")
+	req.Header.Set("Idempotency-Key", "key-1")
+	rr := httptest.NewRecorder()
+	h.BookAppointment(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var body AppointmentResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, "20260302T1000Z-1", body.ID)
+	assert.Equal(t, domain.AppointmentBooked, body.Status)
+	// The ticket lasts until the appointment's no-show deadline
+	ticket, err := auth.ParseTicket(body.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "guest-1", ticket.UserID)
+	assert.True(t, ticket.ExpiresAt.Time.Equal(appt.NoShowAt))
+
+	// A replayed key gets the appointment with its ticket again
+	appointments.On("Book", "biz_123", "main", appt.SlotStart, "key-2").Return(appt, true, nil)
+	req = httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/appointments", strings.NewReader(`{"slot_start": "2026-03-02T10:00:00Z"}`))
+	req.SetPathValue("business_id", "biz_123")
+	req.SetPathValue("queue_id", "main")
+	req.Header.Set("Idempotency-Key", "key-2")
+	rr = httptest.NewRecorder()
+	h.BookAppointment(rr, req)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	ticket, err = auth.ParseTicket(body.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "guest-1", ticket.UserID)
+	assert.True(t, ticket.ExpiresAt.Time.Equal(appt.NoShowAt))
+
+	rr = httptest.NewRecorder()
+	h.BookAppointment(rr, httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/appointments", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	appointments.AssertExpectations(t)
+}
+
+func TestAppointmentHandler_CheckIn(t *testing.T) {
+	checkIn := func(h *AppointmentHandler, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/appointments/20260302T1000Z-1/check-in", nil)
+		req.SetPathValue("business_id", "biz_123")
+		req.SetPathValue("queue_id", "main")
+		req.SetPathValue("appointment_id", "20260302T1000Z-1")
+		return withTicket(t, h.CheckIn, req, "biz_123", "main", userID)
+	}
+
+	t.Run("Guests check themselves in and get a ticket for the queue", func(t *testing.T) {
+		appointments := new(MockAppointments)
+		h := &AppointmentHandler{Appointments: appointments}
+		appointments.On("GetAppointment", "biz_123", "main", "20260302T1000Z-1").Return(bookedAppointment(), nil)
+		checkedIn := bookedAppointment()
+		checkedIn.CheckIn(checkedIn.SlotStart, 2)
+		appointments.On("CheckIn", "biz_123", "main", "20260302T1000Z-1", "", "").Return(checkedIn, nil)
+
+		rr := checkIn(h, "guest-1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body AppointmentResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, domain.AppointmentCheckedIn, body.Status)
+		assert.Equal(t, 2, body.Position)
+		_, err := auth.ParseTicket(body.Token)
+		assert.NoError(t, err)
+		appointments.AssertExpectations(t)
+	})
+
+	t.Run("Guests can't check in someone else", func(t *testing.T) {
+		appointments := new(MockAppointments)
+		h := &AppointmentHandler{Appointments: appointments}
+		appointments.On("GetAppointment", "biz_123", "main", "20260302T1000Z-1").Return(bookedAppointment(), nil)
+
+		rr := checkIn(h, "guest-2")
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		appointments.AssertNotCalled(t, "CheckIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Staff check anyone in", func(t *testing.T) {
+		appointments := new(MockAppointments)
+		h := &AppointmentHandler{Appointments: appointments}
+		appointments.On("CheckIn", "biz_123", "main", "20260302T1000Z-1", "staff-1", "").
+			Return(nil, domain.ErrCheckInNotOpen)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/appointments/20260302T1000Z-1/check-in", nil)
+		req.SetPathValue("appointment_id", "20260302T1000Z-1")
+		rr := asStaff(h.CheckIn, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), "check_in_not_open")
+		appointments.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+)
+
+type MockAuditLog struct {
+	mock.Mock
+}
+
+func (m *MockAuditLog) AppendAuditEntry(ctx context.Context, key string, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	args := m.Called(ctx, key, entry)
+	return args.Get(0).(domain.AuditEntry), args.Error(1)
+}
+
+func (m *MockAuditLog) ListAuditEntries(ctx context.Context, q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+// auditChain returns n chained entries of biz_123.
+func auditChain(t *testing.T, n int) []domain.AuditEntry {
+	entries := make([]domain.AuditEntry, n)
+	prev := ""
+	for i := range entries {
+		entries[i] = domain.AuditEntry{
+			ID: int64(i + 1), BusinessID: "biz_123", QueueID: "main", ActorID: "staff-1", ActorRole: auth.RoleStaff,
+			Action: domain.AuditCallNext, TargetUserID: "guest-1", At: time.Date(2026, 3, 1, 9, i, 0, 0, time.UTC),
+		}
+		require.NoError(t, entries[i].Chain(prev))
+		prev = entries[i].Hash
+	}
+	return entries
+}
+
+func TestAuditHandler_ListAuditLog(t *testing.T) {
+	list := func(h *AuditHandler, query string) (*httptest.ResponseRecorder, AuditLogResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/businesses/biz_123/audit-log"+query, nil)
+		req.SetPathValue("business_id", "biz_123")
+		rr := httptest.NewRecorder()
+		h.ListAuditLog(rr, req)
+		var resp AuditLogResponse
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		}
+		return rr, resp
+	}
+
+	t.Run("Verifies the chain of a page and hands out the next cursor", func(t *testing.T) {
+		log := new(MockAuditLog)
+		log.On("ListAuditEntries", mock.Anything, domain.AuditQuery{BusinessID: "biz_123", AfterID: 4, Limit: 2}).
+			Return(auditChain(t, 2), nil)
+
+		rr, resp := list(&AuditHandler{Log: log}, "?after=4&limit=2")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, resp.Entries, 2)
+		assert.True(t, resp.Verified)
+		assert.True(t, resp.Chained)
+		assert.Equal(t, int64(2), resp.NextAfter)
+	})
+
+	t.Run("Reports entries changed after they were written", func(t *testing.T) {
+		entries := auditChain(t, 3)
+		entries[1].ActorID = "someone-else"
+		log := new(MockAuditLog)
+		log.On("ListAuditEntries", mock.Anything, mock.Anything).Return(entries, nil)
+
+		_, resp := list(&AuditHandler{Log: log}, "")
+
+		assert.False(t, resp.Verified)
+		assert.Zero(t, resp.NextAfter)
+	})
+
+	t.Run("Checks filtered entries one by one", func(t *testing.T) {
+		entries := auditChain(t, 3)
+		log := new(MockAuditLog)
+		log.On("ListAuditEntries", mock.Anything, mock.MatchedBy(func(q domain.AuditQuery) bool {
+			return q.ActorID == "staff-1" && q.From.Equal(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+		})).Return([]domain.AuditEntry{entries[0], entries[2]}, nil)
+
+		_, resp := list(&AuditHandler{Log: log}, "?actor_id=staff-1&from=2026-03-01T09:00:00Z")
+
+		assert.True(t, resp.Verified)
+		assert.False(t, resp.Chained)
+	})
+
+	t.Run("Rejects invalid queries", func(t *testing.T) {
+		log := new(MockAuditLog)
+		for _, query := range []string{"?from=yesterday", "?limit=0", "?limit=501", "?after=-1",
+			"?from=2026-03-02T00:00:00Z&to=2026-03-01T00:00:00Z"} {
+			rr, _ := list(&AuditHandler{Log: log}, query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+		log.AssertNotCalled(t, "ListAuditEntries", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRequireRole(t *testing.T) {
+	handler := RequireRole(auth.RoleAdmin, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for role, want := range map[string]int{auth.RoleAdmin: http.StatusNoContent, auth.RoleStaff: http.StatusForbidden, "": http.StatusForbidden} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/businesses/biz_123/audit-log", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.RoleKey, role))
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		assert.Equal(t, want, rr.Code, role)
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"go.temporal.io/sdk/client"
+
+	"red-duck/auth"
+	"red-duck/internal/pkg/problem"
+)
+
+// AuthHandler runs the magic-code login flow on top of auth.LoginWorkflow.
+type AuthHandler struct {
+	Client    client.Client
+	TaskQueue string
+}
+
+// Login starts a LoginWorkflow, which emails the user a one-time code.
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req auth.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Email == "" {
+		problem.Write(w, http.StatusBadRequest, "missing email")
+		return
+	}
+
+	options := client.StartWorkflowOptions{
+		ID:        auth.LoginWorkflowID(req.Email),
+		TaskQueue: h.TaskQueue,
+	}
+	run, err := h.Client.ExecuteWorkflow(r.Context(), options, auth.LoginWorkflow, req.Email)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("failed to start workflow: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"workflowID": run.GetID(),
+		"runID":      run.GetRunID(),
+	})
+}
+
+// Verify submits the code to the pending LoginWorkflow and returns the token it issues.
+func (h *AuthHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var req auth.VerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Email == "" || req.Code == "" {
+		problem.Write(w, http.StatusBadRequest, "missing email or code")
+		return
+	}
+
+	workflowID := auth.LoginWorkflowID(req.Email)
+	if err := auth.SubmitCodeSignal.Send(r.Context(), h.Client, workflowID, req.Code); err != nil {
+		// If signal fails, workflow might not be running or completed
+		writeError(w, r, fmt.Errorf("failed to signal workflow: %w", err))
+		return
+	}
+
+	var token string
+	if err := h.Client.GetWorkflow(r.Context(), workflowID, "").Get(r.Context(), &token); err != nil {
+		slog.InfoContext(r.Context(), "Login failed", "workflow_id", workflowID, "error", err)
+		problem.Write(w, http.StatusUnauthorized, "authentication failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"token": token,
+	})
+}
+
+// Me echoes the identity of the authenticated caller. It must be wrapped in auth.WithAuth.
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.GetUserID(r.Context())
+	role, _ := auth.GetRole(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "You are authenticated!",
+		"user_id": userID,
+		"role":    role,
+	})
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+
+	"red-duck/auth"
+)
+
+func TestAuthHandler_Login(t *testing.T) {
+	c := new(mocks.Client)
+	h := &AuthHandler{Client: c, TaskQueue: "test-queue"}
+
+	run := new(mocks.WorkflowRun)
+	run.On("GetID").Return("auth-owner@example.com")
+	run.On("GetRunID").Return("run-1")
+	c.On("ExecuteWorkflow", mock.Anything, client.StartWorkflowOptions{
+		ID:        "auth-owner@example.com",
+		TaskQueue: "test-queue",
+	}, mock.Anything, "owner@example.com").Return(run, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"email": "owner@example.com"}`))
+	rr := httptest.NewRecorder()
+	h.Login(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"workflowID": "auth-owner@example.com", "runID": "run-1"}`, rr.Body.String())
+	c.AssertExpectations(t)
+}
+
+func TestAuthHandler_Login_RejectsMissingEmail(t *testing.T) {
+	c := new(mocks.Client)
+	h := &AuthHandler{Client: c}
+
+	rr := httptest.NewRecorder()
+	h.Login(rr, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	c.AssertNotCalled(t, "ExecuteWorkflow")
+}
+
+func TestAuthHandler_Verify(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &AuthHandler{Client: c}
+
+		run := new(mocks.WorkflowRun)
+		run.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*string) = "signed-token"
+		})
+		c.On("SignalWorkflow", mock.Anything, "auth-owner@example.com", "", auth.SignalSubmitCode, "123456").Return(nil)
+		c.On("GetWorkflow", mock.Anything, "auth-owner@example.com", "").Return(run)
+
+		body := `{"email": "owner@example.com", "code": "123456"}`
+		rr := httptest.NewRecorder()
+		h.Verify(rr, httptest.NewRequest(http.MethodPost, "/auth/verify", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"token": "signed-token"}`, rr.Body.String())
+		c.AssertExpectations(t)
+	})
+
+	t.Run("No pending login", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &AuthHandler{Client: c}
+		c.On("SignalWorkflow", mock.Anything, "auth-owner@example.com", "", auth.SignalSubmitCode, "123456").
+			Return(errors.New("workflow execution already completed"))
+
+		body := `{"email": "owner@example.com", "code": "123456"}`
+		rr := httptest.NewRecorder()
+		h.Verify(rr, httptest.NewRequest(http.MethodPost, "/auth/verify", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		c.AssertNotCalled(t, "GetWorkflow")
+	})
+
+	t.Run("Wrong code", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &AuthHandler{Client: c}
+
+		run := new(mocks.WorkflowRun)
+		run.On("Get", mock.Anything, mock.Anything).Return(errors.New("invalid code"))
+		c.On("SignalWorkflow", mock.Anything, "auth-owner@example.com", "", auth.SignalSubmitCode, "000000").Return(nil)
+		c.On("GetWorkflow", mock.Anything, "auth-owner@example.com", "").Return(run)
+
+		body := `{"email": "owner@example.com", "code": "000000"}`
+		rr := httptest.NewRecorder()
+		h.Verify(rr, httptest.NewRequest(http.MethodPost, "/auth/verify", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestAuthHandler_Me(t *testing.T) {
+	h := &AuthHandler{}
+
+	ctx := context.WithValue(context.Background(), auth.UserKey, "user-1")
+	ctx = context.WithValue(ctx, auth.RoleKey, "owner")
+	req := httptest.NewRequest(http.MethodGet, "/req/me", nil).WithContext(ctx)
+	rr := httptest.NewRecorder()
+	h.Me(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, "user-1", body["user_id"])
+	assert.Equal(t, "owner", body["role"])
+}
+
+func TestAuthHandler_Me_RequiresToken(t *testing.T) {
+	h := &AuthHandler{}
+
+	rr := httptest.NewRecorder()
+	auth.WithAuth(h.Me)(rr, httptest.NewRequest(http.MethodGet, "/req/me", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/problem"
+	"red-duck/internal/workflows"
+)
+
+// domainStatus is the HTTP status of each domain error code.
+var domainStatus = map[domain.ErrorCode]int{
+	domain.CodeUserAlreadyInQueue:  http.StatusConflict,
+	domain.CodeUserNotFound:        http.StatusNotFound,
+	domain.CodeQueueEmpty:          http.StatusConflict,
+	domain.CodeQueueNotFound:       http.StatusNotFound,
+	domain.CodeQueueClosed:         http.StatusConflict,
+	domain.CodeCapacityReached:     http.StatusConflict,
+	domain.CodeInvalidSlot:         http.StatusUnprocessableEntity,
+	domain.CodeSlotUnavailable:     http.StatusConflict,
+	domain.CodeAppointmentNotFound: http.StatusNotFound,
+	domain.CodeCheckInNotOpen:      http.StatusConflict,
+	domain.CodeAppointmentClosed:   http.StatusConflict,
+	domain.CodeOutsideGeofence:     http.StatusUnprocessableEntity,
+	domain.CodeTicketNotWaiting:    http.StatusConflict,
+	domain.CodeInvalidPartySize:    http.StatusUnprocessableEntity,
+	domain.CodeNoFittingParty:      http.StatusConflict,
+	domain.CodeMissingUserID:       http.StatusBadRequest,
+}
+
+// writeError answers with the problem for err. Domain errors, including those
+// returned by workflows as ApplicationErrors, are reported by their code.
+// Anything else is logged and reported as an internal error without details,
+// so Temporal and database messages don't leak to clients.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	err = workflows.DomainError(err)
+	if code := domain.CodeOf(err); code != "" {
+		problem.WriteCode(w, domainStatus[code], string(code), err.Error())
+		return
+	}
+	slog.ErrorContext(r.Context(), "Request failed", "error", err)
+	problem.Write(w, http.StatusInternalServerError, "internal error")
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+
+	"red-duck/analytics"
+	"red-duck/auth"
+	"red-duck/internal/adapters/objectstore"
+	"red-duck/internal/pkg/problem"
+)
+
+// downloadLinkTTL is how long a download link handed out for a finished export stays valid.
+const downloadLinkTTL = 15 * time.Minute
+
+type ExportHandler struct {
+	Client    client.Client
+	TaskQueue string
+	Store     objectstore.Store
+}
+
+type ExportJobRequest struct {
+	QueueID    string                 `json:"queue_id"`
+	From       string                 `json:"from"`
+	To         string                 `json:"to"`
+	Format     analytics.ExportFormat `json:"format"`
+	IncludePII bool                   `json:"include_pii"`
+}
+
+type ExportJobStatus struct {
+	JobID       string `json:"job_id"`
+	Status      string `json:"status"`
+	Rows        int64  `json:"rows,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+const (
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// StartExport starts an ExportWorkflow for the caller's business and returns its job ID.
+func (h *ExportHandler) StartExport(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := auth.GetBusinessID(r.Context())
+	if !ok || businessID == "" {
+		problem.Write(w, http.StatusUnauthorized, "unauthorized: missing business context")
+		return
+	}
+
+	var req ExportJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Format == "" {
+		req.Format = analytics.ExportFormatCSV
+	}
+	if !req.Format.Valid() {
+		problem.Write(w, http.StatusBadRequest, "format must be csv or ndjson")
+		return
+	}
+
+	from, to, err := parseDateRange(req.From, req.To)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	exportReq := analytics.ExportRequest{
+		JobID:      uuid.New().String(),
+		BusinessID: businessID,
+		QueueID:    req.QueueID,
+		From:       from,
+		To:         to,
+		Format:     req.Format,
+		IncludePII: req.IncludePII,
+	}
+
+	options := client.StartWorkflowOptions{
+		ID:        analytics.ExportWorkflowID(businessID, exportReq.JobID),
+		TaskQueue: h.TaskQueue,
+	}
+	if _, err := h.Client.ExecuteWorkflow(r.Context(), options, analytics.ExportWorkflow, exportReq); err != nil {
+		writeError(w, r, fmt.Errorf("failed to start export: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", path.Join(r.URL.Path, exportReq.JobID))
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(ExportJobStatus{JobID: exportReq.JobID, Status: ExportStatusRunning})
+}
+
+// GetExport reports the state of an export job and, once it finished, a download link.
+func (h *ExportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	businessID, ok := auth.GetBusinessID(r.Context())
+	if !ok || businessID == "" {
+		problem.Write(w, http.StatusUnauthorized, "unauthorized: missing business context")
+		return
+	}
+
+	jobID := r.PathValue("id")
+	if jobID == "" {
+		problem.Write(w, http.StatusBadRequest, "missing job id")
+		return
+	}
+	if !analytics.ValidJobID(jobID) {
+		problem.Write(w, http.StatusNotFound, "export job not found")
+		return
+	}
+
+	workflowID := analytics.ExportWorkflowID(businessID, jobID)
+	desc, err := h.Client.DescribeWorkflowExecution(r.Context(), workflowID, "")
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		problem.Write(w, http.StatusNotFound, "export job not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, fmt.Errorf("failed to describe export: %w", err))
+		return
+	}
+
+	status := ExportJobStatus{JobID: jobID}
+	switch desc.GetWorkflowExecutionInfo().GetStatus() {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		status.Status = ExportStatusRunning
+	case enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		var result analytics.ExportResult
+		if err := h.Client.GetWorkflow(r.Context(), workflowID, "").Get(r.Context(), &result); err != nil {
+			writeError(w, r, fmt.Errorf("failed to get export result: %w", err))
+			return
+		}
+		if h.Store == nil {
+			problem.Write(w, http.StatusServiceUnavailable, "object storage is not configured")
+			return
+		}
+		url, err := h.Store.URL(r.Context(), result.Key, downloadLinkTTL)
+		if err != nil {
+			writeError(w, r, fmt.Errorf("failed to create download link: %w", err))
+			return
+		}
+		status.Status = ExportStatusCompleted
+		status.Rows = result.Rows
+		status.DownloadURL = url
+	default:
+		status.Status = ExportStatusFailed
+		if err := h.Client.GetWorkflow(r.Context(), workflowID, "").Get(r.Context(), nil); err != nil {
+			status.Error = err.Error()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
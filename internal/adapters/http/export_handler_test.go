@@ -0,0 +1,151 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+
+	"red-duck/analytics"
+	"red-duck/internal/adapters/objectstore"
+)
+
+func TestExportHandler_StartExport(t *testing.T) {
+	c := new(mocks.Client)
+	h := &ExportHandler{Client: c, TaskQueue: "test-queue"}
+
+	var started analytics.ExportRequest
+	c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+		return strings.HasPrefix(o.ID, "export-biz_123-") && o.TaskQueue == "test-queue"
+	}), mock.Anything, mock.Anything).Return(new(mocks.WorkflowRun), nil).Run(func(args mock.Arguments) {
+		started = args.Get(3).(analytics.ExportRequest)
+	})
+
+	body := `{"from": "2026-03-01", "to": "2026-03-31", "format": "ndjson"}`
+	req := httptest.NewRequest(http.MethodPost, "/exports", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	h.StartExport(rr, withBusiness(req, "biz_123"))
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	var status ExportJobStatus
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&status))
+	assert.Equal(t, ExportStatusRunning, status.Status)
+	assert.Equal(t, started.JobID, status.JobID)
+	assert.Equal(t, "biz_123", started.BusinessID)
+	assert.Equal(t, analytics.ExportFormatNDJSON, started.Format)
+	assert.False(t, started.IncludePII)
+	c.AssertExpectations(t)
+}
+
+func TestExportHandler_StartExport_RejectsUnknownFormat(t *testing.T) {
+	c := new(mocks.Client)
+	h := &ExportHandler{Client: c}
+
+	req := httptest.NewRequest(http.MethodPost, "/exports", strings.NewReader(`{"format": "xlsx"}`))
+	rr := httptest.NewRecorder()
+	h.StartExport(rr, withBusiness(req, "biz_123"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	c.AssertNotCalled(t, "ExecuteWorkflow")
+}
+
+func describeWithStatus(s enumspb.WorkflowExecutionStatus) *workflowservice.DescribeWorkflowExecutionResponse {
+	return &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{Status: s},
+	}
+}
+
+func TestExportHandler_GetExport(t *testing.T) {
+	jobID := "0b6f8e3a-6c1d-4c8e-9a57-2f4d6f1e9b10"
+
+	t.Run("Running", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &ExportHandler{Client: c}
+		c.On("DescribeWorkflowExecution", mock.Anything, "export-biz_123-"+jobID, "").
+			Return(describeWithStatus(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/exports/"+jobID, nil)
+		req.SetPathValue("id", jobID)
+		rr := httptest.NewRecorder()
+		h.GetExport(rr, withBusiness(req, "biz_123"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"running"`)
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		c := new(mocks.Client)
+		run := new(mocks.WorkflowRun)
+		h := &ExportHandler{Client: c, Store: objectstore.NewLocalStore(t.TempDir())}
+		c.On("DescribeWorkflowExecution", mock.Anything, "export-biz_123-"+jobID, "").
+			Return(describeWithStatus(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED), nil)
+		c.On("GetWorkflow", mock.Anything, "export-biz_123-"+jobID, "").Return(run)
+		run.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*analytics.ExportResult) = analytics.ExportResult{Key: "exports/biz_123/" + jobID + ".csv", Rows: 7}
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/exports/"+jobID, nil)
+		req.SetPathValue("id", jobID)
+		rr := httptest.NewRecorder()
+		h.GetExport(rr, withBusiness(req, "biz_123"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var status ExportJobStatus
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&status))
+		assert.Equal(t, ExportStatusCompleted, status.Status)
+		assert.Equal(t, int64(7), status.Rows)
+		assert.True(t, strings.HasSuffix(status.DownloadURL, "exports/biz_123/"+jobID+".csv"))
+	})
+
+	t.Run("Other Business", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &ExportHandler{Client: c}
+		c.On("DescribeWorkflowExecution", mock.Anything, "export-biz_999-"+jobID, "").
+			Return(nil, serviceerror.NewNotFound("workflow not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/exports/"+jobID, nil)
+		req.SetPathValue("id", jobID)
+		rr := httptest.NewRecorder()
+		h.GetExport(rr, withBusiness(req, "biz_999"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Job ID that reaches into the business ID", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &ExportHandler{Client: c}
+
+		// export-biz + -123- + jobID would name biz_123's job as biz's
+		req := httptest.NewRequest(http.MethodGet, "/exports/123-"+jobID, nil)
+		req.SetPathValue("id", "123-"+jobID)
+		rr := httptest.NewRecorder()
+		h.GetExport(rr, withBusiness(req, "biz"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		c.AssertNotCalled(t, "DescribeWorkflowExecution", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Temporal unavailable", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := &ExportHandler{Client: c}
+		c.On("DescribeWorkflowExecution", mock.Anything, "export-biz_123-"+jobID, "").
+			Return(nil, serviceerror.NewUnavailable("frontend down"))
+
+		req := httptest.NewRequest(http.MethodGet, "/exports/"+jobID, nil)
+		req.SetPathValue("id", jobID)
+		rr := httptest.NewRecorder()
+		h.GetExport(rr, withBusiness(req, "biz_123"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
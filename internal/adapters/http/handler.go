@@ -1,16 +1,19 @@
 package http
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
-	"go.temporal.io/sdk/client"
-
 	"red-duck/auth"
 	"red-duck/internal/core/domain"
-	"red-duck/internal/pkg/update"
+	"red-duck/internal/core/ports"
+	"red-duck/internal/pkg/problem"
 	"red-duck/internal/workflows"
 )
 
@@ -22,218 +25,405 @@ type Media struct {
 type QueueStatus struct {
 	BusinessID           string `json:"business_id"`
 	QueueLength          int    `json:"queue_length"`
+	Position             int    `json:"position"`
 	EstimatedWaitMinutes int    `json:"estimated_wait_minutes"`
 	Media                Media  `json:"media"`
+	// ConfirmBy is when the holder must confirm they are on their way by,
+	// while they are asked to.
+	ConfirmBy *time.Time `json:"confirm_by,omitempty"`
+}
+
+// ConfirmTicketRequest is the optional body of the confirm route. A location
+// is checked against the business's geofence.
+type ConfirmTicketRequest struct {
+	Location *domain.Location `json:"location,omitempty"`
 }
 
+// QueueHandler serves the queue routes on top of ports.QueueService.
 type QueueHandler struct {
-	Client    client.Client
-	TaskQueue string
+	Queues ports.QueueService
+	// KeepJoinTimeOnTransfer is whether transferred guests keep their join
+	// time when the request doesn't say.
+	KeepJoinTimeOnTransfer bool
 }
 
-func (h *QueueHandler) CreateQueue(w http.ResponseWriter, r *http.Request) {
-	businessID := r.URL.Query().Get("business_id")
-	queueID := r.URL.Query().Get("queue_id")
+// queueParams returns the queue named by the path of a /v1 route, or by the
+// query string of a legacy one.
+func queueParams(r *http.Request) (businessID, queueID string) {
+	businessID, queueID = r.PathValue("business_id"), r.PathValue("queue_id")
+	if businessID == "" && queueID == "" {
+		businessID, queueID = r.URL.Query().Get("business_id"), r.URL.Query().Get("queue_id")
+	}
+	return businessID, queueID
+}
 
-	if businessID == "" || queueID == "" {
-		http.Error(w, "missing business_id or queue_id", http.StatusBadRequest)
-		return
+// RequireBusiness rejects staff requests whose business, as queueParams reads
+// it, is not the business of their token. Requires auth.WithAuth.
+func RequireBusiness(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		businessID, _ := auth.GetBusinessID(r.Context())
+		if requested, _ := queueParams(r); businessID == "" || businessID != requested {
+			problem.Write(w, http.StatusForbidden, "token is not for this business")
+			return
+		}
+		next(w, r)
 	}
+}
 
-	workflowID := fmt.Sprintf("%s:%s", businessID, queueID)
+func (h *QueueHandler) CreateQueue(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
 
-	options := client.StartWorkflowOptions{
-		ID:        workflowID,
-		TaskQueue: h.TaskQueue,
+	if businessID == "" || queueID == "" {
+		problem.Write(w, http.StatusBadRequest, "missing business_id or queue_id")
+		return
 	}
 
-	// We use the string name "BusinessQueueWorkflow" to avoid importing the adapter package
-	run, err := h.Client.ExecuteWorkflow(r.Context(), options, "BusinessQueueWorkflow", businessID, queueID)
+	runID, err := h.Queues.CreateQueue(r.Context(), businessID, queueID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to start workflow: %v", err), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{
-		"workflow_id": run.GetID(),
-		"run_id":      run.GetRunID(),
+		"workflow_id": workflows.QueueWorkflowID(businessID, queueID),
+		"run_id":      runID,
 	})
 }
 
-func (h *QueueHandler) JoinQueue(w http.ResponseWriter, r *http.Request) {
-	businessID := r.URL.Query().Get("business_id")
-	queueID := r.URL.Query().Get("queue_id")
-
+// DeleteQueue shuts the queue down and answers with its final report. The
+// optional reason query parameter is recorded in the report and sent to the
+// guests whose tickets are cancelled. Requires RequireBusiness.
+func (h *QueueHandler) DeleteQueue(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
 	if businessID == "" || queueID == "" {
-		http.Error(w, "missing business_id or queue_id", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, "missing business_id or queue_id")
 		return
 	}
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		reason = "queue deleted"
+	}
+	staffID, _ := auth.GetUserID(r.Context())
 
-	var req domain.JoinRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	report, err := h.Queues.DeleteQueue(r.Context(), businessID, queueID, reason, staffID)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	workflowID := fmt.Sprintf("%s:%s", businessID, queueID)
-	joinQueueUpdate := update.New[domain.JoinRequest, int]("JoinQueue")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+const (
+	defaultQueuePageSize = 20
+	maxQueuePageSize     = 100
+)
 
-	// Call UpdateWorkflow and wait for it to complete
-	handle, err := h.Client.UpdateWorkflow(r.Context(), client.UpdateWorkflowOptions{
-		WorkflowID:   workflowID,
-		UpdateID:     fmt.Sprintf("join-%s-%d", req.UserID, time.Now().UnixNano()),
-		WaitForStage: client.WorkflowUpdateStageCompleted,
-		UpdateName:   joinQueueUpdate.Name(),
-		Args:         []interface{}{req},
-	})
+// QueueList is a page of a business's queues. NextPageToken is empty on the last page.
+type QueueList struct {
+	Queues        []domain.QueueSummary `json:"queues"`
+	NextPageToken string                `json:"next_page_token,omitempty"`
+}
+
+// ListQueues lists the business's running queues, optionally only those in
+// the given state. Requires RequireBusiness.
+func (h *QueueHandler) ListQueues(w http.ResponseWriter, r *http.Request) {
+	businessID := r.PathValue("business_id")
+	query := r.URL.Query()
 
+	state := domain.QueueState(query.Get("state"))
+	if state != "" && !state.Valid() {
+		problem.Write(w, http.StatusBadRequest, "state must be OPEN or CLOSED")
+		return
+	}
+	pageSize := defaultQueuePageSize
+	if v := query.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxQueuePageSize {
+			problem.Write(w, http.StatusBadRequest, fmt.Sprintf("page_size must be between 1 and %d", maxQueuePageSize))
+			return
+		}
+		pageSize = n
+	}
+	pageToken, err := base64.RawURLEncoding.DecodeString(query.Get("page_token"))
 	if err != nil {
-		// If the validator rejects the update, UpdateWorkflow returns an error.
-		// We return 409 Conflict as requested.
-		http.Error(w, fmt.Sprintf("Update rejected or failed: %v", err), http.StatusConflict)
+		problem.Write(w, http.StatusBadRequest, "invalid page_token")
 		return
 	}
+	if len(pageToken) == 0 {
+		pageToken = nil
+	}
 
-	var position int
-	if err := handle.Get(r.Context(), &position); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to get update result: %v", err), http.StatusInternalServerError)
+	queues, next, err := h.Queues.ListQueues(r.Context(), businessID, state, pageSize, pageToken)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	response := map[string]int{"position": position}
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
-	}
+	json.NewEncoder(w).Encode(QueueList{
+		Queues:        queues,
+		NextPageToken: base64.RawURLEncoding.EncodeToString(next),
+	})
 }
 
-func (h *QueueHandler) LeaveQueue(w http.ResponseWriter, r *http.Request) {
+// JoinQueue is GuestJoin with the queue given in the query string.
+func (h *QueueHandler) JoinQueue(w http.ResponseWriter, r *http.Request) {
 	businessID := r.URL.Query().Get("business_id")
 	queueID := r.URL.Query().Get("queue_id")
 
 	if businessID == "" || queueID == "" {
-		http.Error(w, "missing business_id or queue_id", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, "missing business_id or queue_id")
+		return
+	}
+
+	h.joinGuest(w, r, businessID, queueID, 0, http.StatusOK)
+}
+
+// CreateTicketRequest is the optional body of the /v1 join.
+type CreateTicketRequest struct {
+	// PartySize is how many guests join on the ticket; 0 is one.
+	PartySize int `json:"party_size"`
+}
+
+// CreateTicket is the /v1 join: the queue comes from the path and the new
+// ticket is answered with 201.
+func (h *QueueHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	if businessID == "" || queueID == "" {
+		problem.Write(w, http.StatusBadRequest, "missing business_id or queue_id")
 		return
 	}
+	var req CreateTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	h.joinGuest(w, r, businessID, queueID, req.PartySize, http.StatusCreated)
+}
+
+// GuestJoinRequest is the body of the public join route.
+type GuestJoinRequest struct {
+	BusinessID string `json:"business_id"`
+	QueueID    string `json:"queue_id"`
+	PartySize  int    `json:"party_size"`
+}
+
+// GuestJoinResponse gives the guest their generated ID and the ticket that
+// leave and status require.
+type GuestJoinResponse struct {
+	UserID               string `json:"user_id"`
+	Position             int    `json:"position"`
+	EstimatedWaitMinutes int    `json:"estimated_wait_minutes"`
+	// Token is the guest's ticket, left out when a join is replayed.
+	Token string `json:"token,omitempty"`
+}
 
-	var req domain.JoinRequest
+// GuestJoin lets a customer join an existing queue without an account.
+// The guest ID is always generated: callers can't pick someone else's.
+func (h *QueueHandler) GuestJoin(w http.ResponseWriter, r *http.Request) {
+	var req GuestJoinRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.BusinessID == "" || req.QueueID == "" {
+		problem.Write(w, http.StatusBadRequest, "missing business_id or queue_id")
 		return
 	}
 
-	workflowID := fmt.Sprintf("%s:%s", businessID, queueID)
-	leaveQueueUpdate := update.New[domain.JoinRequest, int]("LeaveQueue")
+	h.joinGuest(w, r, req.BusinessID, req.QueueID, req.PartySize, http.StatusOK)
+}
 
-	handle, err := h.Client.UpdateWorkflow(r.Context(), client.UpdateWorkflowOptions{
-		WorkflowID:   workflowID,
-		UpdateID:     fmt.Sprintf("leave-%s-%d", req.UserID, time.Now().UnixNano()),
-		WaitForStage: client.WorkflowUpdateStageCompleted,
-		UpdateName:   leaveQueueUpdate.Name(),
-		Args:         []interface{}{req},
-	})
+// joinGuest adds a new guest, with their party, to the queue and issues them
+// a ticket for their place. A retried join with the same Idempotency-Key gets
+// the same guest and place back, with a fresh ticket: the client that timed
+// out never saw the first one, and the key is its own secret.
+func (h *QueueHandler) joinGuest(w http.ResponseWriter, r *http.Request, businessID, queueID string, partySize, status int) {
+	key, err := idempotencyKey(r)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
+	joined, err := h.Queues.JoinQueue(r.Context(), businessID, queueID, partySize, key)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Update failed: %v", err), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	var remaining int
-	if err := handle.Get(r.Context(), &remaining); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to get update result: %v", err), http.StatusInternalServerError)
+	token, err := auth.GenerateTicket(businessID, queueID, joined.UserID)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, "failed to issue ticket")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]int{"remaining_users": remaining})
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(GuestJoinResponse{
+		UserID:               joined.UserID,
+		Position:             joined.Position,
+		EstimatedWaitMinutes: joined.WaitMinutes,
+		Token:                token,
+	})
 }
 
-func (h *QueueHandler) GetQueueStatus(w http.ResponseWriter, r *http.Request) {
-	businessID := r.URL.Query().Get("business_id")
-	queueID := r.URL.Query().Get("queue_id")
-
-	if businessID == "" || queueID == "" {
-		http.Error(w, "missing business_id or queue_id", http.StatusBadRequest)
+// LeaveQueue removes the holder of the request's ticket from its queue.
+// Requires auth.WithTicket.
+func (h *QueueHandler) LeaveQueue(w http.ResponseWriter, r *http.Request) {
+	ticket, ok := ticketForQueue(w, r)
+	if !ok {
 		return
 	}
+	key, err := idempotencyKey(r)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	remaining, err := h.Queues.LeaveQueue(r.Context(), ticket.BusinessID, ticket.QueueID, ticket.UserID, key)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"remaining_users": remaining})
+}
 
-	workflowID := fmt.Sprintf("%s:%s", businessID, queueID)
+// ConfirmTicket records that the holder of the request's ticket is on their
+// way, or with a location at the business, has arrived. Requires
+// auth.WithTicket.
+func (h *QueueHandler) ConfirmTicket(w http.ResponseWriter, r *http.Request) {
+	ticket, ok := ticketForQueue(w, r)
+	if !ok {
+		return
+	}
+	var req ConfirmTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
 
-	response, err := h.Client.QueryWorkflow(r.Context(), workflowID, "", "GetStatus")
+	confirmed, err := h.Queues.ConfirmTicket(r.Context(), ticket.BusinessID, ticket.QueueID, ticket.UserID, req.Location)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Query failed: %v", err), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	var q domain.Queue
-	if err := response.Get(&q); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to decode query result: %v", err), http.StatusInternalServerError)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(confirmed)
+}
+
+// GetQueueStatus reports the queue of the request's ticket, with the holder's
+// place in it. Requires auth.WithTicket.
+func (h *QueueHandler) GetQueueStatus(w http.ResponseWriter, r *http.Request) {
+	ticket, ok := ticketForQueue(w, r)
+	if !ok {
+		return
+	}
+	q, err := h.Queues.GetQueueStatus(r.Context(), ticket.BusinessID, ticket.QueueID)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	queueLength := q.Len()
-	estimatedWait := queueLength * 5 // 5 minutes per person
+	position := q.GetPosition(ticket.UserID)
 
 	// Build the response
 	status := QueueStatus{
 		BusinessID:           q.BusinessID,
-		QueueLength:          queueLength,
-		EstimatedWaitMinutes: estimatedWait,
+		QueueLength:          q.Len(),
+		Position:             position,
+		EstimatedWaitMinutes: q.WaitMinutes(position),
 		Media: Media{
 			LogoURL:   fmt.Sprintf("http://localhost:2015/media/%s/logo.png", q.BusinessID),
 			HeaderURL: fmt.Sprintf("http://localhost:2015/media/%s/header.jpg", q.BusinessID),
 		},
 	}
+	if pos := status.Position; pos > 0 && !q.Tickets[pos-1].ConfirmBy.IsZero() {
+		status.ConfirmBy = &q.Tickets[pos-1].ConfirmBy
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(status)
 }
 
+// ticketForQueue returns the ticket set by auth.WithTicket, rejecting it when
+// the request names another business, queue or guest.
+func ticketForQueue(w http.ResponseWriter, r *http.Request) (*auth.TicketClaims, bool) {
+	ticket, ok := auth.GetTicket(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, "missing ticket")
+		return nil, false
+	}
+	businessID, queueID := queueParams(r)
+	if err := ticket.Matches(businessID, queueID); err != nil {
+		problem.Write(w, http.StatusForbidden, err.Error())
+		return nil, false
+	}
+	if userID := r.PathValue("user_id"); userID != "" && userID != ticket.UserID {
+		problem.Write(w, http.StatusForbidden, "ticket is for another guest")
+		return nil, false
+	}
+	return ticket, true
+}
+
 func (h *QueueHandler) CallNext(w http.ResponseWriter, r *http.Request) {
 	// 1. Get BusinessID from Auth Context
 	businessID, ok := auth.GetBusinessID(r.Context())
 	if !ok || businessID == "" {
-		http.Error(w, "unauthorized: missing business context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, "unauthorized: missing business context")
 		return
 	}
 
 	// 2. Get QueueID from URL
-	// Using Go 1.22+ path value
-	queueID := r.PathValue("id")
+	// Using Go 1.22+ path value; the legacy route names it "id"
+	queueID := r.PathValue("queue_id")
 	if queueID == "" {
-		http.Error(w, "missing queue_id", http.StatusBadRequest)
+		queueID = r.PathValue("id")
+	}
+	if queueID == "" {
+		problem.Write(w, http.StatusBadRequest, "missing queue_id")
 		return
 	}
 
 	// 3. Parse Body
 	var req struct {
 		CounterID string `json:"counter_id"`
+		Capacity  int    `json:"capacity"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if req.CounterID == "" {
-		http.Error(w, "missing counter_id", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, "missing counter_id")
+		return
+	}
+	if req.Capacity < 0 {
+		problem.Write(w, http.StatusBadRequest, "capacity must not be negative")
 		return
 	}
 
-	// 4. Signal Workflow
-	workflowID := fmt.Sprintf("%s:%s", businessID, queueID)
-	// We use SignalCallNext const from workflows package
-	signal := workflows.CallNextSignal{
-		CounterID: req.CounterID,
+	key, err := idempotencyKey(r)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
 	}
+	staffID, _ := auth.GetUserID(r.Context())
 
-	err := h.Client.SignalWorkflow(r.Context(), workflowID, "", workflows.SignalCallNext, signal)
+	// 4. Call next, waiting for the called ticket
+	ticket, err := h.Queues.CallNext(r.Context(), businessID, queueID, req.CounterID, req.Capacity, staffID, key)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to signal workflow: %v", err), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"status": "signal_sent"})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
 }
@@ -0,0 +1,564 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+
+	"red-duck/auth"
+	"red-duck/internal/adapters/secondary"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/problem"
+	"red-duck/internal/workflows"
+)
+
+// newQueueHandler serves the queue routes from a mocked Temporal client.
+func newQueueHandler(c client.Client) *QueueHandler {
+	return &QueueHandler{Queues: secondary.NewTemporalQueueClient(c, "test-queue")}
+}
+
+func TestQueueHandler_GuestJoin(t *testing.T) {
+	t.Run("Joins the shared queue", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		var joined domain.JoinRequest
+		c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+			joined = o.Args[0].(domain.JoinRequest)
+			return o.WorkflowID == "biz_123:main" && o.UpdateName == "JoinQueue" &&
+				o.UpdateID == "join-"+joined.UserID
+		})).Return(joinUpdates(3, 15), nil)
+
+		body := `{"business_id": "biz_123", "queue_id": "main"}`
+		rr := httptest.NewRecorder()
+		h.GuestJoin(rr, httptest.NewRequest(http.MethodPost, "/queues/join", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp GuestJoinResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Len(t, resp.UserID, 36)
+		assert.Equal(t, joined.UserID, resp.UserID)
+		assert.Equal(t, 3, resp.Position)
+		assert.Equal(t, 15, resp.EstimatedWaitMinutes)
+		assert.NotEmpty(t, resp.Token)
+
+		// The token is a ticket for this place
+		ticket, err := auth.ParseTicket(resp.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, resp.UserID, ticket.UserID)
+		assert.NoError(t, ticket.Matches("biz_123", "main"))
+	})
+
+	t.Run("Joins with a party", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+			return o.Args[0].(domain.JoinRequest).PartySize == 4
+		})).Return(joinUpdates(2, 9+11), nil)
+
+		body := `{"business_id": "biz_123", "queue_id": "main", "party_size": 4}`
+		rr := httptest.NewRecorder()
+		h.GuestJoin(rr, httptest.NewRequest(http.MethodPost, "/queues/join", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp GuestJoinResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		// The workflow's estimate, which weighs the party ahead by its size
+		assert.Equal(t, 9+11, resp.EstimatedWaitMinutes)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("Party too large", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).
+			Return(nil, fromWorkflow(domain.ErrInvalidPartySize))
+
+		body := `{"business_id": "biz_123", "queue_id": "main", "party_size": 50}`
+		rr := httptest.NewRecorder()
+		h.GuestJoin(rr, httptest.NewRequest(http.MethodPost, "/queues/join", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"invalid_party_size"`)
+	})
+
+	t.Run("Queue not found", func(t *testing.T) {
+		c := new(mocks.Client)
+		defer func() { c.AssertExpectations(t) }()
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).
+			Return(nil, serviceerror.NewNotFound("workflow not found"))
+
+		body := `{"business_id": "biz_123", "queue_id": "missing"}`
+		rr := httptest.NewRecorder()
+		h.GuestJoin(rr, httptest.NewRequest(http.MethodPost, "/queues/join", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"queue_not_found"`)
+	})
+
+	t.Run("Join rejected", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).
+			Return(nil, fromWorkflow(domain.ErrQueueClosed))
+
+		body := `{"business_id": "biz_123", "queue_id": "main"}`
+		rr := httptest.NewRecorder()
+		h.GuestJoin(rr, httptest.NewRequest(http.MethodPost, "/queues/join", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), `"code":"queue_closed"`)
+	})
+
+	t.Run("Requires business and queue", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		rr := httptest.NewRecorder()
+		h.GuestJoin(rr, httptest.NewRequest(http.MethodPost, "/queues/join", strings.NewReader(`{"business_id": "biz_123"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		c.AssertNotCalled(t, "UpdateWorkflow")
+	})
+}
+
+func TestQueueHandler_JoinQueue_IgnoresBodyUserID(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	handle := new(mocks.WorkflowUpdateHandle)
+	handle.On("Get", mock.Anything, mock.Anything).Return(nil)
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		return o.WorkflowID == "biz_123:main" && o.Args[0].(domain.JoinRequest).UserID != "victim"
+	})).Return(handle, nil)
+
+	body := `{"userId": "victim"}`
+	rr := httptest.NewRecorder()
+	h.JoinQueue(rr, httptest.NewRequest(http.MethodPost, "/join_queue?business_id=biz_123&queue_id=main", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	c.AssertExpectations(t)
+}
+
+// joinUpdates answers JoinQueue updates at position, with the estimated
+// wait, the way Temporal does:
+// an update ID sent again gets the first result back, nonce and all.
+func joinUpdates(position, wait int) func(context.Context, client.UpdateWorkflowOptions) (client.WorkflowUpdateHandle, error) {
+	first := map[string]string{}
+	return func(_ context.Context, o client.UpdateWorkflowOptions) (client.WorkflowUpdateHandle, error) {
+		if _, ok := first[o.UpdateID]; !ok {
+			first[o.UpdateID] = o.Args[0].(domain.JoinRequest).Nonce
+		}
+		nonce := first[o.UpdateID]
+		handle := new(mocks.WorkflowUpdateHandle)
+		handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*workflows.JoinResult) = workflows.JoinResult{Position: position, WaitMinutes: wait, Nonce: nonce}
+		})
+		return handle, nil
+	}
+}
+
+// withTicket sends r through auth.WithTicket with a ticket for the given place.
+func withTicket(t *testing.T, next http.HandlerFunc, r *http.Request, businessID, queueID, userID string) *httptest.ResponseRecorder {
+	t.Helper()
+	ticket, err := auth.GenerateTicket(businessID, queueID, userID)
+	assert.NoError(t, err)
+	r.Header.Set("Authorization", "Bearer "+ticket)
+	rr := httptest.NewRecorder()
+	auth.WithTicket(next)(rr, r)
+	return rr
+}
+
+func TestQueueHandler_LeaveQueue(t *testing.T) {
+	t.Run("Leaves as the ticket holder", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		handle := new(mocks.WorkflowUpdateHandle)
+		handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*int) = 2
+		})
+		c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+			return o.WorkflowID == "biz_123:main" && o.UpdateName == "LeaveQueue" &&
+				o.Args[0] == domain.JoinRequest{UserID: "guest-1"}
+		})).Return(handle, nil)
+
+		// The body names someone else: it is ignored
+		req := httptest.NewRequest(http.MethodPost, "/leave_queue?business_id=biz_123&queue_id=main", strings.NewReader(`{"userId": "victim"}`))
+		rr := withTicket(t, h.LeaveQueue, req, "biz_123", "main", "guest-1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"remaining_users": 2}`, rr.Body.String())
+		c.AssertExpectations(t)
+	})
+
+	t.Run("Not in the queue", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).Return(nil, fromWorkflow(domain.ErrUserNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/leave_queue", nil)
+		rr := withTicket(t, h.LeaveQueue, req, "biz_123", "main", "guest-1")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"user_not_found"`)
+	})
+
+	t.Run("Ticket for another queue", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		req := httptest.NewRequest(http.MethodPost, "/leave_queue?business_id=biz_123&queue_id=other", nil)
+		rr := withTicket(t, h.LeaveQueue, req, "biz_123", "main", "guest-1")
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		c.AssertNotCalled(t, "UpdateWorkflow")
+	})
+
+	t.Run("Requires a ticket", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		rr := httptest.NewRecorder()
+		auth.WithTicket(h.LeaveQueue)(rr, httptest.NewRequest(http.MethodPost, "/leave_queue?business_id=biz_123&queue_id=main", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		c.AssertNotCalled(t, "UpdateWorkflow")
+	})
+}
+
+func TestQueueHandler_ConfirmTicket(t *testing.T) {
+	t.Run("Confirms with a location", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		handle := new(mocks.WorkflowUpdateHandle)
+		handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*domain.Ticket) = domain.Ticket{UserID: "guest-1", Status: domain.TicketStatusWaiting, Remote: true, Confirmed: true, Arrived: true}
+		})
+		c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+			req := o.Args[0].(workflows.ConfirmTicketRequest)
+			return o.WorkflowID == "biz_123:main" && o.UpdateName == workflows.UpdateConfirm &&
+				req.UserID == "guest-1" && req.Location != nil && req.Location.Latitude == 52.3676
+		})).Return(handle, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/tickets/guest-1/confirm",
+			strings.NewReader(`{"location": {"latitude": 52.3676, "longitude": 4.9041}}`))
+		req.SetPathValue("business_id", "biz_123")
+		req.SetPathValue("queue_id", "main")
+		req.SetPathValue("user_id", "guest-1")
+		rr := withTicket(t, h.ConfirmTicket, req, "biz_123", "main", "guest-1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"arrived":true`)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("Outside the geofence", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).Return(nil, fromWorkflow(domain.ErrOutsideGeofence))
+
+		// Without a body, the guest confirms they are on their way
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/tickets/guest-1/confirm", nil)
+		rr := withTicket(t, h.ConfirmTicket, req, "biz_123", "main", "guest-1")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"outside_geofence"`)
+	})
+
+	t.Run("Ticket for another guest", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/tickets/guest-2/confirm", nil)
+		req.SetPathValue("user_id", "guest-2")
+		rr := withTicket(t, h.ConfirmTicket, req, "biz_123", "main", "guest-1")
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		c.AssertNotCalled(t, "UpdateWorkflow")
+	})
+}
+
+func TestQueueHandler_GetQueueStatus(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	q := domain.NewQueue("main", "biz_123")
+	q.AddUser("called", time.Now())
+	q.AddUser("someone", time.Now())
+	q.AddUser("guest-1", time.Now())
+	q.AddUser("behind", time.Now())
+	q.Tickets[0].Status = domain.TicketStatusReady
+	q.Tickets[1].PartySize = 3
+	value := new(mocks.Value)
+	value.On("Get", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(0).(*domain.Queue) = q.Snapshot()
+	})
+	c.On("QueryWorkflow", mock.Anything, "biz_123:main", "", "GetStatus").Return(value, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/queue_status", nil)
+	rr := withTicket(t, h.GetQueueStatus, req, "biz_123", "main", "guest-1")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var status QueueStatus
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&status))
+	assert.Equal(t, 4, status.QueueLength)
+	assert.Equal(t, 3, status.Position)
+	// The party of three still waiting ahead and their own turn; the called
+	// guest and whoever is behind don't count
+	assert.Equal(t, 9+5, status.EstimatedWaitMinutes)
+}
+
+func TestQueueHandler_JoinQueue_IdempotencyKey(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	var updateIDs []string
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		updateIDs = append(updateIDs, o.UpdateID)
+		return true
+	})).Return(joinUpdates(1, 5), nil)
+
+	join := func(key string) GuestJoinResponse {
+		req := httptest.NewRequest(http.MethodPost, "/join_queue?business_id=biz_123&queue_id=main", nil)
+		req.Header.Set(IdempotencyKeyHeader, key)
+		rr := httptest.NewRecorder()
+		h.JoinQueue(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp GuestJoinResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		return resp
+	}
+
+	first := join("key-1")
+	retry := join("key-1")
+	other := join("key-2")
+
+	assert.Equal(t, first.UserID, retry.UserID)
+	assert.Equal(t, first.Position, retry.Position)
+	assert.Equal(t, updateIDs[0], updateIDs[1])
+	assert.NotEqual(t, first.UserID, other.UserID)
+	// A retry that never saw the first ticket gets one for the same guest
+	assert.NotEmpty(t, first.Token)
+	ticket, err := auth.ParseTicket(retry.Token)
+	require.NoError(t, err)
+	assert.Equal(t, first.UserID, ticket.UserID)
+	assert.NotEmpty(t, other.Token)
+	assert.NotEqual(t, updateIDs[0], updateIDs[2])
+
+	t.Run("Key too long", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/join_queue?business_id=biz_123&queue_id=main", nil)
+		req.Header.Set(IdempotencyKeyHeader, strings.Repeat("k", 256))
+		rr := httptest.NewRecorder()
+		h.JoinQueue(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestQueueHandler_LeaveQueue_IdempotencyKey(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	handle := new(mocks.WorkflowUpdateHandle)
+	handle.On("Get", mock.Anything, mock.Anything).Return(nil)
+	var updateIDs []string
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		updateIDs = append(updateIDs, o.UpdateID)
+		return true
+	})).Return(handle, nil)
+
+	leave := func(userID string) {
+		req := httptest.NewRequest(http.MethodPost, "/leave_queue", nil)
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		rr := withTicket(t, h.LeaveQueue, req, "biz_123", "main", userID)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+	leave("guest-1")
+	leave("guest-1")
+	leave("guest-2")
+
+	assert.Equal(t, updateIDs[0], updateIDs[1])
+	// Another guest reusing the key gets their own update
+	assert.NotEqual(t, updateIDs[0], updateIDs[2])
+}
+
+func TestQueueHandler_CallNext(t *testing.T) {
+	callNext := func(h *QueueHandler, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/queues/main/call-next", strings.NewReader(`{"counter_id": "Counter 3"}`))
+		req.SetPathValue("id", "main")
+		req.Header.Set(IdempotencyKeyHeader, key)
+		ctx := context.WithValue(req.Context(), auth.BusinessIDKey, "biz_123")
+		ctx = context.WithValue(ctx, auth.UserKey, "staff-1")
+		rr := httptest.NewRecorder()
+		h.CallNext(rr, req.WithContext(ctx))
+		return rr
+	}
+
+	t.Run("Returns the called ticket, once per key", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		handle := new(mocks.WorkflowUpdateHandle)
+		handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*domain.Ticket) = domain.Ticket{UserID: "guest-1", Status: domain.TicketStatusReady, AssignedTo: "Counter 3"}
+		})
+		var updateIDs []string
+		c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+			updateIDs = append(updateIDs, o.UpdateID)
+			return o.WorkflowID == "biz_123:main" && o.UpdateName == "CallNext" &&
+				o.Args[0] == workflows.CallNextSignal{CounterID: "Counter 3"}
+		})).Return(handle, nil)
+
+		rr := callNext(h, "tap-1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var ticket domain.Ticket
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&ticket))
+		assert.Equal(t, "guest-1", ticket.UserID)
+
+		// A double tap reattaches to the same update
+		callNext(h, "tap-1")
+		callNext(h, "tap-2")
+		assert.Equal(t, updateIDs[0], updateIDs[1])
+		assert.NotEqual(t, updateIDs[0], updateIDs[2])
+	})
+
+	t.Run("Queue empty", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).Return(nil, fromWorkflow(domain.ErrQueueEmpty))
+
+		rr := callNext(h, "tap-1")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"queue_empty"`)
+	})
+
+	t.Run("Seats by table capacity", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+			return o.Args[0] == workflows.CallNextSignal{CounterID: "Table 4", Capacity: 4}
+		})).Return(nil, fromWorkflow(domain.ErrNoFittingParty))
+
+		req := httptest.NewRequest(http.MethodPost, "/queues/main/call-next", strings.NewReader(`{"counter_id": "Table 4", "capacity": 4}`))
+		req.SetPathValue("id", "main")
+		ctx := context.WithValue(req.Context(), auth.BusinessIDKey, "biz_123")
+		rr := httptest.NewRecorder()
+		h.CallNext(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"no_fitting_party"`)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("Negative capacity", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		req := httptest.NewRequest(http.MethodPost, "/queues/main/call-next", strings.NewReader(`{"counter_id": "Table 4", "capacity": -1}`))
+		req.SetPathValue("id", "main")
+		ctx := context.WithValue(req.Context(), auth.BusinessIDKey, "biz_123")
+		rr := httptest.NewRecorder()
+		h.CallNext(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		c.AssertNotCalled(t, "UpdateWorkflow")
+	})
+}
+
+func TestQueueHandler_DeleteQueue(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+	c.On("SignalWorkflow", mock.Anything, "biz_123:main", "", "Shutdown",
+		workflows.ShutdownRequest{Reason: "closing early", RequestedBy: "staff-1"}).Return(nil)
+	run := new(mocks.WorkflowRun)
+	run.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*domain.QueueReport) = domain.QueueReport{BusinessID: "biz_123", QueueID: "main", Served: 4, Abandoned: 2, Cancelled: 1}
+	})
+	c.On("GetWorkflow", mock.Anything, "biz_123:main", "").Return(run)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/businesses/biz_123/queues/main?reason=closing+early", nil)
+	req.SetPathValue("business_id", "biz_123")
+	req.SetPathValue("queue_id", "main")
+	rr := httptest.NewRecorder()
+	h.DeleteQueue(rr, req.WithContext(context.WithValue(req.Context(), auth.UserKey, "staff-1")))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var report domain.QueueReport
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+	assert.Equal(t, 4, report.Served)
+	assert.Equal(t, 2, report.Abandoned)
+	c.AssertExpectations(t)
+}
+
+func TestQueueHandler_ListQueues(t *testing.T) {
+	list := func(h *QueueHandler, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/businesses/biz_123/queues?"+query, nil)
+		req.SetPathValue("business_id", "biz_123")
+		rr := httptest.NewRecorder()
+		h.ListQueues(rr, req)
+		return rr
+	}
+
+	t.Run("Pages through open queues", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		c.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(r *workflowservice.ListWorkflowExecutionsRequest) bool {
+			return strings.HasSuffix(r.Query, "BusinessID = 'biz_123' AND QueueState = 'OPEN'") &&
+				r.PageSize == 5 && string(r.NextPageToken) == "page-2"
+		})).Return(&workflowservice.ListWorkflowExecutionsResponse{NextPageToken: []byte("page-3")}, nil)
+
+		rr := list(h, "state=OPEN&page_size=5&page_token="+base64.RawURLEncoding.EncodeToString([]byte("page-2")))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body QueueList
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.NotNil(t, body.Queues)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString([]byte("page-3")), body.NextPageToken)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("Rejects bad parameters", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		for _, query := range []string{"state=DELETED", "page_size=0", "page_size=101", "page_token=%25%25"} {
+			assert.Equal(t, http.StatusBadRequest, list(h, query).Code, query)
+		}
+		c.AssertNotCalled(t, "ListWorkflow", mock.Anything, mock.Anything)
+	})
+}
+
+// fromWorkflow returns err as a client receives it when a workflow update
+// handler or validator returns it.
+func fromWorkflow(err error) error {
+	fc := temporal.GetDefaultFailureConverter()
+	return fc.FailureToError(fc.ErrorToFailure(workflows.ApplicationError(err)))
+}
+
+func TestWriteError_HidesInternalDetails(t *testing.T) {
+	rr := httptest.NewRecorder()
+	writeError(rr, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("dial tcp 10.0.0.7:7233: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "10.0.0.7")
+	assert.Contains(t, rr.Body.String(), `"code":"internal"`)
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"red-duck/internal/core/ports"
+)
+
+// IdempotencyKeyHeader lets clients retry a join, leave or call-next safely:
+// requests with the same key get the original result back.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKey returns the request's Idempotency-Key, or "" if it has none.
+func idempotencyKey(r *http.Request) (string, error) {
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if len(key) > ports.MaxIdempotencyKeyLength {
+		return "", fmt.Errorf("%s longer than %d characters", IdempotencyKeyHeader, ports.MaxIdempotencyKeyLength)
+	}
+	return key, nil
+}
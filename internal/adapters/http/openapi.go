@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+
+	"red-duck/internal/pkg/problem"
+)
+
+// Validator checks requests against the OpenAPI document before they reach
+// the handlers, so malformed input is answered with a 400 problem the same
+// way on every route.
+type Validator struct {
+	router routers.Router
+}
+
+// NewValidator loads and validates an OpenAPI 3 document.
+func NewValidator(spec []byte) (*Validator, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("load openapi spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	// Match on paths only: the servers list names the public hosts, not
+	// whatever host the request arrived on.
+	doc.Servers = nil
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("build openapi router: %w", err)
+	}
+	return &Validator{router: router}, nil
+}
+
+// Validate rejects requests that don't match their operation in the spec.
+// Requests for routes the spec doesn't describe are passed through.
+// Credentials are left to the auth middleware.
+func (v *Validator) Validate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			next(w, r)
+			return
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			problem.Write(w, http.StatusBadRequest, validationDetail(err))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// validationDetail describes a validation failure without dumping the schema.
+func validationDetail(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return "invalid request"
+	}
+	reason := reqErr.Reason
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		reason = schemaErr.Reason
+		if ptr := schemaErr.JSONPointer(); len(ptr) > 0 {
+			reason = fmt.Sprintf("%s: %s", strings.Join(ptr, "."), reason)
+		}
+	} else if reason == "" && reqErr.Err != nil {
+		reason = reqErr.Err.Error()
+	}
+	switch {
+	case reqErr.Parameter != nil:
+		return fmt.Sprintf("invalid %s parameter %q: %s", reqErr.Parameter.In, reqErr.Parameter.Name, reason)
+	case reqErr.RequestBody != nil:
+		return "invalid request body: " + reason
+	default:
+		return reason
+	}
+}
+
+// Deprecated marks a legacy route as superseded by the /v1 API.
+func Deprecated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", `</v1/openapi.yaml>; rel="successor-version"`)
+		next(w, r)
+	}
+}
+
+// ServeSpec serves the OpenAPI document.
+func ServeSpec(spec []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(spec)
+	}
+}
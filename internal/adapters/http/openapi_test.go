@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+
+	"red-duck/api"
+	apiclient "red-duck/api/client"
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/core/ports"
+	"red-duck/internal/pkg/problem"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	v, err := NewValidator(api.Spec)
+	require.NoError(t, err)
+	return v
+}
+
+func TestValidator(t *testing.T) {
+	v := newTestValidator(t)
+
+	var body string
+	next := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	send := func(method, target, payload string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		for k, vs := range header {
+			req.Header[k] = vs
+		}
+		rr := httptest.NewRecorder()
+		v.Validate(next)(rr, req)
+		return rr
+	}
+
+	t.Run("Passes valid requests with their body", func(t *testing.T) {
+		rr := send(http.MethodPost, "/v1/businesses/biz_123/queues/main/calls", `{"counter_id": "Counter 3"}`, nil)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.JSONEq(t, `{"counter_id": "Counter 3"}`, body)
+	})
+
+	t.Run("Rejects a body missing a required field", func(t *testing.T) {
+		rr := send(http.MethodPost, "/v1/businesses/biz_123/queues/main/calls", `{}`, nil)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), `"code":"invalid_request"`)
+		assert.Contains(t, rr.Body.String(), "counter_id")
+	})
+
+	t.Run("Rejects invalid parameters", func(t *testing.T) {
+		rr := send(http.MethodPost, "/v1/businesses/biz_123/queues/main/tickets", "", http.Header{
+			IdempotencyKeyHeader: {strings.Repeat("k", ports.MaxIdempotencyKeyLength+1)},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Idempotency-Key")
+
+		rr = send(http.MethodGet, "/v1/analytics/throughput?from=yesterday", "", nil)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `\"from\"`)
+	})
+
+	t.Run("Passes routes the spec doesn't describe", func(t *testing.T) {
+		rr := send(http.MethodPost, "/queues/join", `not json`, nil)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
+
+func TestDeprecated(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Deprecated(func(w http.ResponseWriter, r *http.Request) {})(rr, httptest.NewRequest(http.MethodGet, "/queue_status", nil))
+
+	assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+	assert.Contains(t, rr.Header().Get("Link"), `rel="successor-version"`)
+}
+
+func TestRequireBusiness(t *testing.T) {
+	send := func(claim string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/v1/businesses/biz_123/queues/main", nil)
+		req.SetPathValue("business_id", "biz_123")
+		req = req.WithContext(context.WithValue(req.Context(), auth.BusinessIDKey, claim))
+		rr := httptest.NewRecorder()
+		RequireBusiness(func(w http.ResponseWriter, r *http.Request) {})(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusOK, send("biz_123").Code)
+	assert.Equal(t, http.StatusForbidden, send("biz_other").Code)
+
+	// Legacy routes name the business in the query
+	req := httptest.NewRequest(http.MethodPost, "/create_queue?business_id=biz_123&queue_id=main", nil)
+	req = req.WithContext(context.WithValue(req.Context(), auth.BusinessIDKey, "biz_other"))
+	rr := httptest.NewRecorder()
+	RequireBusiness(func(w http.ResponseWriter, r *http.Request) {})(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestQueueHandler_LeaveQueue_V1(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	leave := func(userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/businesses/biz_123/queues/main/tickets/"+userID, nil)
+		req.SetPathValue("business_id", "biz_123")
+		req.SetPathValue("queue_id", "main")
+		req.SetPathValue("user_id", userID)
+		return withTicket(t, h.LeaveQueue, req, "biz_123", "main", "guest-1")
+	}
+
+	// A ticket only lets its holder leave
+	assert.Equal(t, http.StatusForbidden, leave("guest-2").Code)
+	c.AssertNotCalled(t, "UpdateWorkflow")
+
+	handle := new(mocks.WorkflowUpdateHandle)
+	handle.On("Get", mock.Anything, mock.Anything).Return(nil)
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		return o.WorkflowID == "biz_123:main" && o.Args[0] == domain.JoinRequest{UserID: "guest-1"}
+	})).Return(handle, nil)
+	assert.Equal(t, http.StatusOK, leave("guest-1").Code)
+	c.AssertExpectations(t)
+}
+
+// TestClient drives the /v1 routes through the generated client.
+func TestClient(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		return o.WorkflowID == "biz_123:main" && o.UpdateName == "JoinQueue" &&
+			o.Args[0].(domain.JoinRequest).PartySize == 2
+	})).Return(joinUpdates(1, 5), nil)
+
+	v := newTestValidator(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/tickets", v.Validate(h.CreateTicket))
+	mux.HandleFunc("POST /v1/businesses/{business_id}/queues/{queue_id}/calls", v.Validate(auth.WithAuth(RequireBusiness(h.CallNext))))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	api, err := apiclient.NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+
+	key := "join-1"
+	party := 2
+	joined, err := api.JoinQueueWithResponse(context.Background(), "biz_123", "main", &apiclient.JoinQueueParams{IdempotencyKey: &key}, apiclient.JoinQueueJSONRequestBody{PartySize: &party})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, joined.StatusCode())
+	require.NotNil(t, joined.JSON201)
+	assert.Equal(t, 1, joined.JSON201.Position)
+	require.NotNil(t, joined.JSON201.Token)
+	assert.NotEmpty(t, *joined.JSON201.Token)
+
+	// Errors decode as problems
+	called, err := api.CallNextWithResponse(context.Background(), "biz_123", "main", nil, apiclient.CallNextJSONRequestBody{CounterId: "Counter 3"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, called.StatusCode())
+	require.NotNil(t, called.ApplicationproblemJSON401)
+	assert.Equal(t, "unauthorized", called.ApplicationproblemJSON401.Code)
+}
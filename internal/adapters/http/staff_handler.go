@@ -0,0 +1,189 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/problem"
+)
+
+// Routes for staff fixing queues by hand. Every change is recorded in the
+// audit trail under the staff member's ID, and all of them require
+// RequireBusiness.
+
+// TicketPosition is where a guest's ticket is after a staff change. Token is
+// the guest's new ticket when the change moved them to another queue.
+type TicketPosition struct {
+	UserID   string `json:"user_id"`
+	QueueID  string `json:"queue_id"`
+	Position int    `json:"position"`
+	Token    string `json:"token,omitempty"`
+}
+
+// MoveTicketRequest is the body of the move route.
+type MoveTicketRequest struct {
+	Position int `json:"position"`
+}
+
+// TransferTicketRequest is the body of the transfer route. KeepJoinTime
+// defaults to QueueHandler.KeepJoinTimeOnTransfer.
+type TransferTicketRequest struct {
+	ToQueueID    string `json:"to_queue_id"`
+	Position     int    `json:"position"`
+	KeepJoinTime *bool  `json:"keep_join_time"`
+}
+
+// WalkInRequest is the optional body of the walk-in route. Position 0 is the end.
+type WalkInRequest struct {
+	Position  int `json:"position"`
+	PartySize int `json:"party_size"`
+}
+
+// MoveTicket moves a guest to another place in the queue; positions past
+// the end are clamped.
+func (h *QueueHandler) MoveTicket(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	userID := r.PathValue("user_id")
+
+	var req MoveTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Position < 1 {
+		problem.Write(w, http.StatusBadRequest, "position must be at least 1")
+		return
+	}
+	staffID, _ := auth.GetUserID(r.Context())
+
+	position, err := h.Queues.MoveTicket(r.Context(), businessID, queueID, userID, req.Position, staffID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TicketPosition{UserID: userID, QueueID: queueID, Position: position})
+}
+
+// RemoveTicket takes a guest's ticket out of the queue and answers with it.
+// The optional reason query parameter is recorded in the audit trail.
+func (h *QueueHandler) RemoveTicket(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		reason = "removed by staff"
+	}
+	staffID, _ := auth.GetUserID(r.Context())
+
+	ticket, err := h.Queues.RemoveTicket(r.Context(), businessID, queueID, r.PathValue("user_id"), staffID, reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
+// TransferTicket moves a guest to another queue of the business and issues
+// them a ticket for it; their old ticket no longer matches their queue.
+func (h *QueueHandler) TransferTicket(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+	userID := r.PathValue("user_id")
+
+	var req TransferTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.ToQueueID == "" {
+		problem.Write(w, http.StatusBadRequest, "missing to_queue_id")
+		return
+	}
+	if req.ToQueueID == queueID {
+		problem.Write(w, http.StatusBadRequest, "to_queue_id is the ticket's queue; move the ticket instead")
+		return
+	}
+	keepJoinTime := h.KeepJoinTimeOnTransfer
+	if req.KeepJoinTime != nil {
+		keepJoinTime = *req.KeepJoinTime
+	}
+	staffID, _ := auth.GetUserID(r.Context())
+
+	position, err := h.Queues.TransferTicket(r.Context(), domain.TicketTransfer{
+		BusinessID:   businessID,
+		FromQueueID:  queueID,
+		ToQueueID:    req.ToQueueID,
+		UserID:       userID,
+		Position:     req.Position,
+		KeepJoinTime: keepJoinTime,
+		StaffID:      staffID,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	token, err := auth.GenerateTicket(businessID, req.ToQueueID, userID)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, "failed to issue ticket")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TicketPosition{
+		UserID:   userID,
+		QueueID:  req.ToQueueID,
+		Position: position,
+		Token:    token,
+	})
+}
+
+// AddWalkIn inserts a guest without a phone, at the end of the queue unless
+// the body gives a position. Closed queues take walk-ins too. The ticket in
+// the answer can be printed for the guest.
+func (h *QueueHandler) AddWalkIn(w http.ResponseWriter, r *http.Request) {
+	businessID, queueID := queueParams(r)
+
+	var req WalkInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		problem.Write(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Position < 0 {
+		problem.Write(w, http.StatusBadRequest, "position must not be negative")
+		return
+	}
+	key, err := idempotencyKey(r)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	staffID, _ := auth.GetUserID(r.Context())
+
+	userID, position, err := h.Queues.AddWalkIn(r.Context(), businessID, queueID, req.Position, req.PartySize, staffID, key)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	token, err := auth.GenerateTicket(businessID, queueID, userID)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, "failed to issue ticket")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(GuestJoinResponse{
+		UserID:               userID,
+		Position:             position,
+		EstimatedWaitMinutes: domain.EstimateWait(position, req.PartySize),
+		Token:                token,
+	})
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+
+	"red-duck/auth"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+// asStaff serves req with handler as staff-1 of biz_123, on the tickets of
+// queue main.
+func asStaff(handler http.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	req.SetPathValue("business_id", "biz_123")
+	req.SetPathValue("queue_id", "main")
+	ctx := context.WithValue(req.Context(), auth.BusinessIDKey, "biz_123")
+	ctx = context.WithValue(ctx, auth.UserKey, "staff-1")
+	rr := httptest.NewRecorder()
+	handler(rr, req.WithContext(ctx))
+	return rr
+}
+
+func TestQueueHandler_MoveTicket(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	handle := new(mocks.WorkflowUpdateHandle)
+	handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*domain.Queue) = domain.Queue{Tickets: []domain.Ticket{{UserID: "guest-2"}, {UserID: "guest-1"}}}
+	})
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		return o.WorkflowID == "biz_123:main" && o.UpdateName == "MoveTicket" &&
+			o.Args[0] == workflows.MoveTicketRequest{UserID: "guest-1", Position: 2, StaffID: "staff-1"}
+	})).Return(handle, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/businesses/biz_123/queues/main/tickets/guest-1", strings.NewReader(`{"position": 2}`))
+	req.SetPathValue("user_id", "guest-1")
+	rr := asStaff(h.MoveTicket, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"user_id": "guest-1", "queue_id": "main", "position": 2}`, rr.Body.String())
+	c.AssertExpectations(t)
+}
+
+func TestQueueHandler_RemoveTicket(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	handle := new(mocks.WorkflowUpdateHandle)
+	handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*workflows.RemovedTicket) = workflows.RemovedTicket{
+			Ticket:   domain.Ticket{UserID: "guest-1", Status: domain.TicketStatusWaiting},
+			Position: 3,
+		}
+	})
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		return o.UpdateName == "RemoveTicket" &&
+			o.Args[0] == workflows.RemoveTicketRequest{UserID: "guest-1", StaffID: "staff-1", Reason: "left the building"}
+	})).Return(handle, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/businesses/biz_123/queues/main/tickets/guest-1?reason=left+the+building", nil)
+	req.SetPathValue("user_id", "guest-1")
+	rr := asStaff(h.RemoveTicket, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var ticket domain.Ticket
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&ticket))
+	assert.Equal(t, "guest-1", ticket.UserID)
+	c.AssertExpectations(t)
+}
+
+func TestQueueHandler_TransferTicket(t *testing.T) {
+	transfer := func(h *QueueHandler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/tickets/guest-1/transfer", strings.NewReader(body))
+		req.SetPathValue("user_id", "guest-1")
+		return asStaff(h.TransferTicket, req)
+	}
+
+	t.Run("Keeps the join time by default and issues a ticket for the new queue", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+		h.KeepJoinTimeOnTransfer = true
+
+		run := new(mocks.WorkflowRun)
+		run.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*int) = 1
+		})
+		c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+			return o.ID == "transfer:biz_123:main:guest-1" &&
+				o.WorkflowIDConflictPolicy == enums.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING
+		}), "TransferTicketWorkflow", domain.TicketTransfer{
+			BusinessID:   "biz_123",
+			FromQueueID:  "main",
+			ToQueueID:    "pharmacy",
+			UserID:       "guest-1",
+			Position:     1,
+			KeepJoinTime: true,
+			StaffID:      "staff-1",
+		}).Return(run, nil)
+
+		rr := transfer(h, `{"to_queue_id": "pharmacy", "position": 1}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body TicketPosition
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, "pharmacy", body.QueueID)
+		assert.Equal(t, 1, body.Position)
+		ticket, err := auth.ParseTicket(body.Token)
+		assert.NoError(t, err)
+		assert.NoError(t, ticket.Matches("biz_123", "pharmacy"))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("Reports why the target queue refused the ticket", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		run := new(mocks.WorkflowRun)
+		run.On("Get", mock.Anything, mock.Anything).Return(fromWorkflow(domain.ErrUserAlreadyInQueue))
+		c.On("ExecuteWorkflow", mock.Anything, mock.Anything, "TransferTicketWorkflow", mock.MatchedBy(func(transfer domain.TicketTransfer) bool {
+			return !transfer.KeepJoinTime
+		})).Return(run, nil)
+
+		rr := transfer(h, `{"to_queue_id": "pharmacy"}`)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"user_already_in_queue"`)
+	})
+
+	t.Run("Rejects a transfer to the same queue", func(t *testing.T) {
+		c := new(mocks.Client)
+		h := newQueueHandler(c)
+
+		for _, body := range []string{`{"to_queue_id": "main"}`, `{}`} {
+			assert.Equal(t, http.StatusBadRequest, transfer(h, body).Code, body)
+		}
+		c.AssertNotCalled(t, "ExecuteWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestQueueHandler_AddWalkIn(t *testing.T) {
+	c := new(mocks.Client)
+	h := newQueueHandler(c)
+
+	handle := new(mocks.WorkflowUpdateHandle)
+	handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*int) = 1
+	})
+	var inserted []workflows.InsertTicketRequest
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		req := o.Args[0].(workflows.InsertTicketRequest)
+		inserted = append(inserted, req)
+		return o.UpdateName == "InsertTicket" && o.UpdateID == "walk-in-"+req.Ticket.UserID &&
+			req.Position == 1 && req.StaffID == "staff-1" && req.Joined
+	})).Return(handle, nil)
+
+	walkIn := func(key string) GuestJoinResponse {
+		req := httptest.NewRequest(http.MethodPost, "/v1/businesses/biz_123/queues/main/walk-ins", strings.NewReader(`{"position": 1}`))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		rr := asStaff(h.AddWalkIn, req)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var body GuestJoinResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		return body
+	}
+
+	first := walkIn("desk-1")
+	assert.Equal(t, 1, first.Position)
+	assert.NotEmpty(t, first.Token)
+	// A retry with the same key is the same walk-in
+	assert.Equal(t, first.UserID, walkIn("desk-1").UserID)
+	assert.Equal(t, inserted[0], inserted[1])
+}
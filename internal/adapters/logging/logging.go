@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/activity"
+	tlog "go.temporal.io/sdk/log"
+
+	"red-duck/internal/adapters/config"
+	"red-duck/internal/pkg/requestid"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// New builds the process logger: JSON lines for production, text for
+// development. Records logged with a context are tagged with its request ID,
+// trace ID and, inside an activity, the workflow and activity it belongs to.
+func New(cfg config.LogConfig) *slog.Logger {
+	return NewWithWriter(cfg, os.Stderr)
+}
+
+// NewWithWriter is New writing to w.
+func NewWithWriter(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+
+	var h slog.Handler
+	if strings.EqualFold(cfg.Format, FormatJSON) {
+		h = slog.NewJSONHandler(w, opts)
+	} else {
+		h = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(contextHandler{h})
+}
+
+// Setup makes the logger built from cfg the slog default, which also routes
+// the standard library log package through it.
+func Setup(cfg config.LogConfig) *slog.Logger {
+	logger := New(cfg)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// TemporalLogger adapts logger for client.Options.Logger.
+func TemporalLogger(logger *slog.Logger) tlog.Logger {
+	return tlog.NewStructuredLogger(logger)
+}
+
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// contextHandler adds correlation IDs found in the record's context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	if activity.IsActivity(ctx) {
+		info := activity.GetInfo(ctx)
+		r.AddAttrs(
+			slog.String("workflow_id", info.WorkflowExecution.ID),
+			slog.String("run_id", info.WorkflowExecution.RunID),
+			slog.String("activity_type", info.ActivityType.Name),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"red-duck/internal/adapters/config"
+	"red-duck/internal/pkg/requestid"
+)
+
+func TestNew_JSONIncludesRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(config.LogConfig{Format: FormatJSON}, &buf)
+
+	ctx := requestid.With(context.Background(), "req-1")
+	logger.InfoContext(ctx, "joined", "queue_id", "q1")
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "joined", line["msg"])
+	assert.Equal(t, "req-1", line["request_id"])
+	assert.Equal(t, "q1", line["queue_id"])
+}
+
+func TestNew_TextAndLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(config.LogConfig{Format: FormatText, Level: "warn"}, &buf)
+
+	logger.Info("hidden")
+	logger.With("component", "ingest").Warn("shown")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "level=WARN msg=shown component=ingest")
+}
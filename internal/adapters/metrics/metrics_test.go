@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_CountsByRoutePattern(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /exports/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := Middleware(mux)
+
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GET /exports/{id}", "GET", "404"))
+	for _, id := range []string{"a", "b"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/exports/"+id, nil))
+	}
+	after := testutil.ToFloat64(httpRequests.WithLabelValues("GET /exports/{id}", "GET", "404"))
+	assert.Equal(t, 2.0, after-before, "requests for different IDs share one route label")
+
+	beforeUnmatched := testutil.ToFloat64(httpRequests.WithLabelValues("unmatched", "GET", "404"))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/nope", nil))
+	afterUnmatched := testutil.ToFloat64(httpRequests.WithLabelValues("unmatched", "GET", "404"))
+	assert.Equal(t, 1.0, afterUnmatched-beforeUnmatched)
+}
+
+func TestTemporalHandler_RecordsIntoRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	root := NewTemporalHandler(reg)
+	h := root.WithTags(map[string]string{"namespace": "default", "task-queue": "tq"})
+
+	h.Counter("temporal_request").Inc(3)
+	h.Gauge("redduck_queue_length").Update(4)
+	h.Timer("temporal_request_latency").Record(250 * time.Millisecond)
+	// A later use with fewer tags lands in the same metric with empty labels.
+	root.Counter("temporal_request").Inc(1)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	byName := map[string]int{}
+	for _, f := range families {
+		byName[f.GetName()] = len(f.GetMetric())
+	}
+	assert.Equal(t, 2, byName["temporal_request"])
+	assert.Equal(t, 1, byName["redduck_queue_length"])
+	assert.Equal(t, 1, byName["temporal_request_latency"])
+}
+
+func TestTemporalHandler_LabelsDontDependOnFirstUse(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	root := NewTemporalHandler(reg)
+
+	// The first use carries no tags; later ones still keep theirs
+	root.Counter("temporal_workflow_completed").Inc(1)
+	root.WithTags(map[string]string{"namespace": "default", "workflow_type": "BusinessQueueWorkflow", "unknown": "x"}).
+		Counter("temporal_workflow_completed").Inc(2)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	var tagged map[string]string
+	for _, m := range families[0].GetMetric() {
+		if m.GetCounter().GetValue() == 2 {
+			tagged = map[string]string{}
+			for _, l := range m.GetLabel() {
+				if l.GetValue() != "" {
+					tagged[l.GetName()] = l.GetValue()
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]string{"namespace": "default", "workflow_type": "BusinessQueueWorkflow"}, tagged)
+}
+
+func TestTemporalHandler_TypeMismatchIsIgnored(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	h := NewTemporalHandler(reg)
+
+	h.Counter("temporal_thing").Inc(1)
+	assert.NotPanics(t, func() { h.Gauge("temporal_thing").Update(2) })
+}
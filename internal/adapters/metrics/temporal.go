@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.temporal.io/sdk/client"
+)
+
+// NewTemporalHandler returns a Temporal SDK metrics handler that records into
+// Prometheus. Pass it as client.Options.MetricsHandler so the client, workers
+// and workflow.GetMetricsHandler all report through it.
+//
+// Prometheus needs a fixed label set per metric, so every metric is labelled
+// with all of temporalTagKeys, whichever tags its first use carried. Tags a use
+// lacks are left empty, and tags outside the set are dropped. A name reused
+// with a different metric type is ignored.
+func NewTemporalHandler(reg prometheus.Registerer) client.MetricsHandler {
+	return &temporalHandler{
+		vecs: &temporalVecs{registerer: reg, byName: make(map[string]*temporalVec)},
+		tags: map[string]string{},
+	}
+}
+
+type temporalHandler struct {
+	vecs *temporalVecs
+	tags map[string]string
+}
+
+type temporalVecs struct {
+	registerer prometheus.Registerer
+	mu         sync.Mutex
+	byName     map[string]*temporalVec
+}
+
+type temporalVec struct {
+	labels    []string
+	counter   *prometheus.CounterVec
+	gauge     *prometheus.GaugeVec
+	histogram *prometheus.HistogramVec
+}
+
+// temporalTagKeys are the tag keys the Temporal SDK documents for its metrics
+// (go.temporal.io/sdk/internal/common/metrics), plus those of the queue
+// metrics in workflows.RecordQueueMetrics. Add keys here when tagging new
+// metrics.
+var temporalTagKeys = []string{
+	"activity_type",
+	"business_id",
+	"cause",
+	"client_name",
+	"failure_reason",
+	"namespace",
+	"nexus_operation",
+	"nexus_service",
+	"operation",
+	"poller_type",
+	"queue_id",
+	"status_code",
+	"task_queue",
+	"worker_type",
+	"workflow_type",
+}
+
+var invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+func sanitizeMetricName(name string) string {
+	return invalidMetricChars.ReplaceAllString(name, "_")
+}
+
+func (h *temporalHandler) WithTags(tags map[string]string) client.MetricsHandler {
+	merged := make(map[string]string, len(h.tags)+len(tags))
+	for k, v := range h.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[sanitizeMetricName(k)] = v
+	}
+	return &temporalHandler{vecs: h.vecs, tags: merged}
+}
+
+func (h *temporalHandler) Counter(name string) client.MetricsCounter {
+	v := h.vecs.get(name, func(opts prometheus.Opts, labels []string) prometheus.Collector {
+		return prometheus.NewCounterVec(prometheus.CounterOpts(opts), labels)
+	})
+	if v.counter == nil {
+		return client.MetricsNopHandler.Counter(name)
+	}
+	c := v.counter.WithLabelValues(v.values(h.tags)...)
+	return counterFunc(func(d int64) { c.Add(float64(d)) })
+}
+
+func (h *temporalHandler) Gauge(name string) client.MetricsGauge {
+	v := h.vecs.get(name, func(opts prometheus.Opts, labels []string) prometheus.Collector {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts(opts), labels)
+	})
+	if v.gauge == nil {
+		return client.MetricsNopHandler.Gauge(name)
+	}
+	g := v.gauge.WithLabelValues(v.values(h.tags)...)
+	return gaugeFunc(g.Set)
+}
+
+func (h *temporalHandler) Timer(name string) client.MetricsTimer {
+	v := h.vecs.get(name, func(opts prometheus.Opts, labels []string) prometheus.Collector {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    opts.Name,
+			Help:    opts.Help,
+			Buckets: prometheus.DefBuckets,
+		}, labels)
+	})
+	if v.histogram == nil {
+		return client.MetricsNopHandler.Timer(name)
+	}
+	o := v.histogram.WithLabelValues(v.values(h.tags)...)
+	return timerFunc(func(d time.Duration) { o.Observe(d.Seconds()) })
+}
+
+func (vs *temporalVecs) get(name string, newVec func(prometheus.Opts, []string) prometheus.Collector) *temporalVec {
+	name = sanitizeMetricName(name)
+
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if v, ok := vs.byName[name]; ok {
+		return v
+	}
+
+	labels := temporalTagKeys
+	collector := newVec(prometheus.Opts{Name: name, Help: "Temporal metric " + name + "."}, labels)
+	if err := vs.registerer.Register(collector); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			collector = are.ExistingCollector
+		}
+	}
+
+	v := &temporalVec{labels: labels}
+	switch c := collector.(type) {
+	case *prometheus.CounterVec:
+		v.counter = c
+	case *prometheus.GaugeVec:
+		v.gauge = c
+	case *prometheus.HistogramVec:
+		v.histogram = c
+	}
+	vs.byName[name] = v
+	return v
+}
+
+func (v *temporalVec) values(tags map[string]string) []string {
+	values := make([]string, len(v.labels))
+	for i, l := range v.labels {
+		values[i] = tags[l]
+	}
+	return values
+}
+
+type counterFunc func(int64)
+
+func (f counterFunc) Inc(d int64) { f(d) }
+
+type gaugeFunc func(float64)
+
+func (f gaugeFunc) Update(d float64) { f(d) }
+
+type timerFunc func(time.Duration)
+
+func (f timerFunc) Record(d time.Duration) { f(d) }
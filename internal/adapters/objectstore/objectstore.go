@@ -0,0 +1,140 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"red-duck/internal/adapters/config"
+)
+
+// Store is the minimal object storage contract used for archives and exports.
+type Store interface {
+	// Put streams r into the object at key, overwriting any existing object.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// URL returns a location the object at key can be fetched from, valid for at least ttl.
+	URL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// New builds the store selected by the configuration.
+func New(ctx context.Context, cfg config.StorageConfig) (Store, error) {
+	if cfg.LocalDir != "" {
+		return NewLocalStore(cfg.LocalDir), nil
+	}
+	s, err := NewMinioStore(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.Bucket, cfg.UseSSL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.EnsureBucket(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// LocalStore keeps objects as files below Root. It stands in for MinIO in tests and local dev.
+type LocalStore struct {
+	Root string
+}
+
+// ensure LocalStore implements Store
+var _ Store = (*LocalStore)(nil)
+
+func NewLocalStore(root string) *LocalStore {
+	return &LocalStore{Root: root}
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	p := filepath.Join(s.Root, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, filepath.Clean(s.Root)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return p, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temp file first so a failed upload never leaves a truncated object behind.
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *LocalStore) URL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	return "file://" + filepath.ToSlash(p), nil
+}
+
+// MinioStore keeps objects in a single S3-compatible bucket.
+type MinioStore struct {
+	client *minio.Client
+	bucket string
+}
+
+// ensure MinioStore implements Store
+var _ Store = (*MinioStore)(nil)
+
+func NewMinioStore(endpoint, accessKey, secretKey, bucket string, useSSL bool) (*MinioStore, error) {
+	c, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	}
+	return &MinioStore{client: c, bucket: bucket}, nil
+}
+
+// EnsureBucket creates the bucket if it does not exist yet.
+func (s *MinioStore) EnsureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket %s: %w", s.bucket, err)
+	}
+	if exists {
+		return nil
+	}
+	return s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{})
+}
+
+func (s *MinioStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	// Size -1 makes the client stream the object as a multipart upload.
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, -1, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// URL returns a presigned GET link, so the bucket itself can stay private.
+func (s *MinioStore) URL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, ttl, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
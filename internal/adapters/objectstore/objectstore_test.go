@@ -0,0 +1,35 @@
+package objectstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore_Put(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(root)
+
+	err := store.Put(context.Background(), "exports/biz1/job.csv", strings.NewReader("a,b\n"), "text/csv")
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(root, "exports", "biz1", "job.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b\n", string(data))
+
+	url, err := store.URL(context.Background(), "exports/biz1/job.csv", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, "file://"))
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	err := store.Put(context.Background(), "../outside.txt", strings.NewReader("x"), "text/plain")
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package secondary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/core/ports"
+	"red-duck/internal/workflows"
+)
+
+// TemporalAppointmentClient runs each appointment as an AppointmentWorkflow.
+// The workflow ID names the seat, so Temporal keeps two guests off one seat.
+type TemporalAppointmentClient struct {
+	client    client.Client
+	taskQueue string
+	policy    domain.AppointmentPolicy
+
+	// Now is the clock slots are checked against; time.Now if nil.
+	Now func() time.Time
+}
+
+// Ensure TemporalAppointmentClient implements AppointmentService
+var _ ports.AppointmentService = (*TemporalAppointmentClient)(nil)
+
+func NewTemporalAppointmentClient(c client.Client, taskQueue string, policy domain.AppointmentPolicy) *TemporalAppointmentClient {
+	return &TemporalAppointmentClient{client: c, taskQueue: taskQueue, policy: policy}
+}
+
+func (c *TemporalAppointmentClient) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// Book takes the first free seat of the slot. The guest ID is minted like
+// JoinQueue's, so a retried booking finds the seat it already holds; only
+// the booking that started the seat's workflow took it, the others are
+// replays.
+func (c *TemporalAppointmentClient) Book(ctx context.Context, businessID, queueID string, slotStart time.Time, idempotencyKey string) (*domain.Appointment, bool, error) {
+	queueWfID := workflows.QueueWorkflowID(businessID, queueID)
+	userID := guestID("appointment:"+queueWfID, idempotencyKey)
+	now := c.now()
+	appt, err := c.policy.Book(businessID, queueID, userID, slotStart, now)
+	if err != nil {
+		return nil, false, err
+	}
+	// Only running queues take appointments
+	if _, err := workflows.GetStatusQuery.Execute(ctx, c.client, queueWfID); err != nil {
+		return nil, false, queueError(fmt.Errorf("status query failed: %w", err))
+	}
+
+	params := workflows.AppointmentParams{RemindAt: c.policy.ReminderTimes(appt, now)}
+	for seat := 1; seat <= c.policy.Capacity; seat++ {
+		appt.Seat = seat
+		appt.ID = domain.AppointmentID(appt.SlotStart, seat)
+		params.Appointment = appt
+		options := client.StartWorkflowOptions{
+			ID:                                       workflows.AppointmentWorkflowID(businessID, queueID, appt.ID),
+			TaskQueue:                                c.taskQueue,
+			WorkflowExecutionErrorWhenAlreadyStarted: true,
+			// A seat given back can be booked again
+			WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		}
+
+		_, err := c.client.ExecuteWorkflow(ctx, options, "AppointmentWorkflow", params)
+		if err == nil {
+			return &appt, false, nil
+		}
+		var started *serviceerror.WorkflowExecutionAlreadyStarted
+		if !errors.As(err, &started) {
+			return nil, false, fmt.Errorf("failed to start appointment: %w", err)
+		}
+		if held, err := workflows.GetAppointmentQuery.Execute(ctx, c.client, options.ID); err == nil && held.UserID == userID {
+			return &held, true, nil
+		}
+	}
+	return nil, false, domain.ErrSlotUnavailable
+}
+
+func (c *TemporalAppointmentClient) GetAppointment(ctx context.Context, businessID, queueID, appointmentID string) (*domain.Appointment, error) {
+	wfID := workflows.AppointmentWorkflowID(businessID, queueID, appointmentID)
+	appt, err := workflows.GetAppointmentQuery.Execute(ctx, c.client, wfID)
+	if err != nil {
+		return nil, appointmentError(fmt.Errorf("appointment query failed: %w", err))
+	}
+	return &appt, nil
+}
+
+func (c *TemporalAppointmentClient) CheckIn(ctx context.Context, businessID, queueID, appointmentID, staffID, idempotencyKey string) (*domain.Appointment, error) {
+	wfID := workflows.AppointmentWorkflowID(businessID, queueID, appointmentID)
+	req := workflows.CheckInRequest{StaffID: staffID}
+	appt, err := workflows.CheckInUpdate.ExecuteWithID(ctx, c.client, wfID, updateID("check-in", appointmentID, idempotencyKey), req)
+	if err != nil {
+		return nil, c.endedError(ctx, businessID, queueID, appointmentID, fmt.Errorf("check-in failed: %w", err))
+	}
+	return &appt, nil
+}
+
+func (c *TemporalAppointmentClient) CancelAppointment(ctx context.Context, businessID, queueID, appointmentID, staffID string) (*domain.Appointment, error) {
+	wfID := workflows.AppointmentWorkflowID(businessID, queueID, appointmentID)
+	req := workflows.CancelAppointmentRequest{StaffID: staffID}
+	appt, err := workflows.CancelAppointmentUpdate.Execute(ctx, c.client, wfID, req)
+	if err != nil {
+		return nil, c.endedError(ctx, businessID, queueID, appointmentID, fmt.Errorf("cancel failed: %w", err))
+	}
+	return &appt, nil
+}
+
+// endedError is appointmentError for updates, which fail as if the workflow
+// didn't exist once the appointment ended. Those that ended are closed.
+func (c *TemporalAppointmentClient) endedError(ctx context.Context, businessID, queueID, appointmentID string, err error) error {
+	err = appointmentError(err)
+	if !errors.Is(err, domain.ErrAppointmentNotFound) {
+		return err
+	}
+	if _, queryErr := c.GetAppointment(ctx, businessID, queueID, appointmentID); queryErr == nil {
+		return domain.ErrAppointmentClosed
+	}
+	return err
+}
+
+// appointmentError is queueError for appointment workflows.
+func appointmentError(err error) error {
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return domain.ErrAppointmentNotFound
+	}
+	return workflows.DomainError(err)
+}
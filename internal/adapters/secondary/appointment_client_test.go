@@ -0,0 +1,109 @@
+package secondary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+func TestBook_TakesFirstFreeSeat(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	slot := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	policy := domain.AppointmentPolicy{
+		SlotLength:  15 * time.Minute,
+		Capacity:    2,
+		NoShowAfter: 10 * time.Minute,
+		Reminders:   []time.Duration{time.Hour, 48 * time.Hour},
+	}
+	c := new(mocks.Client)
+	appointments := NewTemporalAppointmentClient(c, "test-queue", policy)
+	appointments.Now = func() time.Time { return now }
+
+	queue := new(mocks.Value)
+	queue.On("Get", mock.Anything).Return(nil)
+	c.On("QueryWorkflow", mock.Anything, "biz_123:main", "", "GetStatus").Return(queue, nil)
+
+	// Seat 1 is someone else's
+	seat1 := "appointment:biz_123:main:20260302T1000Z-1"
+	c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+		return o.ID == seat1 && o.WorkflowExecutionErrorWhenAlreadyStarted
+	}), "AppointmentWorkflow", mock.Anything).
+		Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("started", "", "run-1"))
+	other := new(mocks.Value)
+	other.On("Get", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(0).(*domain.Appointment) = domain.Appointment{ID: "20260302T1000Z-1", UserID: "someone-else"}
+	})
+	c.On("QueryWorkflow", mock.Anything, seat1, "", "GetAppointment").Return(other, nil)
+
+	// Seat 2 is free, and only the reminder still ahead is set
+	seat2 := "appointment:biz_123:main:20260302T1000Z-2"
+	var started workflows.AppointmentParams
+	c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+		return o.ID == seat2
+	}), "AppointmentWorkflow", mock.Anything).
+		Return(new(mocks.WorkflowRun), nil).Once().
+		Run(func(args mock.Arguments) { started = args.Get(3).(workflows.AppointmentParams) })
+
+	appt, replayed, err := appointments.Book(context.Background(), "biz_123", "main", slot, "key-1")
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.Equal(t, "20260302T1000Z-2", appt.ID)
+	assert.Equal(t, 2, appt.Seat)
+	assert.Equal(t, domain.AppointmentBooked, appt.Status)
+	assert.Equal(t, []time.Time{slot.Add(-time.Hour)}, started.RemindAt)
+
+	c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+		return o.ID == seat2
+	}), "AppointmentWorkflow", mock.Anything).
+		Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("started", "", "run-2"))
+	held := new(mocks.Value)
+	held.On("Get", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(0).(*domain.Appointment) = *appt
+	})
+	c.On("QueryWorkflow", mock.Anything, seat2, "", "GetAppointment").Return(held, nil)
+
+	// Retried, the booking finds the seat it holds, as a replay
+	retried, replayed, err := appointments.Book(context.Background(), "biz_123", "main", slot, "key-1")
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, appt.UserID, retried.UserID)
+	assert.Equal(t, appt.ID, retried.ID)
+
+	// Anyone else finds the slot full
+	_, _, err = appointments.Book(context.Background(), "biz_123", "main", slot, "key-2")
+	assert.ErrorIs(t, err, domain.ErrSlotUnavailable)
+
+	// and slots off the grid invalid
+	_, _, err = appointments.Book(context.Background(), "biz_123", "main", slot.Add(time.Minute), "key-3")
+	assert.ErrorIs(t, err, domain.ErrInvalidSlot)
+}
+
+func TestCheckIn_EndedAppointmentIsClosed(t *testing.T) {
+	c := new(mocks.Client)
+	wfID := "appointment:biz_123:main:20260302T1000Z-1"
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		return o.WorkflowID == wfID && o.UpdateName == workflows.UpdateCheckIn
+	})).Return(nil, serviceerror.NewNotFound("workflow execution already completed"))
+	ended := new(mocks.Value)
+	ended.On("Get", mock.Anything).Return(nil)
+	c.On("QueryWorkflow", mock.Anything, wfID, "", "GetAppointment").Return(ended, nil)
+	c.On("QueryWorkflow", mock.Anything, "appointment:biz_123:main:missing", "", "GetAppointment").
+		Return(nil, serviceerror.NewNotFound("workflow not found"))
+
+	appointments := NewTemporalAppointmentClient(c, "test-queue", domain.AppointmentPolicy{})
+	_, err := appointments.CheckIn(context.Background(), "biz_123", "main", "20260302T1000Z-1", "", "key-1")
+	assert.ErrorIs(t, err, domain.ErrAppointmentClosed)
+
+	_, err = appointments.GetAppointment(context.Background(), "biz_123", "main", "missing")
+	assert.ErrorIs(t, err, domain.ErrAppointmentNotFound)
+}
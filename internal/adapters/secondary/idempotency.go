@@ -0,0 +1,33 @@
+package secondary
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+)
+
+// guestIDNamespace derives guest IDs from idempotency keys, so a retried join
+// gets back the same guest.
+var guestIDNamespace = uuid.MustParse("6f1c7a52-3b8e-4d61-9a0f-2c4e5b7d8e90")
+
+// updateID maps an idempotency key to the Temporal update ID of op, which
+// Temporal deduplicates. scope is whoever sends the update, so one caller
+// can't reattach to another's update by reusing their key. Without a key
+// every call gets a fresh update.
+func updateID(op, scope, key string) string {
+	if key == "" {
+		return op + "-" + uuid.New().String()
+	}
+	sum := sha256.Sum256([]byte(scope + "\x00" + key))
+	return op + "-" + hex.EncodeToString(sum[:16])
+}
+
+// guestID mints the ID of a guest joining workflowID, deterministically when
+// the join has an idempotency key.
+func guestID(workflowID, key string) string {
+	if key == "" {
+		return uuid.New().String()
+	}
+	return uuid.NewSHA1(guestIDNamespace, []byte(workflowID+"\x00"+key)).String()
+}
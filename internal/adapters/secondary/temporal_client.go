@@ -2,66 +2,296 @@ package secondary
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/google/uuid"
 
 	"red-duck/internal/core/domain"
 	"red-duck/internal/core/ports"
+	"red-duck/internal/pkg/actor"
 	"red-duck/internal/workflows"
-	"go.temporal.io/sdk/client"
 )
 
+// defaultWatchInterval is how often WatchQueue polls a queue for changes.
+const defaultWatchInterval = time.Second
+
+// TemporalQueueClient runs each queue as a BusinessQueueWorkflow.
 type TemporalQueueClient struct {
-	client client.Client
+	client    client.Client
+	taskQueue string
+
+	// WatchInterval is how often WatchQueue polls; defaultWatchInterval if zero.
+	WatchInterval time.Duration
+	// Confirm is the confirm policy new queues start with; the zero policy
+	// asks nobody.
+	Confirm domain.ConfirmPolicy
+	// Geofences are where guests of each business count as arrived, by
+	// business ID.
+	Geofences map[string]domain.Geofence
+	// MaxSkips is how often new queues let a waiting party be passed over by
+	// calls for smaller tables; domain.DefaultMaxSkips if zero.
+	MaxSkips int
+	// Capacity is how many tickets may wait in new queues at once; zero is
+	// no limit.
+	Capacity int
 }
 
 // Ensure TemporalQueueClient implements QueueService
 var _ ports.QueueService = (*TemporalQueueClient)(nil)
 
-func NewTemporalQueueClient(c client.Client) *TemporalQueueClient {
-	return &TemporalQueueClient{client: c}
+func NewTemporalQueueClient(c client.Client, taskQueue string) *TemporalQueueClient {
+	return &TemporalQueueClient{client: c, taskQueue: taskQueue}
 }
 
 func (c *TemporalQueueClient) getWorkflowID(businessID, queueID string) string {
-	return fmt.Sprintf("%s:%s", businessID, queueID)
+	return workflows.QueueWorkflowID(businessID, queueID)
 }
 
-func (c *TemporalQueueClient) CreateQueue(ctx context.Context, businessID, queueID string) error {
+func (c *TemporalQueueClient) CreateQueue(ctx context.Context, businessID, queueID string) (string, error) {
 	options := client.StartWorkflowOptions{
 		ID:        c.getWorkflowID(businessID, queueID),
-		TaskQueue: workflows.TaskQueue,
+		TaskQueue: c.taskQueue,
+	}
+
+	opts := workflows.QueueOptions{Confirm: c.Confirm, MaxSkips: c.MaxSkips, Capacity: c.Capacity}
+	if fence, ok := c.Geofences[businessID]; ok {
+		opts.Confirm.Geofence = &fence
+	}
+
+	// We use the string name "BusinessQueueWorkflow" to avoid importing the adapter package
+	run, err := c.client.ExecuteWorkflow(ctx, options, "BusinessQueueWorkflow", businessID, queueID, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to start workflow: %w", err)
 	}
+	return run.GetRunID(), nil
+}
 
-	input := workflows.QueueWorkflowInput{
-		BusinessID: businessID,
-		QueueID:    queueID,
+// DeleteQueue signals the shutdown and waits for the workflow to close out.
+func (c *TemporalQueueClient) DeleteQueue(ctx context.Context, businessID, queueID, reason, requestedBy string) (*domain.QueueReport, error) {
+	wfID := c.getWorkflowID(businessID, queueID)
+	req := workflows.ShutdownRequest{Reason: reason, RequestedBy: requestedBy, Role: actor.FromContext(ctx).Role}
+	if err := workflows.ShutdownSignal.Send(ctx, c.client, wfID, req); err != nil {
+		return nil, queueError(fmt.Errorf("shutdown failed: %w", err))
 	}
 
-	// We execute the workflow. It's a long running workflow, so we don't wait for result.
-	// ExecuteWorkflow returns a Run, which we can ignore if we just want to start it.
-	// If it already exists, this might fail depending on ID reuse policy, but that's expected.
-	_, err := c.client.ExecuteWorkflow(ctx, options, workflows.QueueWorkflow, input)
-	return err
+	var report domain.QueueReport
+	if err := c.client.GetWorkflow(ctx, wfID, "").Get(ctx, &report); err != nil {
+		return nil, queueError(fmt.Errorf("close-out failed: %w", err))
+	}
+	return &report, nil
 }
 
-func (c *TemporalQueueClient) JoinQueue(ctx context.Context, businessID, queueID, userID string) error {
+// JoinQueue mints the guest ID. It is derived from the idempotency key, so a
+// retried join reattaches to the original update through its ID. Only the
+// join whose nonce comes back added the guest; the others are replays.
+func (c *TemporalQueueClient) JoinQueue(ctx context.Context, businessID, queueID string, partySize int, idempotencyKey string) (domain.Joined, error) {
 	wfID := c.getWorkflowID(businessID, queueID)
-	return c.client.SignalWorkflow(ctx, wfID, "", workflows.SignalJoinQueue, workflows.JoinQueueSignal{UserID: userID})
+	userID := guestID(wfID, idempotencyKey)
+	req := domain.JoinRequest{UserID: userID, PartySize: partySize, Nonce: uuid.New().String()}
+
+	res, err := workflows.JoinQueueUpdate.ExecuteWithID(ctx, c.client, wfID, "join-"+userID, req)
+	if err != nil {
+		return domain.Joined{}, queueError(err)
+	}
+	return domain.Joined{UserID: userID, Position: res.Position, WaitMinutes: res.WaitMinutes, Replayed: res.Nonce != req.Nonce}, nil
 }
 
-func (c *TemporalQueueClient) LeaveQueue(ctx context.Context, businessID, queueID, userID string) error {
+func (c *TemporalQueueClient) LeaveQueue(ctx context.Context, businessID, queueID, userID, idempotencyKey string) (int, error) {
 	wfID := c.getWorkflowID(businessID, queueID)
-	return c.client.SignalWorkflow(ctx, wfID, "", workflows.SignalLeaveQueue, workflows.LeaveQueueSignal{UserID: userID})
+	remaining, err := workflows.LeaveQueueUpdate.ExecuteWithID(ctx, c.client, wfID, updateID("leave", userID, idempotencyKey), domain.JoinRequest{UserID: userID})
+	if err != nil {
+		return 0, queueError(fmt.Errorf("leave failed: %w", err))
+	}
+	return remaining, nil
 }
 
 func (c *TemporalQueueClient) GetQueueStatus(ctx context.Context, businessID, queueID string) (*domain.Queue, error) {
 	wfID := c.getWorkflowID(businessID, queueID)
-	resp, err := c.client.QueryWorkflow(ctx, wfID, "", workflows.QueryGetState)
+	state, err := workflows.GetStatusQuery.Execute(ctx, c.client, wfID)
 	if err != nil {
-		return nil, err
-	}
-	var state domain.Queue
-	if err := resp.Get(&state); err != nil {
-		return nil, err
+		return nil, queueError(fmt.Errorf("status query failed: %w", err))
 	}
 	return &state, nil
 }
+
+// ListQueues queries visibility by the search attributes the queue workflows
+// upsert. Queues started before they had search attributes are not listed.
+func (c *TemporalQueueClient) ListQueues(ctx context.Context, businessID string, state domain.QueueState, pageSize int, pageToken []byte) ([]domain.QueueSummary, []byte, error) {
+	query := fmt.Sprintf("WorkflowType = 'BusinessQueueWorkflow' AND ExecutionStatus = 'Running' AND %s = %s",
+		workflows.BusinessIDAttribute.GetName(), quoteQuery(businessID))
+	if state != "" {
+		query += fmt.Sprintf(" AND %s = %s", workflows.QueueStateAttribute.GetName(), quoteQuery(string(state)))
+	}
+
+	resp, err := c.client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Query:         query,
+		PageSize:      int32(pageSize),
+		NextPageToken: pageToken,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list queues failed: %w", err)
+	}
+
+	queues := make([]domain.QueueSummary, 0, len(resp.GetExecutions()))
+	for _, e := range resp.GetExecutions() {
+		fields := e.GetSearchAttributes().GetIndexedFields()
+		var length int64
+		q := domain.QueueSummary{BusinessID: businessID}
+		decodeSearchAttribute(fields, workflows.QueueIDAttribute.GetName(), &q.ID)
+		decodeSearchAttribute(fields, workflows.QueueStateAttribute.GetName(), &q.State)
+		decodeSearchAttribute(fields, workflows.QueueLengthAttribute.GetName(), &length)
+		q.Length = int(length)
+		queues = append(queues, q)
+	}
+	return queues, resp.GetNextPageToken(), nil
+}
+
+func (c *TemporalQueueClient) CallNext(ctx context.Context, businessID, queueID, counterID string, capacity int, staffID, idempotencyKey string) (*domain.Ticket, error) {
+	wfID := c.getWorkflowID(businessID, queueID)
+	signal := workflows.CallNextSignal{CounterID: counterID, Capacity: capacity}
+
+	ticket, err := workflows.CallNextUpdate.ExecuteWithID(ctx, c.client, wfID, updateID("call-next", staffID, idempotencyKey), signal)
+	if err != nil {
+		return nil, queueError(err)
+	}
+	return &ticket, nil
+}
+
+func (c *TemporalQueueClient) MoveTicket(ctx context.Context, businessID, queueID, userID string, position int, staffID string) (int, error) {
+	wfID := c.getWorkflowID(businessID, queueID)
+	req := workflows.MoveTicketRequest{UserID: userID, Position: position, StaffID: staffID}
+	q, err := workflows.MoveTicketUpdate.Execute(ctx, c.client, wfID, req)
+	if err != nil {
+		return 0, queueError(fmt.Errorf("move failed: %w", err))
+	}
+	return q.GetPosition(userID), nil
+}
+
+func (c *TemporalQueueClient) RemoveTicket(ctx context.Context, businessID, queueID, userID, staffID, reason string) (*domain.Ticket, error) {
+	wfID := c.getWorkflowID(businessID, queueID)
+	req := workflows.RemoveTicketRequest{UserID: userID, StaffID: staffID, Reason: reason}
+	removed, err := workflows.RemoveTicketUpdate.Execute(ctx, c.client, wfID, req)
+	if err != nil {
+		return nil, queueError(fmt.Errorf("remove failed: %w", err))
+	}
+	return &removed.Ticket, nil
+}
+
+// ConfirmTicket is safe to retry: confirming twice is confirming.
+func (c *TemporalQueueClient) ConfirmTicket(ctx context.Context, businessID, queueID, userID string, location *domain.Location) (*domain.Ticket, error) {
+	wfID := c.getWorkflowID(businessID, queueID)
+	req := workflows.ConfirmTicketRequest{UserID: userID, Location: location}
+	ticket, err := workflows.ConfirmTicketUpdate.Execute(ctx, c.client, wfID, req)
+	if err != nil {
+		return nil, queueError(fmt.Errorf("confirm failed: %w", err))
+	}
+	return &ticket, nil
+}
+
+// AddWalkIn mints the guest ID like JoinQueue, so a retried walk-in
+// reattaches to the original insert.
+func (c *TemporalQueueClient) AddWalkIn(ctx context.Context, businessID, queueID string, position, partySize int, staffID, idempotencyKey string) (string, int, error) {
+	wfID := c.getWorkflowID(businessID, queueID)
+	userID := guestID(wfID, idempotencyKey)
+	req := workflows.InsertTicketRequest{
+		Ticket:   domain.Ticket{UserID: userID, PartySize: partySize},
+		Position: position,
+		StaffID:  staffID,
+		Reason:   "walk-in",
+		Joined:   true,
+	}
+
+	landed, err := workflows.InsertTicketUpdate.ExecuteWithID(ctx, c.client, wfID, "walk-in-"+userID, req)
+	if err != nil {
+		return "", 0, queueError(err)
+	}
+	return userID, landed, nil
+}
+
+// TransferTicket runs the transfer saga and waits for it. A transfer of the
+// same guest out of the same queue that is still running is joined instead of
+// starting another.
+func (c *TemporalQueueClient) TransferTicket(ctx context.Context, transfer domain.TicketTransfer) (int, error) {
+	options := client.StartWorkflowOptions{
+		ID:                       workflows.TransferWorkflowID(transfer.BusinessID, transfer.FromQueueID, transfer.UserID),
+		TaskQueue:                c.taskQueue,
+		WorkflowIDConflictPolicy: enums.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING,
+	}
+	run, err := c.client.ExecuteWorkflow(ctx, options, "TransferTicketWorkflow", transfer)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transfer: %w", err)
+	}
+
+	var position int
+	if err := run.Get(ctx, &position); err != nil {
+		return 0, queueError(fmt.Errorf("transfer failed: %w", err))
+	}
+	return position, nil
+}
+
+// WatchQueue polls the queue's status query and reports each new state.
+func (c *TemporalQueueClient) WatchQueue(ctx context.Context, businessID, queueID string, fn func(*domain.Queue) error) error {
+	interval := c.WatchInterval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *domain.Queue
+	for {
+		q, err := c.GetQueueStatus(ctx, businessID, queueID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if last == nil || !reflect.DeepEqual(q, last) {
+			if err := fn(q); err != nil {
+				return err
+			}
+			last = q
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// quoteQuery quotes s as a string literal of a visibility query.
+func quoteQuery(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// decodeSearchAttribute decodes the named search attribute into v, leaving v
+// unchanged when the workflow doesn't have it.
+func decodeSearchAttribute(fields map[string]*commonpb.Payload, name string, v interface{}) {
+	if p, ok := fields[name]; ok {
+		_ = converter.GetDefaultDataConverter().FromPayload(p, v)
+	}
+}
+
+// queueError recovers the domain error behind a failed call to a queue
+// workflow. A workflow that doesn't exist is a queue that doesn't exist.
+func queueError(err error) error {
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return domain.ErrQueueNotFound
+	}
+	return workflows.DomainError(err)
+}
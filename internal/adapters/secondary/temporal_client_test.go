@@ -0,0 +1,187 @@
+package secondary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+func TestJoinQueue_SameKeySameGuest(t *testing.T) {
+	c := new(mocks.Client)
+	queues := NewTemporalQueueClient(c, "test-queue")
+
+	var updateIDs []string
+	c.On("UpdateWorkflow", mock.Anything, mock.MatchedBy(func(o client.UpdateWorkflowOptions) bool {
+		updateIDs = append(updateIDs, o.UpdateID)
+		return o.WorkflowID == "biz_123:main" && o.UpdateID == "join-"+o.Args[0].(domain.JoinRequest).UserID
+	})).Return(joinUpdates(1, 5), nil)
+
+	first, err := queues.JoinQueue(context.Background(), "biz_123", "main", 0, "key-1")
+	require.NoError(t, err)
+	retried, err := queues.JoinQueue(context.Background(), "biz_123", "main", 0, "key-1")
+	require.NoError(t, err)
+	other, err := queues.JoinQueue(context.Background(), "biz_123", "main", 0, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, first.UserID, retried.UserID)
+	assert.Equal(t, 5, first.WaitMinutes)
+	assert.Equal(t, updateIDs[0], updateIDs[1])
+	assert.NotEqual(t, first.UserID, other.UserID)
+	// Only the join that added the guest is not a replay
+	assert.False(t, first.Replayed)
+	assert.True(t, retried.Replayed)
+	assert.False(t, other.Replayed)
+}
+
+// joinUpdates answers JoinQueue updates at position, with the estimated
+// wait, the way Temporal does:
+// an update ID sent again gets the first result back, nonce and all.
+func joinUpdates(position, wait int) func(context.Context, client.UpdateWorkflowOptions) (client.WorkflowUpdateHandle, error) {
+	first := map[string]string{}
+	return func(_ context.Context, o client.UpdateWorkflowOptions) (client.WorkflowUpdateHandle, error) {
+		if _, ok := first[o.UpdateID]; !ok {
+			first[o.UpdateID] = o.Args[0].(domain.JoinRequest).Nonce
+		}
+		nonce := first[o.UpdateID]
+		handle := new(mocks.WorkflowUpdateHandle)
+		handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*workflows.JoinResult) = workflows.JoinResult{Position: position, WaitMinutes: wait, Nonce: nonce}
+		})
+		return handle, nil
+	}
+}
+
+func TestCreateQueue_StartsWithBusinessGeofence(t *testing.T) {
+	c := new(mocks.Client)
+	queues := NewTemporalQueueClient(c, "test-queue")
+	queues.Confirm = domain.ConfirmPolicy{Ahead: 3, Within: 5 * time.Minute}
+	fence := domain.Geofence{Center: domain.Location{Latitude: 52.3676, Longitude: 4.9041}, RadiusMeters: 150}
+	queues.Geofences = map[string]domain.Geofence{"biz_123": fence}
+
+	run := new(mocks.WorkflowRun)
+	run.On("GetRunID").Return("run-1")
+	var started []workflows.QueueOptions
+	c.On("ExecuteWorkflow", mock.Anything, mock.Anything, "BusinessQueueWorkflow", mock.Anything, mock.Anything, mock.Anything).
+		Return(run, nil).
+		Run(func(args mock.Arguments) { started = append(started, args.Get(5).(workflows.QueueOptions)) })
+
+	_, err := queues.CreateQueue(context.Background(), "biz_123", "main")
+	require.NoError(t, err)
+	_, err = queues.CreateQueue(context.Background(), "biz_other", "main")
+	require.NoError(t, err)
+
+	require.Len(t, started, 2)
+	assert.Equal(t, 3, started[0].Confirm.Ahead)
+	assert.Equal(t, &fence, started[0].Confirm.Geofence)
+	// Businesses without a geofence don't check locations
+	assert.Nil(t, started[1].Confirm.Geofence)
+	assert.Nil(t, queues.Confirm.Geofence)
+}
+
+func TestGetQueueStatus_MissingWorkflowIsQueueNotFound(t *testing.T) {
+	c := new(mocks.Client)
+	c.On("QueryWorkflow", mock.Anything, "biz_123:missing", "", "GetStatus").
+		Return(nil, serviceerror.NewNotFound("workflow not found"))
+
+	_, err := NewTemporalQueueClient(c, "test-queue").GetQueueStatus(context.Background(), "biz_123", "missing")
+	assert.ErrorIs(t, err, domain.ErrQueueNotFound)
+}
+
+func TestListQueues_QueriesSearchAttributes(t *testing.T) {
+	c := new(mocks.Client)
+	payload := func(v interface{}) *commonpb.Payload {
+		p, err := converter.GetDefaultDataConverter().ToPayload(v)
+		require.NoError(t, err)
+		return p
+	}
+	c.On("ListWorkflow", mock.Anything, &workflowservice.ListWorkflowExecutionsRequest{
+		Query:         "WorkflowType = 'BusinessQueueWorkflow' AND ExecutionStatus = 'Running' AND BusinessID = 'biz\\'s' AND QueueState = 'CLOSED'",
+		PageSize:      10,
+		NextPageToken: []byte("page-2"),
+	}).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{{
+			SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+				"QueueID":     payload("main"),
+				"QueueState":  payload("CLOSED"),
+				"QueueLength": payload(3),
+			}},
+		}},
+		NextPageToken: []byte("page-3"),
+	}, nil)
+
+	queues, next, err := NewTemporalQueueClient(c, "test-queue").
+		ListQueues(context.Background(), "biz's", domain.QueueStateClosed, 10, []byte("page-2"))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.QueueSummary{{ID: "main", BusinessID: "biz's", State: domain.QueueStateClosed, Length: 3}}, queues)
+	assert.Equal(t, []byte("page-3"), next)
+}
+
+func TestDeleteQueue_WaitsForReport(t *testing.T) {
+	c := new(mocks.Client)
+	c.On("SignalWorkflow", mock.Anything, "biz_123:main", "", "Shutdown",
+		workflows.ShutdownRequest{Reason: "closing early", RequestedBy: "staff-1"}).Return(nil)
+	run := new(mocks.WorkflowRun)
+	run.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*domain.QueueReport) = domain.QueueReport{QueueID: "main", Served: 4, Cancelled: 1}
+	})
+	c.On("GetWorkflow", mock.Anything, "biz_123:main", "").Return(run)
+
+	report, err := NewTemporalQueueClient(c, "test-queue").DeleteQueue(context.Background(), "biz_123", "main", "closing early", "staff-1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Served)
+	assert.Equal(t, 1, report.Cancelled)
+
+	c.On("SignalWorkflow", mock.Anything, "biz_123:gone", "", "Shutdown", mock.Anything).
+		Return(serviceerror.NewNotFound("workflow execution already completed"))
+	_, err = NewTemporalQueueClient(c, "test-queue").DeleteQueue(context.Background(), "biz_123", "gone", "", "")
+	assert.ErrorIs(t, err, domain.ErrQueueNotFound)
+}
+
+func TestWatchQueue_ReportsChanges(t *testing.T) {
+	c := new(mocks.Client)
+	queues := NewTemporalQueueClient(c, "test-queue")
+	queues.WatchInterval = time.Millisecond
+
+	// The queue changes once, between the second and third poll
+	states := []int{1, 1, 2, 2}
+	polls := 0
+	value := new(mocks.Value)
+	value.On("Get", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		q := domain.NewQueue("main", "biz_123")
+		for i := 0; i < states[min(polls, len(states)-1)]; i++ {
+			q.Tickets = append(q.Tickets, domain.Ticket{UserID: string(rune('a' + i))})
+		}
+		polls++
+		*args.Get(0).(*domain.Queue) = *q
+	})
+	c.On("QueryWorkflow", mock.Anything, "biz_123:main", "", "GetStatus").Return(value, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lengths []int
+	err := queues.WatchQueue(ctx, "biz_123", "main", func(q *domain.Queue) error {
+		lengths = append(lengths, q.Len())
+		if len(lengths) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{1, 2}, lengths)
+	assert.GreaterOrEqual(t, polls, 3)
+}
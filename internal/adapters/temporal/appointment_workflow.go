@@ -0,0 +1,206 @@
+package temporal
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"red-duck/analytics"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/requestid"
+	"red-duck/internal/workflows"
+)
+
+// AppointmentWorkflow holds a seat of a slot, and returns the appointment as
+// it ended. The guest is reminded before the slot, and checking in puts them
+// in the queue ahead of the waiting walk-ins. The seat is released when the
+// appointment is cancelled or at its no-show deadline; an appointment still
+// booked then is a no-show.
+func AppointmentWorkflow(ctx workflow.Context, params workflows.AppointmentParams) (domain.Appointment, error) {
+	logger := workflow.GetLogger(ctx)
+	appt := params.Appointment
+	logger.Info("AppointmentWorkflow started", "AppointmentID", appt.ID, "UserID", appt.UserID, "RequestID", requestid.FromWorkflow(ctx))
+
+	// Set while the check-in waits for the queue, so the appointment can't be
+	// cancelled or marked a no-show under it
+	checkingIn := false
+
+	var a *AppointmentActivities
+	record := func(ctx workflow.Context, event string) {
+		container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout:    10 * time.Second,
+			ScheduleToCloseTimeout: 5 * time.Minute,
+		})
+		if err := workflow.ExecuteActivity(container, a.RecordAppointment, AppointmentEventParams{Event: event, Appointment: appt}).Get(container, nil); err != nil {
+			logger.Error("RecordAppointment activity failed", "Event", event, "Error", err)
+		}
+	}
+
+	err := workflows.GetAppointmentQuery.SetHandler(ctx, func() (domain.Appointment, error) {
+		return appt, nil
+	})
+	if err != nil {
+		return appt, err
+	}
+
+	// Define CheckIn Update: the ticket is inserted like a walk-in, at the
+	// appointment's priority, and joins the queue in analytics as one does
+	err = workflows.CheckInUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.CheckInRequest) (domain.Appointment, error) {
+			checkingIn = true
+			defer func() { checkingIn = false }()
+
+			stepCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+				StartToCloseTimeout:    10 * time.Second,
+				ScheduleToCloseTimeout: time.Minute,
+			})
+			var t *TransferActivities
+			var position int
+			err := workflow.ExecuteActivity(stepCtx, t.InsertTicket, InsertTicketParams{
+				WorkflowID: workflows.QueueWorkflowID(appt.BusinessID, appt.QueueID),
+				Request: workflows.InsertTicketRequest{
+					Ticket: domain.Ticket{
+						UserID:   appt.UserID,
+						Status:   domain.TicketStatusWaiting,
+						Priority: domain.PriorityAppointment,
+					},
+					StaffID: req.StaffID,
+					Reason:  "appointment " + appt.ID,
+					Joined:  true,
+				},
+			}).Get(ctx, &position)
+			if err != nil {
+				logger.Error("Check-in failed", "AppointmentID", appt.ID, "Error", err)
+				return domain.Appointment{}, err
+			}
+
+			appt.CheckIn(workflow.Now(ctx), position)
+			record(ctx, AppointmentCheckedIn)
+			logger.Info("Appointment checked in", "AppointmentID", appt.ID, "Position", position, "RequestID", requestid.FromWorkflow(ctx))
+			return appt, nil
+		},
+		func(ctx workflow.Context, req workflows.CheckInRequest) error {
+			if checkingIn {
+				return workflows.ApplicationError(domain.ErrAppointmentClosed)
+			}
+			return workflows.ApplicationError(appt.CanCheckIn(workflow.Now(ctx)))
+		},
+	)
+	if err != nil {
+		return appt, err
+	}
+
+	// Define CancelAppointment Update
+	err = workflows.CancelAppointmentUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.CancelAppointmentRequest) (domain.Appointment, error) {
+			if err := appt.Cancel(); err != nil {
+				return domain.Appointment{}, workflows.ApplicationError(err)
+			}
+			record(ctx, AppointmentCancelled)
+			logger.Info("Appointment cancelled", "AppointmentID", appt.ID, "StaffID", req.StaffID, "RequestID", requestid.FromWorkflow(ctx))
+			return appt, nil
+		},
+		func(ctx workflow.Context, req workflows.CancelAppointmentRequest) error {
+			if checkingIn || appt.Status != domain.AppointmentBooked {
+				return workflows.ApplicationError(domain.ErrAppointmentClosed)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return appt, err
+	}
+
+	record(ctx, AppointmentBooked)
+
+	// Reminders stop once the guest checked in or cancelled
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		remindCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout:    10 * time.Second,
+			ScheduleToCloseTimeout: 5 * time.Minute,
+		})
+		for _, at := range params.RemindAt {
+			if err := workflow.Sleep(ctx, at.Sub(workflow.Now(ctx))); err != nil {
+				return
+			}
+			if appt.Status != domain.AppointmentBooked {
+				return
+			}
+			if err := workflow.ExecuteActivity(remindCtx, a.SendReminder, appt).Get(remindCtx, nil); err != nil {
+				logger.Error("SendReminder activity failed", "AppointmentID", appt.ID, "Error", err)
+			}
+		}
+	})
+
+	// Hold the seat until the no-show deadline, or until it is given back
+	_, err = workflow.AwaitWithTimeout(ctx, appt.NoShowAt.Sub(workflow.Now(ctx)), func() bool {
+		return appt.Status == domain.AppointmentCancelled
+	})
+	if err != nil {
+		return appt, err
+	}
+	if err := workflow.Await(ctx, func() bool { return !checkingIn }); err != nil {
+		return appt, err
+	}
+	if appt.Status == domain.AppointmentBooked {
+		appt.Status = domain.AppointmentNoShow
+		record(ctx, AppointmentNoShow)
+		logger.Info("Appointment not checked in, releasing seat", "AppointmentID", appt.ID)
+	}
+
+	if err := workflow.Await(ctx, func() bool { return workflow.AllHandlersFinished(ctx) }); err != nil {
+		return appt, err
+	}
+	return appt, nil
+}
+
+// Appointment changes, recorded as appointment.<event> events.
+const (
+	AppointmentBooked    = "booked"
+	AppointmentCheckedIn = "checked_in"
+	AppointmentCancelled = "cancelled"
+	AppointmentNoShow    = "no_show"
+)
+
+// AppointmentActivities publish what happens to appointments.
+type AppointmentActivities struct {
+	Tracker analytics.EventTracker
+}
+
+// AppointmentEventParams describes a change to an appointment.
+type AppointmentEventParams struct {
+	Event       string
+	Appointment domain.Appointment
+}
+
+// RecordAppointment tracks a change to an appointment, as an event attributed
+// to its guest.
+func (a *AppointmentActivities) RecordAppointment(ctx context.Context, params AppointmentEventParams) error {
+	appt := params.Appointment
+	props := map[string]interface{}{
+		"queue_id":       appt.QueueID,
+		"appointment_id": appt.ID,
+		"slot_start":     appt.SlotStart,
+	}
+	if appt.Position != 0 {
+		props["position"] = appt.Position
+	}
+
+	a.Tracker.Track(ctx, "appointment."+params.Event, appt.BusinessID, appt.UserID, props)
+	return nil
+}
+
+// SendReminder tracks an appointment.reminder event; whoever consumes the
+// events delivers it to the guest.
+func (a *AppointmentActivities) SendReminder(ctx context.Context, appt domain.Appointment) error {
+	props := map[string]interface{}{
+		"queue_id":       appt.QueueID,
+		"appointment_id": appt.ID,
+		"slot_start":     appt.SlotStart,
+		"check_in_from":  appt.CheckInFrom,
+	}
+
+	a.Tracker.Track(ctx, "appointment.reminder", appt.BusinessID, appt.UserID, props)
+	return nil
+}
@@ -0,0 +1,140 @@
+package temporal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+func TestAppointmentWorkflow(t *testing.T) {
+	slot := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	params := workflows.AppointmentParams{
+		Appointment: domain.Appointment{
+			ID:          "20260302T1000Z-1",
+			BusinessID:  "biz-1",
+			QueueID:     "front-desk",
+			UserID:      "guest-1",
+			SlotStart:   slot,
+			Seat:        1,
+			Status:      domain.AppointmentBooked,
+			CheckInFrom: slot.Add(-30 * time.Minute),
+			NoShowAt:    slot.Add(10 * time.Minute),
+		},
+		RemindAt: []time.Time{slot.Add(-time.Hour)},
+	}
+	setup := func(t *testing.T) (*testsuite.TestWorkflowEnvironment, *AppointmentActivities, *TransferActivities) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		env.SetStartTime(slot.Add(-2 * time.Hour))
+		var a *AppointmentActivities
+		var ta *TransferActivities
+		env.RegisterActivity(a)
+		env.RegisterActivity(ta)
+		env.OnActivity(a.RecordAppointment, mock.Anything, mock.MatchedBy(func(p AppointmentEventParams) bool {
+			return p.Event == AppointmentBooked
+		})).Return(nil).Once()
+		return env, a, ta
+	}
+
+	t.Run("Checks the guest in ahead of walk-ins and holds the seat", func(t *testing.T) {
+		env, a, ta := setup(t)
+		env.OnActivity(a.SendReminder, mock.Anything, mock.MatchedBy(func(appt domain.Appointment) bool {
+			return appt.ID == "20260302T1000Z-1"
+		})).Return(nil).Once()
+		env.OnActivity(ta.InsertTicket, mock.Anything, InsertTicketParams{
+			WorkflowID: "biz-1:front-desk",
+			Request: workflows.InsertTicketRequest{
+				Ticket:  domain.Ticket{UserID: "guest-1", Status: domain.TicketStatusWaiting, Priority: domain.PriorityAppointment},
+				StaffID: "staff-1",
+				Reason:  "appointment 20260302T1000Z-1",
+				Joined:  true,
+			},
+		}).Return(2, nil).Once()
+		env.OnActivity(a.RecordAppointment, mock.Anything, mock.MatchedBy(func(p AppointmentEventParams) bool {
+			return p.Event == AppointmentCheckedIn && p.Appointment.Position == 2
+		})).Return(nil).Once()
+
+		var early, checkedIn error
+		var result domain.Appointment
+		env.RegisterDelayedCallback(func() {
+			// An hour before the slot check-in isn't open yet
+			env.UpdateWorkflow(workflows.UpdateCheckIn, "check-in-1", &testsuite.TestUpdateCallback{
+				OnReject:   func(err error) { early = err },
+				OnAccept:   func() { t.Error("early check-in accepted") },
+				OnComplete: func(interface{}, error) {},
+			}, workflows.CheckInRequest{StaffID: "staff-1"})
+		}, time.Hour)
+		env.RegisterDelayedCallback(func() {
+			env.UpdateWorkflow(workflows.UpdateCheckIn, "check-in-2", &testsuite.TestUpdateCallback{
+				OnReject: func(err error) { t.Errorf("check-in rejected: %v", err) },
+				OnAccept: func() {},
+				OnComplete: func(r interface{}, err error) {
+					checkedIn = err
+					result = r.(domain.Appointment)
+				},
+			}, workflows.CheckInRequest{StaffID: "staff-1"})
+		}, 110*time.Minute)
+
+		env.ExecuteWorkflow(AppointmentWorkflow, params)
+
+		assert.NoError(t, env.GetWorkflowError())
+		assert.ErrorIs(t, workflows.DomainError(early), domain.ErrCheckInNotOpen)
+		assert.NoError(t, checkedIn)
+		assert.Equal(t, domain.AppointmentCheckedIn, result.Status)
+		assert.Equal(t, 2, result.Position)
+
+		var final domain.Appointment
+		assert.NoError(t, env.GetWorkflowResult(&final))
+		assert.Equal(t, domain.AppointmentCheckedIn, final.Status)
+		// The seat is held until the no-show deadline
+		assert.True(t, env.Now().Equal(params.Appointment.NoShowAt), "released at %v", env.Now())
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Releases the seat of a no-show", func(t *testing.T) {
+		env, a, _ := setup(t)
+		env.OnActivity(a.SendReminder, mock.Anything, mock.Anything).Return(nil).Once()
+		env.OnActivity(a.RecordAppointment, mock.Anything, mock.MatchedBy(func(p AppointmentEventParams) bool {
+			return p.Event == AppointmentNoShow && p.Appointment.Status == domain.AppointmentNoShow
+		})).Return(nil).Once()
+
+		env.ExecuteWorkflow(AppointmentWorkflow, params)
+
+		assert.NoError(t, env.GetWorkflowError())
+		var final domain.Appointment
+		assert.NoError(t, env.GetWorkflowResult(&final))
+		assert.Equal(t, domain.AppointmentNoShow, final.Status)
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Releases the seat on cancellation, without reminding", func(t *testing.T) {
+		env, a, _ := setup(t)
+		env.OnActivity(a.RecordAppointment, mock.Anything, mock.MatchedBy(func(p AppointmentEventParams) bool {
+			return p.Event == AppointmentCancelled
+		})).Return(nil).Once()
+
+		env.RegisterDelayedCallback(func() {
+			env.UpdateWorkflow(workflows.UpdateCancelAppointment, "cancel-1", &testsuite.TestUpdateCallback{
+				OnReject:   func(err error) { t.Errorf("cancel rejected: %v", err) },
+				OnAccept:   func() {},
+				OnComplete: func(interface{}, error) {},
+			}, workflows.CancelAppointmentRequest{})
+		}, 10*time.Minute)
+
+		env.ExecuteWorkflow(AppointmentWorkflow, params)
+
+		assert.NoError(t, env.GetWorkflowError())
+		var final domain.Appointment
+		assert.NoError(t, env.GetWorkflowResult(&final))
+		assert.Equal(t, domain.AppointmentCancelled, final.Status)
+		assert.True(t, env.Now().Before(params.RemindAt[0]), "released at %v", env.Now())
+		env.AssertNotCalled(t, "SendReminder", mock.Anything, mock.Anything)
+		env.AssertExpectations(t)
+	})
+}
@@ -1,27 +1,132 @@
 package temporal
 
 import (
+	"encoding/json"
 	"time"
 
 	"red-duck/internal/core/domain"
-	"red-duck/internal/pkg/update"
+	"red-duck/internal/pkg/actor"
+	"red-duck/internal/pkg/requestid"
+	"red-duck/internal/workflows"
 
 	"go.temporal.io/sdk/workflow"
 )
 
-// BusinessQueueWorkflow manages a business queue.
-func BusinessQueueWorkflow(ctx workflow.Context, businessID, queueID string) error {
+// BusinessQueueWorkflow manages a business queue until it is shut down, and
+// returns its final report. Queues started before they took options run with
+// the zero options.
+func BusinessQueueWorkflow(ctx workflow.Context, businessID, queueID string, opts workflows.QueueOptions) (domain.QueueReport, error) {
 	logger := workflow.GetLogger(ctx)
-	logger.Info("BusinessQueueWorkflow started", "BusinessID", businessID, "QueueID", queueID)
+	logger.Info("BusinessQueueWorkflow started", "BusinessID", businessID, "QueueID", queueID, "RequestID", requestid.FromWorkflow(ctx))
 
-	state := domain.NewQueue(businessID, queueID)
+	state := domain.NewQueue(queueID, businessID)
+	state.Capacity = opts.Capacity
+	var stats domain.QueueStats
 
-	// Define the Update type
-	joinQueueUpdate := update.New[domain.JoinRequest, int]("JoinQueue")
+	// Remote guests nearing the front are asked to confirm they are coming,
+	// and each is given a timer for their deadline. The zero policy asks
+	// nobody, so queues without it replay as before.
+	confirm := opts.Confirm
+	maxSkips := opts.MaxSkips
+	if maxSkips == 0 {
+		maxSkips = domain.DefaultMaxSkips
+	}
+	var askConfirmations func()
 
-	// Set Update Handler with Options (Validator)
-	err := workflow.SetUpdateHandlerWithOptions(ctx, joinQueueUpdate.Name(),
-		func(ctx workflow.Context, req domain.JoinRequest) (int, error) {
+	// Queues started before they had search attributes keep running without
+	// them, so their history still replays
+	searchable := workflow.GetVersion(ctx, "queue-search-attributes", workflow.DefaultVersion, 1) == 1
+	recordState := func(ctx workflow.Context) {
+		askConfirmations()
+		workflows.RecordQueueMetrics(ctx, state)
+		if !searchable {
+			return
+		}
+		if err := workflows.UpsertQueueSearchAttributes(ctx, state); err != nil {
+			logger.Error("Failed to upsert search attributes", "Error", err)
+		}
+	}
+
+	recordConfirmation := func(ctx workflow.Context, params ConfirmationParams) error {
+		params.BusinessID = businessID
+		params.QueueID = queueID
+		container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout:    10 * time.Second,
+			ScheduleToCloseTimeout: 5 * time.Minute,
+		})
+		var a *QueueActivities
+		err := workflow.ExecuteActivity(container, a.RecordConfirmation, params).Get(container, nil)
+		if err != nil {
+			logger.Error("RecordConfirmation activity failed", "Event", params.Event, "Error", err)
+		}
+		return err
+	}
+	askConfirmations = func() {
+		for _, userID := range state.DueForConfirmation(confirm) {
+			deadline := workflow.Now(ctx).Add(confirm.Within)
+			state.RequestConfirmation(userID, deadline)
+			workflow.Go(ctx, func(ctx workflow.Context) {
+				// The event is the ask, so the deadline only runs once it
+				// is out. An ask that never went out is withdrawn, and the
+				// guest asked again when the queue next changes; asks that
+				// failed before this replay as they ran.
+				err := recordConfirmation(ctx, ConfirmationParams{UserID: userID, Event: ConfirmRequested, ConfirmBy: deadline, Position: state.GetPosition(userID)})
+				if err != nil && workflow.GetVersion(ctx, "confirm-ask-published", workflow.DefaultVersion, 1) == 1 {
+					state.WithdrawConfirmation(userID, deadline)
+					return
+				}
+				if err := workflow.Sleep(ctx, confirm.Within); err != nil {
+					return
+				}
+				_, position, expired := state.MissConfirmation(userID, deadline, confirm)
+				if position == 0 {
+					return
+				}
+				event := ConfirmMissed
+				if expired {
+					event = TicketExpired
+					stats.RecordLeft()
+				}
+				recordConfirmation(ctx, ConfirmationParams{UserID: userID, Event: event, Position: position})
+				recordState(ctx)
+				logger.Info("Confirmation deadline missed", "UserID", userID, "Position", position, "Expired", expired)
+			})
+		}
+	}
+	recordState(ctx)
+
+	// Staff updates are written to the audit log under who sent them. Queues
+	// started before the log replay without it.
+	audited := workflow.GetVersion(ctx, "staff-audit-log", workflow.DefaultVersion, 1) == 1
+	recordAudit := func(ctx workflow.Context, who actor.Actor, action, targetUserID string, before, after any) {
+		if !audited {
+			return
+		}
+		entry := domain.AuditEntry{
+			BusinessID:   businessID,
+			QueueID:      queueID,
+			ActorID:      who.UserID,
+			ActorRole:    who.Role,
+			Action:       action,
+			TargetUserID: targetUserID,
+			Before:       auditJSON(before),
+			After:        auditJSON(after),
+			RequestID:    requestid.FromWorkflow(ctx),
+			At:           workflow.Now(ctx),
+		}
+		container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout:    10 * time.Second,
+			ScheduleToCloseTimeout: 5 * time.Minute,
+		})
+		var a *QueueActivities
+		if err := workflow.ExecuteActivity(container, a.RecordAudit, entry).Get(container, nil); err != nil {
+			logger.Error("RecordAudit activity failed", "Action", action, "Error", err)
+		}
+	}
+
+	// Set Update Handler with Validator
+	err := workflows.JoinQueueUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req domain.JoinRequest) (workflows.JoinResult, error) {
 			// Activity Options
 			container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 				StartToCloseTimeout: 10 * time.Second,
@@ -29,44 +134,46 @@ func BusinessQueueWorkflow(ctx workflow.Context, businessID, queueID string) err
 
 			// Call JoinQueue Activity (Simulated DB + NATS)
 			var a *QueueActivities
+			wait := state.NewcomerWaitMinutes(req.PartySize)
 			params := JoinQueueParams{
 				BusinessID:      businessID,
+				QueueID:         queueID,
 				UserID:          req.UserID,
 				QueueLength:     state.Len() + 1,
-				WaitTimeMinutes: (state.Len() + 1) * 5, // Rough estimate
+				WaitTimeMinutes: wait,
+				PartySize:       max(req.PartySize, 1),
 			}
 			err := workflow.ExecuteActivity(container, a.JoinQueue, params).Get(container, nil)
 			if err != nil {
 				logger.Error("JoinQueue activity failed", "Error", err)
-				return 0, err
+				return workflows.JoinResult{}, err
 			}
 
-			// Handler logic: Add user to state and return position
-			position := state.AddUser(req.UserID)
-			logger.Info("User joined queue", "UserID", req.UserID, "Position", position)
-			return position, nil
+			// Handler logic: Add user to state and return position. Joins
+			// come from the guest's phone, wherever they are.
+			position := state.AddUser(req.UserID, workflow.Now(ctx))
+			state.Tickets[position-1].Remote = true
+			state.Tickets[position-1].PartySize = req.PartySize
+			recordState(ctx)
+			logger.Info("User joined queue", "UserID", req.UserID, "Position", position, "RequestID", requestid.FromWorkflow(ctx))
+			return workflows.JoinResult{Position: position, WaitMinutes: wait, Nonce: req.Nonce}, nil
 		},
-		workflow.UpdateHandlerOptions{
-			Validator: func(ctx workflow.Context, req domain.JoinRequest) error {
-				// Validator logic: Check if queue is closed or user already exists
-				return state.CanJoin(req.UserID)
-			},
+		func(ctx workflow.Context, req domain.JoinRequest) error {
+			// Validator logic: Check the party, and if queue is closed, full or user already exists
+			if err := domain.ValidPartySize(req.PartySize); err != nil {
+				return workflows.ApplicationError(err)
+			}
+			return workflows.ApplicationError(state.CanJoin(req.UserID))
 		},
 	)
 	if err != nil {
 		logger.Error("Failed to set update handler", "Error", err)
-		return err
+		return domain.QueueReport{}, err
 	}
 
 	// Define LeaveQueue Update
-	leaveQueueUpdate := update.New[domain.JoinRequest, int]("LeaveQueue")
-	err = workflow.SetUpdateHandler(ctx, leaveQueueUpdate.Name(),
+	err = workflows.LeaveQueueUpdate.SetHandler(ctx,
 		func(ctx workflow.Context, req domain.JoinRequest) (int, error) {
-			// Check if user exists before calling activity
-			if state.GetPosition(req.UserID) == 0 {
-				return 0, domain.ErrUserNotFound
-			}
-
 			// Activity Options
 			container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 				StartToCloseTimeout: 10 * time.Second,
@@ -75,13 +182,13 @@ func BusinessQueueWorkflow(ctx workflow.Context, businessID, queueID string) err
 			// Call LeaveQueue Activity
 			var a *QueueActivities
 			params := JoinQueueParams{
-				BusinessID:      businessID,
-				QueueLength:     state.Len() - 1,
-				WaitTimeMinutes: (state.Len() - 1) * 5,
+				BusinessID:  businessID,
+				QueueID:     queueID,
+				UserID:      req.UserID,
+				QueueLength: max(state.Len()-1, 0),
 			}
-			if params.QueueLength < 0 {
-				params.QueueLength = 0
-				params.WaitTimeMinutes = 0
+			if pos := state.GetPosition(req.UserID); pos > 0 {
+				params.PartySize = state.Tickets[pos-1].Guests()
 			}
 
 			err := workflow.ExecuteActivity(container, a.LeaveQueue, params).Get(container, nil)
@@ -89,29 +196,337 @@ func BusinessQueueWorkflow(ctx workflow.Context, businessID, queueID string) err
 				return 0, err
 			}
 
+			// Guests who leave before being called abandoned the queue
+			pos := state.GetPosition(req.UserID)
+			waiting := pos > 0 && state.Tickets[pos-1].Status == domain.TicketStatusWaiting
 			err = state.Dequeue(req.UserID)
 			if err != nil {
-				return 0, err
+				return 0, workflows.ApplicationError(err)
 			}
-			logger.Info("User left queue", "UserID", req.UserID)
+			if waiting {
+				stats.RecordLeft()
+			}
+			recordState(ctx)
+			logger.Info("User left queue", "UserID", req.UserID, "RequestID", requestid.FromWorkflow(ctx))
 			return state.Len(), nil
 		},
+		func(ctx workflow.Context, req domain.JoinRequest) error {
+			// Check if user exists before calling activity
+			if state.GetPosition(req.UserID) == 0 {
+				return workflows.ApplicationError(domain.ErrUserNotFound)
+			}
+			return nil
+		},
 	)
 	if err != nil {
-		return err
+		return domain.QueueReport{}, err
+	}
+
+	// Define CallNext Update: an update rather than a signal so the caller gets
+	// the called ticket back, and a retry with the same update ID is deduplicated.
+	err = workflows.CallNextUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.CallNextSignal) (domain.Ticket, error) {
+			// A call with a capacity seats the first party that fits
+			var ticket *domain.Ticket
+			var err error
+			if req.Capacity > 0 {
+				ticket, err = state.ServeFitting(req.CounterID, req.Capacity, maxSkips)
+			} else {
+				ticket, err = state.ServeNext(req.CounterID)
+			}
+			if err != nil {
+				return domain.Ticket{}, workflows.ApplicationError(err)
+			}
+			called := auditTicket{Ticket: *ticket, Position: state.GetPosition(ticket.UserID)}
+			waiting := called
+			waiting.Status, waiting.AssignedTo = domain.TicketStatusWaiting, ""
+			stats.RecordServed(workflow.Now(ctx).Sub(ticket.JoinedAt))
+			recordState(ctx)
+
+			container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+				StartToCloseTimeout: time.Minute,
+			})
+			var a *QueueActivities
+			params := workflows.CallNextParams{
+				BusinessID: businessID,
+				QueueID:    queueID,
+				UserID:     ticket.UserID,
+				CounterID:  req.CounterID,
+				Status:     string(ticket.Status),
+				PartySize:  ticket.Guests(),
+			}
+			if err := workflow.ExecuteActivity(container, a.CallNext, params).Get(container, nil); err != nil {
+				logger.Error("CallNext activity failed", "Error", err)
+			}
+			recordAudit(ctx, actor.FromWorkflow(ctx), domain.AuditCallNext, called.UserID, waiting, called)
+
+			logger.Info("Calling next user", "UserID", ticket.UserID, "CounterID", req.CounterID, "RequestID", requestid.FromWorkflow(ctx))
+			return *ticket, nil
+		},
+		func(ctx workflow.Context, req workflows.CallNextSignal) error {
+			if req.Capacity > 0 {
+				return workflows.ApplicationError(state.CanSeat(req.Capacity, maxSkips))
+			}
+			if !state.HasWaiting() {
+				return workflows.ApplicationError(domain.ErrQueueEmpty)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	// Define SetOpen Update: closing only stops new joins, waiting tickets are still served
+	err = workflows.SetOpenUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, open bool) (domain.Queue, error) {
+			before := auditQueueState{Closed: state.Closed}
+			state.Closed = !open
+			recordState(ctx)
+			action := domain.AuditOpenQueue
+			if !open {
+				action = domain.AuditCloseQueue
+			}
+			recordAudit(ctx, actor.FromWorkflow(ctx), action, "", before, auditQueueState{Closed: state.Closed})
+			logger.Info("Queue open state changed", "Open", open, "RequestID", requestid.FromWorkflow(ctx))
+			return state.Snapshot(), nil
+		},
+		nil,
+	)
+	if err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	// Staff changes to tickets are published as ticket events once applied,
+	// apart from the audit log recordAudit keeps
+	publishTicketChange := func(ctx workflow.Context, params TicketChangeParams) {
+		params.BusinessID = businessID
+		params.QueueID = queueID
+		container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+		})
+		var a *QueueActivities
+		if err := workflow.ExecuteActivity(container, a.RecordTicketChange, params).Get(container, nil); err != nil {
+			logger.Error("RecordTicketChange activity failed", "Error", err)
+		}
+	}
+
+	// Define MoveTicket Update
+	err = workflows.MoveTicketUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.MoveTicketRequest) (domain.Queue, error) {
+			from := state.GetPosition(req.UserID)
+			if err := state.MoveTo(req.UserID, req.Position); err != nil {
+				return domain.Queue{}, workflows.ApplicationError(err)
+			}
+			// A move can bring a remote guest into reach of confirming. Moves
+			// made before they recorded the state replay without it.
+			if workflow.GetVersion(ctx, "ticket-move-state", workflow.DefaultVersion, 1) == 1 {
+				recordState(ctx)
+			}
+			position := state.GetPosition(req.UserID)
+			ticket := state.Tickets[position-1]
+			recordAudit(ctx, updateActor(ctx, req.StaffID), domain.AuditMoveTicket, req.UserID,
+				auditTicket{Ticket: ticket, Position: from}, auditTicket{Ticket: ticket, Position: position})
+			// Moves made before they were published replay without the activity
+			if workflow.GetVersion(ctx, "ticket-move-audit", workflow.DefaultVersion, 1) == 1 {
+				publishTicketChange(ctx, TicketChangeParams{UserID: req.UserID, StaffID: req.StaffID, Action: TicketMoved, Position: position})
+			}
+			logger.Info("Ticket moved", "UserID", req.UserID, "Position", position, "RequestID", requestid.FromWorkflow(ctx))
+			return state.Snapshot(), nil
+		},
+		func(ctx workflow.Context, req workflows.MoveTicketRequest) error {
+			if state.GetPosition(req.UserID) == 0 {
+				return workflows.ApplicationError(domain.ErrUserNotFound)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	// Define RemoveTicket Update: staff removals, including transfers out, are
+	// not counted as abandoning the queue
+	err = workflows.RemoveTicketUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.RemoveTicketRequest) (workflows.RemovedTicket, error) {
+			position := state.GetPosition(req.UserID)
+			ticket, err := state.Remove(req.UserID)
+			if err != nil {
+				return workflows.RemovedTicket{}, workflows.ApplicationError(err)
+			}
+			recordState(ctx)
+			publishTicketChange(ctx, TicketChangeParams{UserID: req.UserID, StaffID: req.StaffID, Action: TicketRemoved, Position: position, Reason: req.Reason})
+			recordAudit(ctx, updateActor(ctx, req.StaffID), domain.AuditRemoveTicket, req.UserID,
+				auditTicket{Ticket: ticket, Position: position, Reason: req.Reason}, nil)
+			logger.Info("Ticket removed", "UserID", req.UserID, "Reason", req.Reason, "RequestID", requestid.FromWorkflow(ctx))
+			return workflows.RemovedTicket{Ticket: ticket, Position: position}, nil
+		},
+		func(ctx workflow.Context, req workflows.RemoveTicketRequest) error {
+			if state.GetPosition(req.UserID) == 0 {
+				return workflows.ApplicationError(domain.ErrUserNotFound)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	// Define InsertTicket Update: staff may insert into a closed queue
+	err = workflows.InsertTicketUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.InsertTicketRequest) (int, error) {
+			ticket := req.Ticket
+			if ticket.JoinedAt.IsZero() {
+				ticket.JoinedAt = workflow.Now(ctx)
+			}
+			if ticket.Status == "" {
+				ticket.Status = domain.TicketStatusWaiting
+			}
+			position, err := state.InsertAt(ticket, req.Position)
+			if err != nil {
+				return 0, workflows.ApplicationError(err)
+			}
+			recordState(ctx)
+			// New guests count as joins in analytics. Inserts from before
+			// requests said so carry none, so they replay without it.
+			if req.Joined {
+				container := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+					StartToCloseTimeout: 10 * time.Second,
+				})
+				var a *QueueActivities
+				params := JoinQueueParams{
+					BusinessID:      businessID,
+					QueueID:         queueID,
+					UserID:          ticket.UserID,
+					QueueLength:     state.Len(),
+					WaitTimeMinutes: state.WaitMinutes(position),
+					PartySize:       ticket.Guests(),
+				}
+				if err := workflow.ExecuteActivity(container, a.JoinQueue, params).Get(container, nil); err != nil {
+					logger.Error("JoinQueue activity failed", "Error", err)
+				}
+			}
+			publishTicketChange(ctx, TicketChangeParams{UserID: ticket.UserID, StaffID: req.StaffID, Action: TicketInserted, Position: position, Reason: req.Reason})
+			recordAudit(ctx, updateActor(ctx, req.StaffID), domain.AuditInsertTicket, ticket.UserID,
+				nil, auditTicket{Ticket: ticket, Position: position, Reason: req.Reason})
+			logger.Info("Ticket inserted", "UserID", ticket.UserID, "Position", position, "Reason", req.Reason, "RequestID", requestid.FromWorkflow(ctx))
+			return position, nil
+		},
+		func(ctx workflow.Context, req workflows.InsertTicketRequest) error {
+			if req.Ticket.UserID == "" {
+				return workflows.ApplicationError(domain.ErrMissingUserID)
+			}
+			if err := domain.ValidPartySize(req.Ticket.PartySize); err != nil {
+				return workflows.ApplicationError(err)
+			}
+			if state.GetPosition(req.Ticket.UserID) != 0 {
+				return workflows.ApplicationError(domain.ErrUserAlreadyInQueue)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	// Define ConfirmTicket Update: a location is only checked against the
+	// queue's geofence, if it has one
+	err = workflows.ConfirmTicketUpdate.SetHandler(ctx,
+		func(ctx workflow.Context, req workflows.ConfirmTicketRequest) (domain.Ticket, error) {
+			arrived, err := confirm.Arrival(req.Location)
+			if err != nil {
+				return domain.Ticket{}, workflows.ApplicationError(err)
+			}
+			ticket, err := state.Confirm(req.UserID, arrived)
+			if err != nil {
+				return domain.Ticket{}, workflows.ApplicationError(err)
+			}
+			recordState(ctx)
+			recordConfirmation(ctx, ConfirmationParams{UserID: req.UserID, Event: TicketConfirmed, Position: state.GetPosition(req.UserID), Arrived: ticket.Arrived})
+			logger.Info("Ticket confirmed", "UserID", req.UserID, "Arrived", ticket.Arrived, "RequestID", requestid.FromWorkflow(ctx))
+			return ticket, nil
+		},
+		func(ctx workflow.Context, req workflows.ConfirmTicketRequest) error {
+			pos := state.GetPosition(req.UserID)
+			if pos == 0 {
+				return workflows.ApplicationError(domain.ErrUserNotFound)
+			}
+			if state.Tickets[pos-1].Status != domain.TicketStatusWaiting {
+				return workflows.ApplicationError(domain.ErrTicketNotWaiting)
+			}
+			_, err := confirm.Arrival(req.Location)
+			return workflows.ApplicationError(err)
+		},
+	)
+	if err != nil {
+		return domain.QueueReport{}, err
 	}
 
 	// Define GetStatus Query
-	err = workflow.SetQueryHandler(ctx, "GetStatus", func() (domain.Queue, error) {
+	err = workflows.GetStatusQuery.SetHandler(ctx, func() (domain.Queue, error) {
 		return state.Snapshot(), nil
 	})
 	if err != nil {
-		return err
+		return domain.QueueReport{}, err
 	}
 
-	// Keep the workflow running until "Exit" signal is received
-	var exitSignal string
-	workflow.GetSignalChannel(ctx, "Exit").Receive(ctx, &exitSignal)
+	// Keep the workflow running until it is asked to shut down
+	var shutdown workflows.ShutdownRequest
+	selector := workflow.NewSelector(ctx)
+	workflows.AddShutdownToSelector(ctx, selector, func(req workflows.ShutdownRequest) {
+		shutdown = req
+	})
+	selector.Select(ctx)
+	logger.Info("Queue shutting down", "Reason", shutdown.Reason, "RequestID", requestid.FromWorkflow(ctx))
 
-	return nil
+	// No new joins, and let the updates already running finish first
+	state.Closed = true
+	if err := workflow.Await(ctx, func() bool { return workflow.AllHandlersFinished(ctx) }); err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	report, err := workflows.CloseOut(ctx, state, stats, shutdown)
+	if err != nil {
+		return domain.QueueReport{}, err
+	}
+	recordState(ctx)
+	// Shutdowns the system asked for aren't staff changes
+	if shutdown.RequestedBy != "" {
+		who := actor.Actor{UserID: shutdown.RequestedBy, Role: shutdown.Role, BusinessID: businessID}
+		recordAudit(ctx, who, domain.AuditShutdown, "", nil, report)
+	}
+	return report, nil
+}
+
+// updateActor returns who sent the update being handled, or staffID for
+// callers that don't send an actor.
+func updateActor(ctx workflow.Context, staffID string) actor.Actor {
+	who := actor.FromWorkflow(ctx)
+	if who.UserID == "" {
+		who.UserID = staffID
+	}
+	return who
+}
+
+// auditTicket is a ticket and its 1-based place as the audit log records it.
+type auditTicket struct {
+	domain.Ticket
+	Position int    `json:"position"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// auditQueueState is the queue state the audit log records for opening and
+// closing.
+type auditQueueState struct {
+	Closed bool `json:"closed"`
+}
+
+// auditJSON encodes a side of an audit entry, nil staying nil.
+func auditJSON(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	// The sides are plain structs, which always encode
+	raw, _ := json.Marshal(v)
+	return raw
 }
@@ -1,13 +1,18 @@
 package temporal
 
 import (
+	"context"
+	"strings"
 	"testing"
-	//"time"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 
-	//"red-duck/internal/core/domain"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
 )
 
 type BusinessQueueWorkflowTestSuite struct {
@@ -51,13 +56,575 @@ func (s *BusinessQueueWorkflowTestSuite) TestJoinQueue_Success() {
 		s.env.SignalWorkflow("Exit", "ok")
 	}, time.Millisecond*100)
 
-	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1")
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", workflows.QueueOptions{})
 
 	s.True(s.env.IsWorkflowCompleted())
 	s.NoError(s.env.GetWorkflowError())
 }
 */
 
+func (s *BusinessQueueWorkflowTestSuite) TestCallNext_ReturnsTicket() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CallNext, mock.Anything, workflows.CallNextParams{
+		BusinessID: "biz-1",
+		QueueID:    "queue-1",
+		UserID:     "user-1",
+		CounterID:  "Counter 3",
+		Status:     string(domain.TicketStatusReady),
+		PartySize:  1,
+	}).Return(nil).Once()
+	// The call is audited with the ticket either side of it
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.BusinessID == "biz-1" && e.QueueID == "queue-1" && e.Action == domain.AuditCallNext &&
+			e.TargetUserID == "user-1" &&
+			strings.Contains(string(e.Before), `"status":"WAITING"`) &&
+			strings.Contains(string(e.After), `"assignedTo":"Counter 3"`)
+	})).Return(nil).Once()
+
+	var called domain.Ticket
+	var joined workflows.JoinResult
+	var emptyErr error
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow("JoinQueue", "join-1", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("join rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				joined = result.(workflows.JoinResult)
+			},
+		}, domain.JoinRequest{UserID: "user-1", Nonce: "nonce-1"})
+	}, time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateCallNext, "call-1", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("call-next rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				called = result.(domain.Ticket)
+			},
+		}, workflows.CallNextSignal{CounterID: "Counter 3"})
+	}, 2*time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		// Nobody else is waiting: rejected before touching history
+		s.env.UpdateWorkflow(workflows.UpdateCallNext, "call-2", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { emptyErr = err },
+			OnAccept:   func() { s.Fail("call-next on an empty queue accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, workflows.CallNextSignal{CounterID: "Counter 3"})
+		s.env.SignalWorkflow("Exit", "ok")
+	}, 3*time.Millisecond)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", workflows.QueueOptions{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	// The join answers with its own nonce, which a replay of it would get too
+	s.Equal(workflows.JoinResult{Position: 1, WaitMinutes: 5, Nonce: "nonce-1"}, joined)
+	s.Equal("user-1", called.UserID)
+	s.Equal(domain.TicketStatusReady, called.Status)
+	s.Equal("Counter 3", called.AssignedTo)
+	s.ErrorIs(workflows.DomainError(emptyErr), domain.ErrQueueEmpty)
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestSetOpenAndMoveTicket() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	// Closing is published to visibility, with the tickets still counted
+	s.env.OnUpsertTypedSearchAttributes(mock.MatchedBy(func(sa temporal.SearchAttributes) bool {
+		state, _ := sa.GetKeyword(workflows.QueueStateAttribute)
+		length, _ := sa.GetInt64(workflows.QueueLengthAttribute)
+		return state == string(domain.QueueStateClosed) && length == 2
+	})).Return(nil).Once()
+	s.env.OnUpsertTypedSearchAttributes(mock.Anything).Return(nil)
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordTicketChange, mock.Anything, TicketChangeParams{
+		BusinessID: "biz-1", QueueID: "queue-1", UserID: "user-2", StaffID: "staff-1", Action: TicketMoved, Position: 1,
+	}).Return(nil).Once()
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.Action == domain.AuditMoveTicket && e.ActorID == "staff-1" && e.TargetUserID == "user-2" &&
+			strings.Contains(string(e.After), `"position":1`)
+	})).Return(nil).Once()
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.Action == domain.AuditCloseQueue &&
+			string(e.Before) == `{"closed":false}` && string(e.After) == `{"closed":true}`
+	})).Return(nil).Once()
+
+	join := func(userID string) {
+		s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-"+userID, &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("join rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, domain.JoinRequest{UserID: userID})
+	}
+
+	var moved, closed domain.Queue
+	var closedErr, missingErr error
+	s.env.RegisterDelayedCallback(func() {
+		join("user-1")
+		join("user-2")
+	}, time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateMoveTicket, "move-1", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("move rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				moved = result.(domain.Queue)
+			},
+		}, workflows.MoveTicketRequest{UserID: "user-2", Position: 1, StaffID: "staff-1"})
+		s.env.UpdateWorkflow(workflows.UpdateMoveTicket, "move-2", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { missingErr = err },
+			OnAccept:   func() { s.Fail("move of an unknown user accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, workflows.MoveTicketRequest{UserID: "user-9", Position: 1})
+		s.env.UpdateWorkflow(workflows.UpdateSetOpen, "close-1", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("close rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				closed = result.(domain.Queue)
+			},
+		}, false)
+	}, 2*time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-user-3", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { closedErr = err },
+			OnAccept:   func() { s.Fail("join on a closed queue accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, domain.JoinRequest{UserID: "user-3"})
+		s.env.SignalWorkflow("Exit", "ok")
+	}, 3*time.Millisecond)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", workflows.QueueOptions{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.Require().Len(moved.Tickets, 2)
+	s.Equal("user-2", moved.Tickets[0].UserID)
+	s.ErrorIs(workflows.DomainError(missingErr), domain.ErrUserNotFound)
+	s.True(closed.Closed)
+	s.ErrorIs(workflows.DomainError(closedErr), domain.ErrQueueClosed)
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestRemoveAndInsertTicket() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	// The walk-in joins the queue like a guest who joined remotely would
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.MatchedBy(func(p JoinQueueParams) bool {
+		return p.UserID == "walk-in-1" && p.QueueID == "queue-1" && p.PartySize == 3 && p.QueueLength == 2
+	})).Return(nil).Once()
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordTicketChange, mock.Anything, mock.MatchedBy(func(p TicketChangeParams) bool {
+		return p.Action == TicketRemoved && p.UserID == "user-1" && p.Position == 1 && p.StaffID == "staff-1" && p.Reason == "no-show"
+	})).Return(nil).Once()
+	s.env.OnActivity(a.RecordTicketChange, mock.Anything, mock.MatchedBy(func(p TicketChangeParams) bool {
+		return p.Action == TicketInserted && p.UserID == "walk-in-1" && p.Position == 1
+	})).Return(nil).Once()
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.Action == domain.AuditRemoveTicket && e.ActorID == "staff-1" && e.TargetUserID == "user-1" &&
+			strings.Contains(string(e.Before), `"reason":"no-show"`) && string(e.After) == "null"
+	})).Return(nil).Once()
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.Action == domain.AuditInsertTicket && e.TargetUserID == "walk-in-1" &&
+			string(e.Before) == "null" && strings.Contains(string(e.After), `"position":1`)
+	})).Return(nil).Once()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-user-1", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("join rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, domain.JoinRequest{UserID: "user-1"})
+	}, time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-user-2", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("join rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, domain.JoinRequest{UserID: "user-2"})
+	}, 2*time.Millisecond)
+
+	var removed workflows.RemovedTicket
+	var inserted int
+	var duplicateErr, anonymousErr error
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateRemoveTicket, "remove-1", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("remove rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				removed = result.(workflows.RemovedTicket)
+			},
+		}, workflows.RemoveTicketRequest{UserID: "user-1", StaffID: "staff-1", Reason: "no-show"})
+	}, 3*time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateInsertTicket, "insert-1", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("insert rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				inserted = result.(int)
+			},
+		}, workflows.InsertTicketRequest{Ticket: domain.Ticket{UserID: "walk-in-1", PartySize: 3}, Position: 1, StaffID: "staff-1", Joined: true})
+		s.env.UpdateWorkflow(workflows.UpdateInsertTicket, "insert-2", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { duplicateErr = err },
+			OnAccept:   func() { s.Fail("insert of a queued user accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, workflows.InsertTicketRequest{Ticket: domain.Ticket{UserID: "user-2"}})
+		s.env.UpdateWorkflow(workflows.UpdateInsertTicket, "insert-3", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { anonymousErr = err },
+			OnAccept:   func() { s.Fail("insert without a user accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, workflows.InsertTicketRequest{})
+	}, 4*time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(workflows.SignalShutdown, workflows.ShutdownRequest{Reason: "done"})
+	}, 5*time.Millisecond)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", workflows.QueueOptions{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.Equal("user-1", removed.Ticket.UserID)
+	s.Equal(1, removed.Position)
+	s.Equal(1, inserted)
+	s.ErrorIs(workflows.DomainError(duplicateErr), domain.ErrUserAlreadyInQueue)
+	s.ErrorIs(workflows.DomainError(anonymousErr), domain.ErrMissingUserID)
+
+	// The removal is not an abandonment; the walk-in and user-2 are cancelled
+	var report domain.QueueReport
+	s.NoError(s.env.GetWorkflowResult(&report))
+	s.Equal(2, report.Cancelled)
+	s.Equal(2, report.Abandoned)
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestShutdown_ClosesOut() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CallNext, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.MatchedBy(func(p workflows.CancelTicketsParams) bool {
+		return p.Reason == "closing early" && len(p.Tickets) == 1 && p.Tickets[0].UserID == "user-2" &&
+			p.Tickets[0].Status == domain.TicketStatusCancelled
+	})).Return(nil).Once()
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.Action == domain.AuditCallNext
+	})).Return(nil).Once()
+	// Shutting down is audited under the staff member who asked
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.Action == domain.AuditShutdown && e.ActorID == "staff-1" && e.ActorRole == "admin" &&
+			strings.Contains(string(e.After), `"cancelled":1`)
+	})).Return(nil).Once()
+	var saved domain.QueueReport
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(func(_ context.Context, r domain.QueueReport) error {
+		saved = r
+		return nil
+	}).Once()
+
+	for i, userID := range []string{"user-1", "user-2"} {
+		s.env.RegisterDelayedCallback(func() {
+			s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-"+userID, &testsuite.TestUpdateCallback{
+				OnReject:   func(err error) { s.Fail("join rejected", err) },
+				OnAccept:   func() {},
+				OnComplete: func(interface{}, error) {},
+			}, domain.JoinRequest{UserID: userID})
+		}, time.Duration(i+1)*time.Millisecond)
+	}
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateCallNext, "call-1", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("call-next rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, workflows.CallNextSignal{CounterID: "Counter 3"})
+	}, 2*time.Minute)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(workflows.SignalShutdown, workflows.ShutdownRequest{Reason: "closing early", RequestedBy: "staff-1", Role: "admin"})
+	}, 3*time.Minute)
+
+	// Far from the wall clock, so join times taken from it would show
+	s.env.SetStartTime(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", workflows.QueueOptions{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	var report domain.QueueReport
+	s.NoError(s.env.GetWorkflowResult(&report))
+	s.Equal("closing early", report.Reason)
+	s.Equal(1, report.Served)
+	s.Equal(1, report.Abandoned)
+	s.Equal(1, report.Cancelled)
+	// user-1 joined at 1ms in workflow time and was called at 2 minutes
+	s.InDelta(120, report.AvgWaitSeconds, 0.01)
+	s.Equal(saved, report)
+
+	// The final state keeps answering queries
+	res, err := s.env.QueryWorkflow(workflows.QueryGetStatus)
+	s.NoError(err)
+	var q domain.Queue
+	s.NoError(res.Get(&q))
+	s.True(q.Closed)
+	s.Equal(domain.TicketStatusCancelled, q.Tickets[1].Status)
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestConfirm_MissesMoveBackThenExpire() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	var events []string
+	s.env.OnActivity(a.RecordConfirmation, mock.Anything, mock.Anything).Return(func(_ context.Context, p ConfirmationParams) error {
+		events = append(events, p.UserID+" "+p.Event)
+		return nil
+	})
+
+	shop := domain.Location{Latitude: 52.3676, Longitude: 4.9041}
+	opts := workflows.QueueOptions{Confirm: domain.ConfirmPolicy{
+		Ahead:     2,
+		Within:    5 * time.Minute,
+		MoveBack:  1,
+		MaxMisses: 2,
+		Geofence:  &domain.Geofence{Center: shop, RadiusMeters: 100},
+	}}
+
+	for i, userID := range []string{"user-1", "user-2"} {
+		s.env.RegisterDelayedCallback(func() {
+			s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-"+userID, &testsuite.TestUpdateCallback{
+				OnReject:   func(err error) { s.Fail("join rejected", err) },
+				OnAccept:   func() {},
+				OnComplete: func(interface{}, error) {},
+			}, domain.JoinRequest{UserID: userID})
+		}, time.Duration(i+1)*time.Millisecond)
+	}
+	var outsideErr error
+	var confirmed domain.Ticket
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateConfirm, "confirm-1", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { outsideErr = err },
+			OnAccept:   func() { s.Fail("confirmation from across town accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, workflows.ConfirmTicketRequest{UserID: "user-2", Location: &domain.Location{Latitude: 52.3, Longitude: 4.9}})
+		s.env.UpdateWorkflow(workflows.UpdateConfirm, "confirm-2", &testsuite.TestUpdateCallback{
+			OnReject: func(err error) { s.Fail("confirmation rejected", err) },
+			OnAccept: func() {},
+			OnComplete: func(result interface{}, err error) {
+				s.NoError(err)
+				confirmed = result.(domain.Ticket)
+			},
+		}, workflows.ConfirmTicketRequest{UserID: "user-2", Location: &shop})
+	}, time.Minute)
+	var q domain.Queue
+	s.env.RegisterDelayedCallback(func() {
+		res, err := s.env.QueryWorkflow(workflows.QueryGetStatus)
+		s.NoError(err)
+		s.NoError(res.Get(&q))
+		s.env.SignalWorkflow("Exit", "ok")
+	}, 11*time.Minute)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", opts)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.ErrorIs(workflows.DomainError(outsideErr), domain.ErrOutsideGeofence)
+	s.True(confirmed.Confirmed)
+	s.True(confirmed.Arrived)
+	// user-1 drops behind user-2 at the first miss, is asked again, and their
+	// ticket expires at the second
+	s.Equal([]string{
+		"user-1 " + ConfirmRequested,
+		"user-2 " + ConfirmRequested,
+		"user-2 " + TicketConfirmed,
+		"user-1 " + ConfirmMissed,
+		"user-1 " + ConfirmRequested,
+		"user-1 " + TicketExpired,
+	}, events)
+	s.Len(q.Tickets, 1)
+	s.Equal("user-2", q.Tickets[0].UserID)
+
+	// The expired ticket left; user-2 is cancelled by the exit
+	var report domain.QueueReport
+	s.NoError(s.env.GetWorkflowResult(&report))
+	s.Equal(1, report.Cancelled)
+	s.Equal(2, report.Abandoned)
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestConfirm_MoveIntoReachAsks() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordTicketChange, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	var events []string
+	s.env.OnActivity(a.RecordConfirmation, mock.Anything, mock.Anything).Return(func(_ context.Context, p ConfirmationParams) error {
+		events = append(events, p.UserID+" "+p.Event)
+		return nil
+	})
+	opts := workflows.QueueOptions{Confirm: domain.ConfirmPolicy{Ahead: 1, Within: 5 * time.Minute, MoveBack: 1, MaxMisses: 2}}
+
+	for i, userID := range []string{"user-1", "user-2"} {
+		s.env.RegisterDelayedCallback(func() {
+			s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-"+userID, &testsuite.TestUpdateCallback{
+				OnReject:   func(err error) { s.Fail("join rejected", err) },
+				OnAccept:   func() {},
+				OnComplete: func(interface{}, error) {},
+			}, domain.JoinRequest{UserID: userID})
+		}, time.Duration(i+1)*time.Millisecond)
+	}
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateMoveTicket, "move-1", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("move rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, workflows.MoveTicketRequest{UserID: "user-2", Position: 1, StaffID: "staff-1"})
+	}, time.Minute)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow("Exit", "ok")
+	}, 2*time.Minute)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", opts)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	// Moved to the front by staff, user-2 is asked straight away
+	s.Equal([]string{
+		"user-1 " + ConfirmRequested,
+		"user-2 " + ConfirmRequested,
+	}, events)
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestConfirm_UnpublishedAskIsWithdrawn() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	// The first ask never goes out
+	s.env.OnActivity(a.RecordConfirmation, mock.Anything, mock.Anything).
+		Return(temporal.NewNonRetryableApplicationError("nats: connection closed", "publish", nil)).Once()
+	var events []string
+	s.env.OnActivity(a.RecordConfirmation, mock.Anything, mock.Anything).Return(func(_ context.Context, p ConfirmationParams) error {
+		events = append(events, p.UserID+" "+p.Event)
+		return nil
+	})
+	opts := workflows.QueueOptions{Confirm: domain.ConfirmPolicy{Ahead: 1, Within: 5 * time.Minute, MoveBack: 1, MaxMisses: 2}}
+
+	join := func(userID string) {
+		s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-"+userID, &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("join rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, domain.JoinRequest{UserID: userID})
+	}
+	var unasked domain.Queue
+	s.env.RegisterDelayedCallback(func() { join("user-1") }, time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		// Well past the deadline, user-1 was neither moved back nor held to it
+		res, err := s.env.QueryWorkflow(workflows.QueryGetStatus)
+		s.NoError(err)
+		s.NoError(res.Get(&unasked))
+		// The next change asks them again
+		join("user-2")
+	}, 10*time.Minute)
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow("Exit", "ok")
+	}, 11*time.Minute)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", opts)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.Require().Len(unasked.Tickets, 1)
+	s.True(unasked.Tickets[0].ConfirmBy.IsZero())
+	s.Zero(unasked.Tickets[0].Misses)
+	s.Equal([]string{"user-1 " + ConfirmRequested}, events)
+}
+
+func (s *BusinessQueueWorkflowTestSuite) TestCallNext_SeatsFirstPartyThatFits() {
+	var a *QueueActivities
+	s.env.RegisterActivity(a)
+	var joined []JoinQueueParams
+	s.env.OnActivity(a.JoinQueue, mock.Anything, mock.Anything).Return(func(_ context.Context, p JoinQueueParams) error {
+		joined = append(joined, p)
+		return nil
+	})
+	s.env.OnActivity(a.CancelTickets, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.RecordAudit, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.SaveQueueReport, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(a.CallNext, mock.Anything, workflows.CallNextParams{
+		BusinessID: "biz-1",
+		QueueID:    "queue-1",
+		UserID:     "user-2",
+		CounterID:  "Table 2",
+		Status:     string(domain.TicketStatusReady),
+		PartySize:  2,
+	}).Return(nil).Once()
+
+	var results []workflows.JoinResult
+	for i, req := range []domain.JoinRequest{
+		{UserID: "user-1", PartySize: 6},
+		{UserID: "user-2", PartySize: 2},
+	} {
+		s.env.RegisterDelayedCallback(func() {
+			s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-"+req.UserID, &testsuite.TestUpdateCallback{
+				OnReject: func(err error) { s.Fail("join rejected", err) },
+				OnAccept: func() {},
+				OnComplete: func(result interface{}, err error) {
+					s.NoError(err)
+					results = append(results, result.(workflows.JoinResult))
+				},
+			}, req)
+		}, time.Duration(i+1)*time.Millisecond)
+	}
+	var tooBigErr, noFitErr error
+	s.env.RegisterDelayedCallback(func() {
+		s.env.UpdateWorkflow(workflows.UpdateJoinQueue, "join-user-3", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { tooBigErr = err },
+			OnAccept:   func() { s.Fail("oversized party accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, domain.JoinRequest{UserID: "user-3", PartySize: domain.MaxPartySize + 1})
+		s.env.UpdateWorkflow(workflows.UpdateCallNext, "call-1", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { s.Fail("call-next rejected", err) },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, workflows.CallNextSignal{CounterID: "Table 2", Capacity: 2})
+	}, 3*time.Millisecond)
+	s.env.RegisterDelayedCallback(func() {
+		// Only the party of six is left, and it doesn't fit
+		s.env.UpdateWorkflow(workflows.UpdateCallNext, "call-2", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { noFitErr = err },
+			OnAccept:   func() { s.Fail("call-next for a table nobody fits accepted") },
+			OnComplete: func(interface{}, error) {},
+		}, workflows.CallNextSignal{CounterID: "Table 2", Capacity: 2})
+		s.env.SignalWorkflow("Exit", "ok")
+	}, 4*time.Millisecond)
+
+	s.env.ExecuteWorkflow(BusinessQueueWorkflow, "biz-1", "queue-1", workflows.QueueOptions{})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.ErrorIs(workflows.DomainError(tooBigErr), domain.ErrInvalidPartySize)
+	s.ErrorIs(workflows.DomainError(noFitErr), domain.ErrNoFittingParty)
+	// The party of two waits behind the six-top's service time as well as its own
+	s.Require().Len(joined, 2)
+	s.Equal(6, joined[0].PartySize)
+	s.Equal(15+7, joined[1].WaitTimeMinutes)
+	// and is told so
+	s.Require().Len(results, 2)
+	s.Equal(15+7, results[1].WaitMinutes)
+}
+
 func TestBusinessQueueWorkflowTestSuite(t *testing.T) {
 	suite.Run(t, new(BusinessQueueWorkflowTestSuite))
 }
@@ -2,67 +2,186 @@ package temporal
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"time"
+
+	"go.temporal.io/sdk/activity"
 
 	"red-duck/analytics"
+	"red-duck/internal/core/domain"
+	"red-duck/internal/core/ports"
 	"red-duck/internal/workflows"
 )
 
 type QueueActivities struct {
 	Tracker analytics.EventTracker
+	Reports ports.QueueReportRepository
+	Audit   ports.AuditLogRepository
 }
 
 type JoinQueueParams struct {
 	BusinessID      string
+	QueueID         string
 	UserID          string
 	QueueLength     int
 	WaitTimeMinutes int
+	PartySize       int
 }
 
 func (a *QueueActivities) JoinQueue(ctx context.Context, params JoinQueueParams) error {
 	// Simulate Database Transaction
 	// In a real application, we would save the JoinRequest to the database here.
 	// Since no database code was found, we simulate success.
-	log.Printf("Simulating DB transaction for JoinQueue businessID=%s userID=%s", params.BusinessID, params.UserID)
+	slog.InfoContext(ctx, "Simulating DB transaction for JoinQueue", "business_id", params.BusinessID, "user_id", params.UserID)
 
 	// Publish Event to NATS via Tracker
 	props := map[string]interface{}{
+		"queue_id":       params.QueueID,
 		"queue_length":   params.QueueLength,
 		"estimated_wait": params.WaitTimeMinutes,
+		"party_size":     params.PartySize,
 	}
 
 	// Fire and forget tracking
-	a.Tracker.Track("queue.joined", params.BusinessID, params.UserID, props)
+	a.Tracker.Track(ctx, "queue.joined", params.BusinessID, params.UserID, props)
 	return nil
 }
 
 func (a *QueueActivities) LeaveQueue(ctx context.Context, params JoinQueueParams) error {
 	// Simulate Database Transaction
-	log.Printf("Simulating DB transaction for LeaveQueue businessID=%s userID=%s", params.BusinessID, params.UserID)
+	slog.InfoContext(ctx, "Simulating DB transaction for LeaveQueue", "business_id", params.BusinessID, "user_id", params.UserID)
 
 	// Publish Event via Tracker
 	props := map[string]interface{}{
+		"queue_id":     params.QueueID,
 		"reason":       "user_quit",
 		"queue_length": params.QueueLength, // Optional context
+		"party_size":   params.PartySize,
 	}
 
 	// Fire and forget tracking
-	a.Tracker.Track("queue.left", params.BusinessID, params.UserID, props)
+	a.Tracker.Track(ctx, "queue.left", params.BusinessID, params.UserID, props)
 	return nil
 }
 
 func (a *QueueActivities) CallNext(ctx context.Context, params workflows.CallNextParams) error {
 	// Simulate Database Transaction
-	log.Printf("Simulating DB transaction for CallNext businessID=%s userID=%s counter=%s", params.BusinessID, params.UserID, params.CounterID)
+	slog.InfoContext(ctx, "Simulating DB transaction for CallNext", "business_id", params.BusinessID, "user_id", params.UserID, "counter_id", params.CounterID)
 
 	// Publish Event via Tracker
 	props := map[string]interface{}{
+		"queue_id":    params.QueueID,
 		"status":      params.Status,
 		"instruction": "Go to " + params.CounterID,
 		"counter_id":  params.CounterID,
+		"party_size":  params.PartySize,
 	}
 
 	// Fire and forget tracking
-	a.Tracker.Track("queue.called", params.BusinessID, params.UserID, props)
+	a.Tracker.Track(ctx, "queue.called", params.BusinessID, params.UserID, props)
+	return nil
+}
+
+// CancelTickets notifies each guest still waiting when their queue shut down.
+func (a *QueueActivities) CancelTickets(ctx context.Context, params workflows.CancelTicketsParams) error {
+	for _, t := range params.Tickets {
+		props := map[string]interface{}{
+			"queue_id":   params.QueueID,
+			"reason":     params.Reason,
+			"joined_at":  t.JoinedAt,
+			"party_size": t.Guests(),
+		}
+		a.Tracker.Track(ctx, "queue.cancelled", params.BusinessID, t.UserID, props)
+	}
+	slog.InfoContext(ctx, "Cancelled waiting tickets", "business_id", params.BusinessID, "queue_id", params.QueueID, "count", len(params.Tickets))
 	return nil
 }
+
+// SaveQueueReport persists the final report of a queue.
+func (a *QueueActivities) SaveQueueReport(ctx context.Context, report domain.QueueReport) error {
+	return a.Reports.SaveQueueReport(ctx, report)
+}
+
+// Staff changes to tickets, recorded as queue.ticket_<action> events.
+const (
+	TicketMoved    = "moved"
+	TicketRemoved  = "removed"
+	TicketInserted = "inserted"
+)
+
+// TicketChangeParams describes a change staff made to a ticket.
+type TicketChangeParams struct {
+	BusinessID string
+	QueueID    string
+	UserID     string
+	StaffID    string
+	Action     string
+	// Position is the ticket's 1-based place after the change, or before it
+	// for removals.
+	Position int
+	Reason   string
+}
+
+// RecordTicketChange publishes a staff change as a ticket event attributed
+// to the guest whose ticket changed. The audit log is RecordAudit's.
+func (a *QueueActivities) RecordTicketChange(ctx context.Context, params TicketChangeParams) error {
+	props := map[string]interface{}{
+		"queue_id": params.QueueID,
+		"staff_id": params.StaffID,
+		"position": params.Position,
+	}
+	if params.Reason != "" {
+		props["reason"] = params.Reason
+	}
+
+	a.Tracker.Track(ctx, "queue.ticket_"+params.Action, params.BusinessID, params.UserID, props)
+	return nil
+}
+
+// RecordAudit appends a staff change to the business's audit log. The entry
+// is keyed by the activity, so a retry doesn't append it twice.
+func (a *QueueActivities) RecordAudit(ctx context.Context, entry domain.AuditEntry) error {
+	info := activity.GetInfo(ctx)
+	_, err := a.Audit.AppendAuditEntry(ctx, info.WorkflowExecution.RunID+"/"+info.ActivityID, entry)
+	return err
+}
+
+// Steps of a remote guest's confirmation, recorded as queue.<event> events.
+const (
+	ConfirmRequested = "confirm_requested"
+	TicketConfirmed  = "confirmed"
+	ConfirmMissed    = "confirm_missed"
+	TicketExpired    = "ticket_expired"
+)
+
+// ConfirmationParams describes a step of a remote guest's confirmation.
+type ConfirmationParams struct {
+	BusinessID string
+	QueueID    string
+	UserID     string
+	Event      string
+	// ConfirmBy is the deadline the guest was given, when asked.
+	ConfirmBy time.Time
+	// Position is the ticket's 1-based place after the step, or before it
+	// when the ticket expired.
+	Position int
+	Arrived  bool
+}
+
+// RecordConfirmation publishes a step of a remote guest's confirmation. The
+// confirm_requested event is how the guest is asked: whoever consumes the
+// events notifies them, so a failed publish fails the activity to be retried.
+func (a *QueueActivities) RecordConfirmation(ctx context.Context, params ConfirmationParams) error {
+	props := map[string]interface{}{
+		"queue_id": params.QueueID,
+		"position": params.Position,
+	}
+	if !params.ConfirmBy.IsZero() {
+		props["confirm_by"] = params.ConfirmBy
+	}
+	if params.Arrived {
+		props["arrived"] = true
+	}
+
+	return a.Tracker.Track(ctx, "queue."+params.Event, params.BusinessID, params.UserID, props)
+}
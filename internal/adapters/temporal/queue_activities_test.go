@@ -2,10 +2,17 @@ package temporal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
 )
 
 // MockEventTracker struct
@@ -13,7 +20,7 @@ type MockEventTracker struct {
 	mock.Mock
 }
 
-func (m *MockEventTracker) Track(eventType, businessID, userID string, props map[string]interface{}) error {
+func (m *MockEventTracker) Track(ctx context.Context, eventType, businessID, userID string, props map[string]interface{}) error {
 	args := m.Called(eventType, businessID, userID, props)
 	return args.Error(0)
 }
@@ -40,3 +47,75 @@ func TestJoinQueue_TracksEvent(t *testing.T) {
 	assert.NoError(t, err)
 	mockTracker.AssertExpectations(t)
 }
+
+func TestCancelTickets_TracksEventPerTicket(t *testing.T) {
+	mockTracker := new(MockEventTracker)
+	activities := &QueueActivities{Tracker: mockTracker}
+
+	mockTracker.On("Track", "queue.cancelled", "biz_123", "user_1", mock.MatchedBy(func(props map[string]interface{}) bool {
+		return props["queue_id"] == "main" && props["reason"] == "closing early"
+	})).Return(nil).Once()
+	mockTracker.On("Track", "queue.cancelled", "biz_123", "user_2", mock.Anything).Return(nil).Once()
+
+	err := activities.CancelTickets(context.Background(), workflows.CancelTicketsParams{
+		BusinessID: "biz_123",
+		QueueID:    "main",
+		Reason:     "closing early",
+		Tickets:    []domain.Ticket{{UserID: "user_1"}, {UserID: "user_2"}},
+	})
+
+	assert.NoError(t, err)
+	mockTracker.AssertExpectations(t)
+}
+
+func TestRecordConfirmation_FailsWhenNotPublished(t *testing.T) {
+	mockTracker := new(MockEventTracker)
+	activities := &QueueActivities{Tracker: mockTracker}
+	mockTracker.On("Track", "queue.confirm_requested", "biz_123", "user_1", mock.Anything).Return(errors.New("nats: connection closed"))
+
+	// The event is the ask, so it is retried rather than dropped
+	err := activities.RecordConfirmation(context.Background(), ConfirmationParams{
+		BusinessID: "biz_123",
+		QueueID:    "main",
+		UserID:     "user_1",
+		Event:      ConfirmRequested,
+	})
+
+	assert.Error(t, err)
+	mockTracker.AssertExpectations(t)
+}
+
+type mockAuditLog struct {
+	mock.Mock
+}
+
+func (m *mockAuditLog) AppendAuditEntry(ctx context.Context, key string, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	args := m.Called(key, entry)
+	return args.Get(0).(domain.AuditEntry), args.Error(1)
+}
+
+func (m *mockAuditLog) ListAuditEntries(ctx context.Context, q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	args := m.Called(q)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+func TestRecordAudit_KeysEntryByActivity(t *testing.T) {
+	audit := new(mockAuditLog)
+	entry := domain.AuditEntry{
+		BusinessID: "biz_123", QueueID: "main", ActorID: "staff-1", Action: domain.AuditCloseQueue,
+		Before: json.RawMessage(`{"closed":false}`), After: json.RawMessage(`{"closed":true}`),
+	}
+	audit.On("AppendAuditEntry", mock.MatchedBy(func(key string) bool {
+		// The run and activity IDs: the same on every retry of the activity
+		return strings.Count(key, "/") == 1 && !strings.HasPrefix(key, "/") && !strings.HasSuffix(key, "/")
+	}), entry).Return(entry, nil).Once()
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestActivityEnvironment()
+	activities := &QueueActivities{Audit: audit}
+	env.RegisterActivity(activities)
+	_, err := env.ExecuteActivity(activities.RecordAudit, entry)
+
+	assert.NoError(t, err)
+	audit.AssertExpectations(t)
+}
@@ -0,0 +1,147 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/requestid"
+	"red-duck/internal/workflows"
+)
+
+// TransferTicketWorkflow moves a guest's ticket between two queues of a
+// business as a saga: it removes the ticket from the source queue, then
+// inserts it into the target queue and returns its position there. When the
+// insert fails the ticket is put back where it was, and the insert's error is
+// returned.
+func TransferTicketWorkflow(ctx workflow.Context, req domain.TicketTransfer) (int, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("TransferTicketWorkflow started", "UserID", req.UserID, "From", req.FromQueueID, "To", req.ToQueueID, "RequestID", requestid.FromWorkflow(ctx))
+
+	from := workflows.QueueWorkflowID(req.BusinessID, req.FromQueueID)
+	to := workflows.QueueWorkflowID(req.BusinessID, req.ToQueueID)
+
+	// The steps give up after a minute so the caller gets an answer; domain
+	// errors are not retried at all
+	stepCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout:    10 * time.Second,
+		ScheduleToCloseTimeout: time.Minute,
+	})
+	var a *TransferActivities
+
+	var removed workflows.RemovedTicket
+	err := workflow.ExecuteActivity(stepCtx, a.RemoveTicket, RemoveTicketParams{
+		WorkflowID: from,
+		Request: workflows.RemoveTicketRequest{
+			UserID:  req.UserID,
+			StaffID: req.StaffID,
+			Reason:  "transferred to " + req.ToQueueID,
+		},
+	}).Get(ctx, &removed)
+	if err != nil {
+		return 0, err
+	}
+
+	// The guest waits again in the target queue, as the same party with the
+	// same priority, and starts over there unless their join time is kept
+	ticket := removed.Ticket
+	ticket.Status = domain.TicketStatusWaiting
+	ticket.AssignedTo = ""
+	ticket.ConfirmBy = time.Time{}
+	ticket.Skips = 0
+	if !req.KeepJoinTime {
+		ticket.JoinedAt = time.Time{}
+	}
+	var position int
+	err = workflow.ExecuteActivity(stepCtx, a.InsertTicket, InsertTicketParams{
+		WorkflowID: to,
+		Request: workflows.InsertTicketRequest{
+			Ticket:   ticket,
+			Position: req.Position,
+			StaffID:  req.StaffID,
+			Reason:   "transferred from " + req.FromQueueID,
+		},
+	}).Get(ctx, &position)
+	if err == nil {
+		logger.Info("Ticket transferred", "UserID", req.UserID, "Position", position)
+		return position, nil
+	}
+
+	// Compensate: the guest must not lose their place, so putting the ticket
+	// back is retried until it succeeds or the source queue rejects it
+	logger.Warn("Transfer failed, returning ticket to its queue", "UserID", req.UserID, "Error", err)
+	compensateCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+	})
+	restore := InsertTicketParams{
+		WorkflowID: from,
+		Request: workflows.InsertTicketRequest{
+			Ticket:   removed.Ticket,
+			Position: removed.Position,
+			StaffID:  req.StaffID,
+			Reason:   "transfer to " + req.ToQueueID + " failed",
+		},
+	}
+	if restoreErr := workflow.ExecuteActivity(compensateCtx, a.InsertTicket, restore).Get(ctx, nil); restoreErr != nil {
+		logger.Error("Failed to return ticket to its queue", "UserID", req.UserID, "Error", restoreErr)
+	}
+	return 0, err
+}
+
+// TransferActivities send the updates of a transfer to the queue workflows.
+type TransferActivities struct {
+	Client client.Client
+}
+
+// RemoveTicketParams removes a ticket from the queue run by WorkflowID.
+type RemoveTicketParams struct {
+	WorkflowID string
+	Request    workflows.RemoveTicketRequest
+}
+
+// InsertTicketParams inserts a ticket into the queue run by WorkflowID.
+type InsertTicketParams struct {
+	WorkflowID string
+	Request    workflows.InsertTicketRequest
+}
+
+// RemoveTicket sends RemoveTicketUpdate. A retried attempt reattaches to the
+// update of the first, so the ticket is removed once.
+func (a *TransferActivities) RemoveTicket(ctx context.Context, params RemoveTicketParams) (workflows.RemovedTicket, error) {
+	removed, err := workflows.RemoveTicketUpdate.ExecuteWithID(ctx, a.Client, params.WorkflowID, activityUpdateID(ctx), params.Request)
+	return removed, updateError(err)
+}
+
+// InsertTicket sends InsertTicketUpdate. A retried attempt reattaches to the
+// update of the first, so the ticket is inserted once.
+func (a *TransferActivities) InsertTicket(ctx context.Context, params InsertTicketParams) (int, error) {
+	position, err := workflows.InsertTicketUpdate.ExecuteWithID(ctx, a.Client, params.WorkflowID, activityUpdateID(ctx), params.Request)
+	return position, updateError(err)
+}
+
+// activityUpdateID names the update sent by the running activity, the same
+// for all its attempts.
+func activityUpdateID(ctx context.Context) string {
+	info := activity.GetInfo(ctx)
+	return fmt.Sprintf("%s-%s", info.WorkflowExecution.RunID, info.ActivityID)
+}
+
+// updateError makes the domain error behind a failed update, or the queue
+// workflow not existing, a non-retryable failure of the activity.
+func updateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return workflows.ApplicationError(domain.ErrQueueNotFound)
+	}
+	return workflows.ApplicationError(workflows.DomainError(err))
+}
@@ -0,0 +1,133 @@
+package temporal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/workflows"
+)
+
+func TestTransferTicketWorkflow(t *testing.T) {
+	joinedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	transfer := domain.TicketTransfer{
+		BusinessID:   "biz-1",
+		FromQueueID:  "front-desk",
+		ToQueueID:    "pharmacy",
+		UserID:       "guest-1",
+		Position:     1,
+		KeepJoinTime: true,
+		StaffID:      "staff-1",
+	}
+	removed := workflows.RemovedTicket{
+		Ticket: domain.Ticket{
+			UserID: "guest-1", Status: domain.TicketStatusReady, AssignedTo: "Counter 2", JoinedAt: joinedAt,
+			Remote: true, ConfirmBy: joinedAt.Add(time.Hour), PartySize: 4, Skips: 2,
+		},
+		Position: 3,
+	}
+
+	t.Run("Moves the ticket, keeping its join time", func(t *testing.T) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		var a *TransferActivities
+		env.RegisterActivity(a)
+		env.OnActivity(a.RemoveTicket, mock.Anything, RemoveTicketParams{
+			WorkflowID: "biz-1:front-desk",
+			Request:    workflows.RemoveTicketRequest{UserID: "guest-1", StaffID: "staff-1", Reason: "transferred to pharmacy"},
+		}).Return(removed, nil)
+		// The party waits again in the new queue
+		env.OnActivity(a.InsertTicket, mock.Anything, InsertTicketParams{
+			WorkflowID: "biz-1:pharmacy",
+			Request: workflows.InsertTicketRequest{
+				Ticket:   domain.Ticket{UserID: "guest-1", Status: domain.TicketStatusWaiting, JoinedAt: joinedAt, Remote: true, PartySize: 4},
+				Position: 1,
+				StaffID:  "staff-1",
+				Reason:   "transferred from front-desk",
+			},
+		}).Return(1, nil).Once()
+
+		env.ExecuteWorkflow(TransferTicketWorkflow, transfer)
+
+		assert.NoError(t, env.GetWorkflowError())
+		var position int
+		assert.NoError(t, env.GetWorkflowResult(&position))
+		assert.Equal(t, 1, position)
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Starts the guest over without their join time", func(t *testing.T) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		var a *TransferActivities
+		env.RegisterActivity(a)
+		env.OnActivity(a.RemoveTicket, mock.Anything, mock.Anything).Return(removed, nil)
+		env.OnActivity(a.InsertTicket, mock.Anything, mock.MatchedBy(func(p InsertTicketParams) bool {
+			return p.Request.Ticket.JoinedAt.IsZero() && p.Request.Ticket.PartySize == 4
+		})).Return(2, nil).Once()
+
+		restart := transfer
+		restart.KeepJoinTime = false
+		env.ExecuteWorkflow(TransferTicketWorkflow, restart)
+
+		assert.NoError(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Keeps a checked-in appointment ahead of walk-ins", func(t *testing.T) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		var a *TransferActivities
+		env.RegisterActivity(a)
+		appointment := removed
+		appointment.Ticket.Priority = domain.PriorityAppointment
+		env.OnActivity(a.RemoveTicket, mock.Anything, mock.Anything).Return(appointment, nil)
+		env.OnActivity(a.InsertTicket, mock.Anything, mock.MatchedBy(func(p InsertTicketParams) bool {
+			return p.Request.Ticket.Priority == domain.PriorityAppointment && p.Request.Position == 0
+		})).Return(1, nil).Once()
+
+		anywhere := transfer
+		anywhere.Position = 0
+		env.ExecuteWorkflow(TransferTicketWorkflow, anywhere)
+
+		assert.NoError(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Returns the ticket to its place when the target refuses it", func(t *testing.T) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		var a *TransferActivities
+		env.RegisterActivity(a)
+		env.OnActivity(a.RemoveTicket, mock.Anything, mock.Anything).Return(removed, nil)
+		env.OnActivity(a.InsertTicket, mock.Anything, mock.MatchedBy(func(p InsertTicketParams) bool {
+			return p.WorkflowID == "biz-1:pharmacy"
+		})).Return(0, workflows.ApplicationError(domain.ErrQueueNotFound)).Once()
+		env.OnActivity(a.InsertTicket, mock.Anything, mock.MatchedBy(func(p InsertTicketParams) bool {
+			return p.WorkflowID == "biz-1:front-desk" && p.Request.Ticket == removed.Ticket && p.Request.Position == 3
+		})).Return(3, nil).Once()
+
+		env.ExecuteWorkflow(TransferTicketWorkflow, transfer)
+
+		assert.ErrorIs(t, workflows.DomainError(env.GetWorkflowError()), domain.ErrQueueNotFound)
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Leaves the queues alone when the ticket can't be removed", func(t *testing.T) {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		var a *TransferActivities
+		env.RegisterActivity(a)
+		env.OnActivity(a.RemoveTicket, mock.Anything, mock.Anything).
+			Return(workflows.RemovedTicket{}, workflows.ApplicationError(domain.ErrUserNotFound))
+
+		env.ExecuteWorkflow(TransferTicketWorkflow, transfer)
+
+		assert.ErrorIs(t, workflows.DomainError(env.GetWorkflowError()), domain.ErrUserNotFound)
+		env.AssertNotCalled(t, "InsertTicket", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+
+	"red-duck/internal/adapters/config"
+)
+
+// useStdoutTracing installs a global provider writing to the returned buffer.
+// Call flush before reading it.
+func useStdoutTracing(t *testing.T) (buf *bytes.Buffer, flush func()) {
+	buf = &bytes.Buffer{}
+	exporter, err := NewStdoutExporter(buf)
+	require.NoError(t, err)
+	tp := NewTracerProvider(exporter, "test")
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return buf, func() { require.NoError(t, tp.ForceFlush(context.Background())) }
+}
+
+func TestSetup_RejectsUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), config.TracingConfig{Exporter: "zipkin"}, "test")
+	assert.Error(t, err)
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterNone}, "test")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestMiddleware_NamesSpanAfterRouteAndContinuesTrace(t *testing.T) {
+	buf, flush := useStdoutTracing(t)
+
+	var handlerTraceID trace.TraceID
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /exports/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerTraceID = trace.SpanContextFromContext(r.Context()).TraceID()
+	})
+
+	req := httptest.NewRequest("GET", "/exports/job-1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	Middleware(mux).ServeHTTP(httptest.NewRecorder(), req)
+	flush()
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerTraceID.String())
+	assert.Contains(t, buf.String(), `"Name":"GET /exports/{id}"`)
+}
+
+func tracedWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Second})
+	return workflow.ExecuteActivity(ctx, tracedActivity).Get(ctx, nil)
+}
+
+func tracedActivity(ctx context.Context) error {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return errors.New("activity context carries no span")
+	}
+	return nil
+}
+
+func TestTemporalInterceptor_TracesWorkflowAndActivity(t *testing.T) {
+	buf, flush := useStdoutTracing(t)
+
+	i, err := TemporalInterceptor()
+	require.NoError(t, err)
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{i}})
+	env.RegisterActivity(tracedActivity)
+	env.ExecuteWorkflow(tracedWorkflow)
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	flush()
+
+	assert.Contains(t, buf.String(), "RunWorkflow:tracedWorkflow")
+	assert.Contains(t, buf.String(), "RunActivity:tracedActivity")
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidSlot         = errors.New("invalid appointment slot")
+	ErrSlotUnavailable     = errors.New("appointment slot fully booked")
+	ErrAppointmentNotFound = errors.New("appointment not found")
+	ErrCheckInNotOpen      = errors.New("check-in not open yet")
+	ErrAppointmentClosed   = errors.New("appointment no longer booked")
+)
+
+// Ticket priorities. Higher priorities are inserted ahead of waiting tickets
+// of lower ones.
+const (
+	PriorityWalkIn      = 0
+	PriorityAppointment = 1
+)
+
+type AppointmentStatus string
+
+const (
+	AppointmentBooked    AppointmentStatus = "BOOKED"
+	AppointmentCheckedIn AppointmentStatus = "CHECKED_IN"
+	AppointmentCancelled AppointmentStatus = "CANCELLED"
+	// AppointmentNoShow appointments were not checked in by their NoShowAt.
+	AppointmentNoShow AppointmentStatus = "NO_SHOW"
+)
+
+// Appointment is a guest's seat in a slot of a queue. On check-in the guest
+// gets a ticket in the queue, ahead of the walk-ins waiting there.
+type Appointment struct {
+	ID         string            `json:"appointment_id"`
+	BusinessID string            `json:"business_id"`
+	QueueID    string            `json:"queue_id"`
+	UserID     string            `json:"user_id"`
+	SlotStart  time.Time         `json:"slot_start"`
+	Seat       int               `json:"seat"`
+	Status     AppointmentStatus `json:"status"`
+	// CheckInFrom and NoShowAt bound when the guest can check in. The seat is
+	// released at NoShowAt.
+	CheckInFrom time.Time `json:"check_in_from"`
+	NoShowAt    time.Time `json:"no_show_at"`
+	CheckedInAt time.Time `json:"checked_in_at,omitzero"`
+	// Position is the 1-based place the ticket got in the queue on check-in.
+	Position int `json:"position,omitempty"`
+}
+
+// AppointmentID names the seat of the slot starting at slotStart.
+func AppointmentID(slotStart time.Time, seat int) string {
+	return fmt.Sprintf("%s-%d", slotStart.UTC().Format("20060102T1504Z"), seat)
+}
+
+// CanCheckIn reports why the appointment can't be checked in at now, if it can't.
+func (a *Appointment) CanCheckIn(now time.Time) error {
+	if a.Status != AppointmentBooked || !now.Before(a.NoShowAt) {
+		return ErrAppointmentClosed
+	}
+	if now.Before(a.CheckInFrom) {
+		return ErrCheckInNotOpen
+	}
+	return nil
+}
+
+// CheckIn records that the guest got a ticket at position.
+func (a *Appointment) CheckIn(now time.Time, position int) {
+	a.Status = AppointmentCheckedIn
+	a.CheckedInAt = now
+	a.Position = position
+}
+
+// Cancel gives the seat back. Only booked appointments can be cancelled:
+// once checked in, the guest leaves the queue instead.
+func (a *Appointment) Cancel() error {
+	if a.Status != AppointmentBooked {
+		return ErrAppointmentClosed
+	}
+	a.Status = AppointmentCancelled
+	return nil
+}
+
+// AppointmentPolicy is how a business takes appointments.
+type AppointmentPolicy struct {
+	// SlotLength divides the day into slots, starting at midnight UTC.
+	SlotLength time.Duration
+	// Capacity is how many guests can book the same slot.
+	Capacity int
+	// CheckInEarly is how long before its slot an appointment can check in.
+	CheckInEarly time.Duration
+	// NoShowAfter is how long after its slot starts an appointment that
+	// hasn't checked in is a no-show.
+	NoShowAfter time.Duration
+	// Reminders are sent this long before the slot.
+	Reminders []time.Duration
+	// Horizon is how far ahead slots can be booked.
+	Horizon time.Duration
+}
+
+// Book returns the appointment of userID in the slot starting at slotStart,
+// without a seat. The slot must be on the grid, not yet past its no-show
+// deadline and within the horizon.
+func (p AppointmentPolicy) Book(businessID, queueID, userID string, slotStart, now time.Time) (Appointment, error) {
+	slotStart = slotStart.UTC()
+	if p.SlotLength <= 0 || !slotStart.Truncate(p.SlotLength).Equal(slotStart) {
+		return Appointment{}, fmt.Errorf("%w: slots start every %s", ErrInvalidSlot, p.SlotLength)
+	}
+	noShowAt := slotStart.Add(p.NoShowAfter)
+	if !now.Before(noShowAt) {
+		return Appointment{}, fmt.Errorf("%w: slot is over", ErrInvalidSlot)
+	}
+	if p.Horizon > 0 && slotStart.After(now.Add(p.Horizon)) {
+		return Appointment{}, fmt.Errorf("%w: slots can be booked up to %s ahead", ErrInvalidSlot, p.Horizon)
+	}
+	return Appointment{
+		BusinessID:  businessID,
+		QueueID:     queueID,
+		UserID:      userID,
+		SlotStart:   slotStart,
+		Status:      AppointmentBooked,
+		CheckInFrom: slotStart.Add(-p.CheckInEarly),
+		NoShowAt:    noShowAt,
+	}, nil
+}
+
+// ReminderTimes returns when to remind the guest of a. Reminders already due
+// at now are skipped. The times are in order.
+func (p AppointmentPolicy) ReminderTimes(a Appointment, now time.Time) []time.Time {
+	var times []time.Time
+	for _, before := range p.Reminders {
+		if at := a.SlotStart.Add(-before); at.After(now) {
+			times = append(times, at)
+		}
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	return slices.CompactFunc(times, time.Time.Equal)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAppointmentPolicy_Book(t *testing.T) {
+	policy := AppointmentPolicy{
+		SlotLength:   15 * time.Minute,
+		Capacity:     2,
+		CheckInEarly: 30 * time.Minute,
+		NoShowAfter:  10 * time.Minute,
+		Horizon:      14 * 24 * time.Hour,
+	}
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	slot := time.Date(2026, 3, 2, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	a, err := policy.Book("biz1", "q1", "guest-1", slot, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !a.SlotStart.Equal(slot) || a.SlotStart.Location() != time.UTC {
+		t.Errorf("expected slot in UTC, got %v", a.SlotStart)
+	}
+	if a.Status != AppointmentBooked || a.UserID != "guest-1" {
+		t.Errorf("expected booked appointment of guest-1, got %+v", a)
+	}
+	if !a.CheckInFrom.Equal(slot.Add(-30*time.Minute)) || !a.NoShowAt.Equal(slot.Add(10*time.Minute)) {
+		t.Errorf("unexpected check-in window %v - %v", a.CheckInFrom, a.NoShowAt)
+	}
+	if id := AppointmentID(a.SlotStart, 2); id != "20260302T0930Z-2" {
+		t.Errorf("unexpected appointment ID %s", id)
+	}
+
+	invalid := map[string]time.Time{
+		"off the grid":       slot.Add(5 * time.Minute),
+		"past its no-show":   now.Add(-15 * time.Minute),
+		"beyond the horizon": now.Add(15 * 24 * time.Hour),
+	}
+	for name, slot := range invalid {
+		if _, err := policy.Book("biz1", "q1", "guest-1", slot, now); !errors.Is(err, ErrInvalidSlot) {
+			t.Errorf("%s: expected ErrInvalidSlot, got %v", name, err)
+		}
+	}
+
+	// A slot that started can be booked until its no-show deadline
+	if _, err := policy.Book("biz1", "q1", "guest-1", now, now.Add(5*time.Minute)); err != nil {
+		t.Errorf("expected the current slot to be bookable, got %v", err)
+	}
+}
+
+func TestAppointmentPolicy_ReminderTimes(t *testing.T) {
+	policy := AppointmentPolicy{Reminders: []time.Duration{time.Hour, 24 * time.Hour, time.Hour}}
+	slot := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	a := Appointment{SlotStart: slot}
+
+	times := policy.ReminderTimes(a, slot.Add(-48*time.Hour))
+	if len(times) != 2 || !times[0].Equal(slot.Add(-24*time.Hour)) || !times[1].Equal(slot.Add(-time.Hour)) {
+		t.Errorf("expected reminders a day and an hour ahead, got %v", times)
+	}
+	// Reminders already due are skipped
+	if times := policy.ReminderTimes(a, slot.Add(-2*time.Hour)); len(times) != 1 {
+		t.Errorf("expected one reminder, got %v", times)
+	}
+}
+
+func TestAppointment_CheckIn(t *testing.T) {
+	slot := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	a := Appointment{Status: AppointmentBooked, SlotStart: slot, CheckInFrom: slot.Add(-30 * time.Minute), NoShowAt: slot.Add(10 * time.Minute)}
+
+	if err := a.CanCheckIn(slot.Add(-time.Hour)); err != ErrCheckInNotOpen {
+		t.Errorf("expected ErrCheckInNotOpen, got %v", err)
+	}
+	if err := a.CanCheckIn(a.NoShowAt); err != ErrAppointmentClosed {
+		t.Errorf("expected ErrAppointmentClosed at the no-show deadline, got %v", err)
+	}
+	if err := a.CanCheckIn(slot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a.CheckIn(slot, 2)
+	if a.Status != AppointmentCheckedIn || a.Position != 2 || !a.CheckedInAt.Equal(slot) {
+		t.Errorf("expected checked-in appointment at pos 2, got %+v", a)
+	}
+	if err := a.CanCheckIn(slot); err != ErrAppointmentClosed {
+		t.Errorf("expected ErrAppointmentClosed, got %v", err)
+	}
+	if err := a.Cancel(); err != ErrAppointmentClosed {
+		t.Errorf("expected checked-in appointments not to cancel, got %v", err)
+	}
+}
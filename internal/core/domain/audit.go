@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditCallNext     = "call_next"
+	AuditMoveTicket   = "move_ticket"
+	AuditRemoveTicket = "remove_ticket"
+	AuditInsertTicket = "insert_ticket"
+	AuditOpenQueue    = "open_queue"
+	AuditCloseQueue   = "close_queue"
+	AuditShutdown     = "shutdown_queue"
+)
+
+// ErrAuditChainBroken reports an audit entry that was changed, removed or
+// reordered after it was written.
+var ErrAuditChainBroken = errors.New("audit log chain is broken")
+
+// AuditEntry records a change staff made to a queue. Entries of a business
+// form a hash chain: each one's Hash covers its content and the Hash of the
+// entry before it, so editing or deleting an entry breaks every later one.
+type AuditEntry struct {
+	ID         int64  `json:"id"`
+	BusinessID string `json:"business_id"`
+	QueueID    string `json:"queue_id"`
+	// ActorID and ActorRole identify the staff member from their token.
+	ActorID      string `json:"actor_id"`
+	ActorRole    string `json:"actor_role"`
+	Action       string `json:"action"`
+	TargetUserID string `json:"target_user_id,omitempty"`
+	// Before and After are the target ticket, or the queue state, either
+	// side of the change. Either is null when there is no such side.
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	RequestID string          `json:"request_id,omitempty"`
+	At        time.Time       `json:"at"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+}
+
+// AuditQuery selects entries of a business's audit log. Empty fields match
+// everything; From is inclusive and To exclusive. AfterID pages through the
+// log: only entries with a greater ID are returned, at most Limit of them.
+type AuditQuery struct {
+	BusinessID string
+	QueueID    string
+	ActorID    string
+	From       time.Time
+	To         time.Time
+	AfterID    int64
+	Limit      int
+}
+
+// Filtered reports whether q skips entries of the log, so the entries it
+// returns don't link to each other.
+func (q AuditQuery) Filtered() bool {
+	return q.QueueID != "" || q.ActorID != "" || !q.From.IsZero() || !q.To.IsZero()
+}
+
+// ComputeHash returns the hex SHA-256 of e's content and PrevHash. Before and
+// After are hashed in canonical form, and At at microseconds in UTC, so the
+// hash survives a round trip through Postgres jsonb and timestamptz.
+func (e AuditEntry) ComputeHash() (string, error) {
+	before, err := canonicalJSON(e.Before)
+	if err != nil {
+		return "", fmt.Errorf("audit entry before: %w", err)
+	}
+	after, err := canonicalJSON(e.After)
+	if err != nil {
+		return "", fmt.Errorf("audit entry after: %w", err)
+	}
+	// Field order is fixed by the struct, so the encoding is too
+	content, err := json.Marshal(struct {
+		PrevHash     string          `json:"prev_hash"`
+		BusinessID   string          `json:"business_id"`
+		QueueID      string          `json:"queue_id"`
+		ActorID      string          `json:"actor_id"`
+		ActorRole    string          `json:"actor_role"`
+		Action       string          `json:"action"`
+		TargetUserID string          `json:"target_user_id"`
+		Before       json.RawMessage `json:"before"`
+		After        json.RawMessage `json:"after"`
+		RequestID    string          `json:"request_id"`
+		At           string          `json:"at"`
+	}{
+		PrevHash:     e.PrevHash,
+		BusinessID:   e.BusinessID,
+		QueueID:      e.QueueID,
+		ActorID:      e.ActorID,
+		ActorRole:    e.ActorRole,
+		Action:       e.Action,
+		TargetUserID: e.TargetUserID,
+		Before:       before,
+		After:        after,
+		RequestID:    e.RequestID,
+		At:           e.At.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Chain links e after the entry whose hash is prevHash and sets its Hash. At
+// is cut to the microseconds Postgres keeps.
+func (e *AuditEntry) Chain(prevHash string) error {
+	e.PrevHash = prevHash
+	e.At = e.At.UTC().Truncate(time.Microsecond)
+	hash, err := e.ComputeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	return nil
+}
+
+// VerifyAuditChain checks that entries, consecutive and oldest first, each
+// hash to their Hash and link to the entry before them. The first entry's
+// PrevHash is taken on trust, so a page of the log can be verified alone.
+func VerifyAuditChain(entries []AuditEntry) error {
+	for i, e := range entries {
+		if i > 0 && e.PrevHash != entries[i-1].Hash {
+			return fmt.Errorf("%w: entry %d does not follow entry %d", ErrAuditChainBroken, e.ID, entries[i-1].ID)
+		}
+		hash, err := e.ComputeHash()
+		if err != nil {
+			return fmt.Errorf("%w: entry %d: %v", ErrAuditChainBroken, e.ID, err)
+		}
+		if hash != e.Hash {
+			return fmt.Errorf("%w: entry %d was modified", ErrAuditChainBroken, e.ID)
+		}
+	}
+	return nil
+}
+
+// canonicalJSON re-encodes raw with sorted keys and no insignificant space.
+// Empty input is null.
+func canonicalJSON(raw json.RawMessage) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifyAuditChain(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 123456789, time.FixedZone("CET", 3600))
+	chain := func() []AuditEntry {
+		entries := []AuditEntry{
+			{ID: 1, BusinessID: "biz1", QueueID: "q1", ActorID: "staff-1", ActorRole: "staff", Action: AuditCallNext,
+				TargetUserID: "guest-1", Before: json.RawMessage(`{"user_id":"guest-1","status":"WAITING"}`), At: at},
+			{ID: 2, BusinessID: "biz1", QueueID: "q1", ActorID: "owner", ActorRole: "admin", Action: AuditRemoveTicket,
+				TargetUserID: "guest-2", After: json.RawMessage(`null`), RequestID: "req-1", At: at.Add(time.Minute)},
+		}
+		prev := ""
+		for i := range entries {
+			if err := entries[i].Chain(prev); err != nil {
+				t.Fatalf("chain entry %d: %v", i, err)
+			}
+			prev = entries[i].Hash
+		}
+		return entries
+	}
+
+	entries := chain()
+	if err := VerifyAuditChain(entries); err != nil {
+		t.Fatalf("expected an intact chain, got %v", err)
+	}
+	if entries[1].PrevHash != entries[0].Hash {
+		t.Errorf("expected entry 2 to link to entry 1")
+	}
+
+	// Postgres hands jsonb back reformatted, and times in microseconds
+	stored := chain()
+	stored[0].Before = json.RawMessage(`{"status": "WAITING", "user_id": "guest-1"}`)
+	stored[0].At = at.UTC().Truncate(time.Microsecond)
+	if err := VerifyAuditChain(stored); err != nil {
+		t.Errorf("expected the chain to survive storage, got %v", err)
+	}
+
+	edited := chain()
+	edited[0].ActorID = "someone-else"
+	if err := VerifyAuditChain(edited); !errors.Is(err, ErrAuditChainBroken) {
+		t.Errorf("expected an edited entry to break the chain, got %v", err)
+	}
+
+	if err := VerifyAuditChain([]AuditEntry{entries[0], entries[0]}); !errors.Is(err, ErrAuditChainBroken) {
+		t.Errorf("expected an entry out of place to break the chain, got %v", err)
+	}
+
+	// A page starting mid-log is verified from its first entry
+	if err := VerifyAuditChain(entries[1:]); err != nil {
+		t.Errorf("expected a page of the chain to verify, got %v", err)
+	}
+}
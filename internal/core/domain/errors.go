@@ -0,0 +1,61 @@
+package domain
+
+import "errors"
+
+// ErrorCode is the stable, machine-readable name of a domain error. It is the
+// Temporal ApplicationError type the error travels as, and the code of the
+// HTTP problem it is reported as.
+type ErrorCode string
+
+const (
+	CodeUserAlreadyInQueue  ErrorCode = "user_already_in_queue"
+	CodeUserNotFound        ErrorCode = "user_not_found"
+	CodeQueueEmpty          ErrorCode = "queue_empty"
+	CodeQueueNotFound       ErrorCode = "queue_not_found"
+	CodeQueueClosed         ErrorCode = "queue_closed"
+	CodeCapacityReached     ErrorCode = "capacity_reached"
+	CodeInvalidSlot         ErrorCode = "invalid_slot"
+	CodeSlotUnavailable     ErrorCode = "slot_unavailable"
+	CodeAppointmentNotFound ErrorCode = "appointment_not_found"
+	CodeCheckInNotOpen      ErrorCode = "check_in_not_open"
+	CodeAppointmentClosed   ErrorCode = "appointment_closed"
+	CodeOutsideGeofence     ErrorCode = "outside_geofence"
+	CodeTicketNotWaiting    ErrorCode = "ticket_not_waiting"
+	CodeInvalidPartySize    ErrorCode = "invalid_party_size"
+	CodeNoFittingParty      ErrorCode = "no_fitting_party"
+	CodeMissingUserID       ErrorCode = "missing_user_id"
+)
+
+var errorCodes = map[ErrorCode]error{
+	CodeUserAlreadyInQueue:  ErrUserAlreadyInQueue,
+	CodeUserNotFound:        ErrUserNotFound,
+	CodeQueueEmpty:          ErrQueueEmpty,
+	CodeQueueNotFound:       ErrQueueNotFound,
+	CodeQueueClosed:         ErrQueueClosed,
+	CodeCapacityReached:     ErrCapacityReached,
+	CodeInvalidSlot:         ErrInvalidSlot,
+	CodeSlotUnavailable:     ErrSlotUnavailable,
+	CodeAppointmentNotFound: ErrAppointmentNotFound,
+	CodeCheckInNotOpen:      ErrCheckInNotOpen,
+	CodeAppointmentClosed:   ErrAppointmentClosed,
+	CodeOutsideGeofence:     ErrOutsideGeofence,
+	CodeTicketNotWaiting:    ErrTicketNotWaiting,
+	CodeInvalidPartySize:    ErrInvalidPartySize,
+	CodeNoFittingParty:      ErrNoFittingParty,
+	CodeMissingUserID:       ErrMissingUserID,
+}
+
+// CodeOf returns the code of the domain error in err's chain, or "" if there is none.
+func CodeOf(err error) ErrorCode {
+	for code, target := range errorCodes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
+	return ""
+}
+
+// ErrorForCode returns the domain error with the given code, or nil for an unknown code.
+func ErrorForCode(code ErrorCode) error {
+	return errorCodes[code]
+}
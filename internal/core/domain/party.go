@@ -0,0 +1,123 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidPartySize = errors.New("invalid party size")
+	ErrNoFittingParty   = errors.New("no waiting party fits")
+)
+
+// MaxPartySize bounds how many guests one ticket can stand for.
+const MaxPartySize = 20
+
+// MinutesPerExtraGuest is how much longer serving a party takes for each
+// guest beyond the first.
+const MinutesPerExtraGuest = 2
+
+// DefaultMaxSkips is how often a waiting party can be passed over by calls
+// for smaller tables when the queue doesn't say.
+const DefaultMaxSkips = 3
+
+// ValidPartySize checks a requested party size; 0 is a party of one.
+func ValidPartySize(n int) error {
+	if n < 0 || n > MaxPartySize {
+		return ErrInvalidPartySize
+	}
+	return nil
+}
+
+// Guests returns how many guests the ticket stands for.
+func (t Ticket) Guests() int {
+	return max(t.PartySize, 1)
+}
+
+// ServiceMinutes is the rough time serving a party of partySize takes.
+func ServiceMinutes(partySize int) int {
+	return MinutesPerPerson + MinutesPerExtraGuest*(max(partySize, 1)-1)
+}
+
+// EstimateWait estimates the wait of a party at position when only the
+// position is known, counting each ticket ahead as a party of one.
+func EstimateWait(position, partySize int) int {
+	if position < 1 {
+		return 0
+	}
+	return (position-1)*MinutesPerPerson + ServiceMinutes(partySize)
+}
+
+// WaitMinutes estimates the wait of whoever is at the 1-based position: the
+// parties still waiting ahead of them, weighted by party size, and their own.
+// Called tickets and those behind don't hold them up, and a ticket no longer
+// waiting has no wait.
+func (q *Queue) WaitMinutes(position int) int {
+	if position < 1 || position > len(q.Tickets) || q.Tickets[position-1].Status != TicketStatusWaiting {
+		return 0
+	}
+	return q.waitingMinutes(position-1) + ServiceMinutes(q.Tickets[position-1].PartySize)
+}
+
+// NewcomerWaitMinutes estimates the wait of a party of partySize joining the
+// back of the queue now.
+func (q *Queue) NewcomerWaitMinutes(partySize int) int {
+	return q.waitingMinutes(len(q.Tickets)) + ServiceMinutes(partySize)
+}
+
+// waitingMinutes weighs the waiting tickets among the first n.
+func (q *Queue) waitingMinutes(n int) int {
+	minutes := 0
+	for _, t := range q.Tickets[:n] {
+		if t.Status == TicketStatusWaiting {
+			minutes += ServiceMinutes(t.PartySize)
+		}
+	}
+	return minutes
+}
+
+// nextFitting returns the index of the ticket a call for a table of
+// capacity serves: the first waiting party that fits. A party passed over
+// maxSkips times holds the line, so nobody behind it is served until a table
+// it fits comes free.
+func (q *Queue) nextFitting(capacity, maxSkips int) (int, error) {
+	waiting := false
+	for i, t := range q.Tickets {
+		if t.Status != TicketStatusWaiting {
+			continue
+		}
+		waiting = true
+		if t.Guests() <= capacity {
+			return i, nil
+		}
+		if t.Skips >= maxSkips {
+			break
+		}
+	}
+	if !waiting {
+		return 0, ErrQueueEmpty
+	}
+	return 0, ErrNoFittingParty
+}
+
+// CanSeat reports whether a call for a table of capacity would serve
+// anyone, without serving them.
+func (q *Queue) CanSeat(capacity, maxSkips int) error {
+	_, err := q.nextFitting(capacity, maxSkips)
+	return err
+}
+
+// ServeFitting calls the first waiting party that fits a counter or table
+// of capacity, like ServeNext does the first waiting ticket. Each waiting
+// party it passes over counts a skip.
+func (q *Queue) ServeFitting(counterID string, capacity, maxSkips int) (*Ticket, error) {
+	i, err := q.nextFitting(capacity, maxSkips)
+	if err != nil {
+		return nil, err
+	}
+	for j := range q.Tickets[:i] {
+		if q.Tickets[j].Status == TicketStatusWaiting {
+			q.Tickets[j].Skips++
+		}
+	}
+	q.Tickets[i].Status = TicketStatusReady
+	q.Tickets[i].AssignedTo = counterID
+	return &q.Tickets[i], nil
+}
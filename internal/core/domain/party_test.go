@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQueue_WaitMinutes(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	for _, id := range []string{"u1", "u2", "u3"} {
+		q.AddUser(id, joinedAt)
+	}
+	q.Tickets[1].PartySize = 4
+
+	// 5 for u1, 11 for the party of four, 5 for u3
+	if got := q.WaitMinutes(3); got != 21 {
+		t.Errorf("expected 21 minutes, got %d", got)
+	}
+	if got := q.WaitMinutes(10); got != 0 {
+		t.Errorf("expected no wait past the end, got %d", got)
+	}
+	if got := q.NewcomerWaitMinutes(2); got != 28 {
+		t.Errorf("expected 28 minutes for a party of two joining, got %d", got)
+	}
+
+	// Called tickets no longer hold anyone up, nor wait themselves
+	q.Tickets[1].Status = TicketStatusReady
+	if got := q.WaitMinutes(3); got != 10 {
+		t.Errorf("expected 10 minutes, got %d", got)
+	}
+	if got := q.WaitMinutes(2); got != 0 {
+		t.Errorf("expected no wait once called, got %d", got)
+	}
+	if got := q.WaitMinutes(0); got != 0 {
+		t.Errorf("expected no wait for position 0, got %d", got)
+	}
+	if got := EstimateWait(3, 4); got != 21 {
+		t.Errorf("expected 21 minutes, got %d", got)
+	}
+
+	if err := ValidPartySize(MaxPartySize + 1); !errors.Is(err, ErrInvalidPartySize) {
+		t.Errorf("expected ErrInvalidPartySize, got %v", err)
+	}
+	if err := ValidPartySize(-1); !errors.Is(err, ErrInvalidPartySize) {
+		t.Errorf("expected ErrInvalidPartySize, got %v", err)
+	}
+}
+
+func TestQueue_ServeFitting(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	for _, id := range []string{"big", "u2", "u3"} {
+		q.AddUser(id, joinedAt)
+	}
+	q.Tickets[0].PartySize = 6
+	q.Tickets[1].PartySize = 2
+
+	ticket, err := q.ServeFitting("Table 1", 2, 2)
+	if err != nil || ticket.UserID != "u2" || ticket.AssignedTo != "Table 1" {
+		t.Fatalf("expected u2 seated at Table 1, got %+v, %v", ticket, err)
+	}
+	if q.Tickets[0].Skips != 1 {
+		t.Errorf("expected the large party skipped once, got %d", q.Tickets[0].Skips)
+	}
+
+	if _, err := q.ServeFitting("Table 2", 2, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Passed over twice, the large party now holds the line
+	q.AddUser("u4", joinedAt)
+	if err := q.CanSeat(2, 2); !errors.Is(err, ErrNoFittingParty) {
+		t.Errorf("expected ErrNoFittingParty, got %v", err)
+	}
+	ticket, err = q.ServeFitting("Table 3", 8, 2)
+	if err != nil || ticket.UserID != "big" {
+		t.Fatalf("expected the large party seated, got %+v, %v", ticket, err)
+	}
+
+	q.Tickets[len(q.Tickets)-1].Status = TicketStatusReady
+	if err := q.CanSeat(8, 2); !errors.Is(err, ErrQueueEmpty) {
+		t.Errorf("expected ErrQueueEmpty, got %v", err)
+	}
+}
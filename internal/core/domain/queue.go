@@ -8,31 +8,110 @@ import (
 var (
 	ErrUserAlreadyInQueue = errors.New("user already in queue")
 	ErrUserNotFound       = errors.New("user not found in queue")
+	ErrQueueEmpty         = errors.New("queue empty")
+	ErrQueueNotFound      = errors.New("queue not found")
+	ErrQueueClosed        = errors.New("queue closed")
+	ErrCapacityReached    = errors.New("queue capacity reached")
+	ErrMissingUserID      = errors.New("missing user ID")
 )
 
+// MinutesPerPerson is the rough service time used for wait estimates.
+const MinutesPerPerson = 5
+
 type TicketStatus string
 
 const (
 	TicketStatusWaiting   TicketStatus = "WAITING"
 	TicketStatusReady     TicketStatus = "READY"
 	TicketStatusCompleted TicketStatus = "COMPLETED"
+	// TicketStatusCancelled tickets were still waiting when their queue shut down.
+	TicketStatusCancelled TicketStatus = "CANCELLED"
+)
+
+// QueueState is whether a queue takes new joins.
+type QueueState string
+
+const (
+	QueueStateOpen   QueueState = "OPEN"
+	QueueStateClosed QueueState = "CLOSED"
 )
 
+// Valid reports whether s is a known state.
+func (s QueueState) Valid() bool {
+	return s == QueueStateOpen || s == QueueStateClosed
+}
+
 type Ticket struct {
 	UserID     string       `json:"userId"`
 	Status     TicketStatus `json:"status"`
 	AssignedTo string       `json:"assignedTo,omitempty"` // Counter ID, e.g. "Counter 3"
 	JoinedAt   time.Time    `json:"joinedAt"`
+	// Priority is PriorityWalkIn or, for checked-in appointments, PriorityAppointment.
+	Priority int `json:"priority,omitempty"`
+	// Remote tickets were joined from afar, and are asked to confirm they
+	// are coming as their turn approaches: by ConfirmBy, once asked.
+	Remote    bool      `json:"remote,omitempty"`
+	ConfirmBy time.Time `json:"confirmBy,omitzero"`
+	Confirmed bool      `json:"confirmed,omitempty"`
+	// Arrived is whether a confirmation placed the guest at the business.
+	Arrived bool `json:"arrived,omitempty"`
+	// Misses counts the confirmation deadlines the guest let pass.
+	Misses int `json:"misses,omitempty"`
+	// PartySize is how many guests the ticket stands for; 0 is one.
+	PartySize int `json:"partySize,omitempty"`
+	// Skips counts the calls for smaller tables that passed the party over.
+	Skips int `json:"skips,omitempty"`
 }
 
 type Queue struct {
 	ID         string
 	BusinessID string
 	Tickets    []Ticket
+	// Closed queues keep serving their tickets but take no new joins.
+	Closed bool
+	// Capacity is how many tickets may wait at once; zero is no limit.
+	Capacity int
+}
+
+// QueueSummary describes a queue without its tickets, as listings report it.
+type QueueSummary struct {
+	ID         string     `json:"queue_id"`
+	BusinessID string     `json:"business_id"`
+	State      QueueState `json:"state"`
+	Length     int        `json:"queue_length"`
 }
 
 type JoinRequest struct {
 	UserID string `json:"userId"`
+	// PartySize is how many guests join on the ticket; 0 is one.
+	PartySize int `json:"partySize,omitempty"`
+	// Nonce is fresh for every join sent, so a join can tell whether it is
+	// the one that added the guest or a replay of it.
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// Joined is where a join put the guest.
+type Joined struct {
+	UserID      string
+	Position    int
+	WaitMinutes int
+	// Replayed joins reused the idempotency key of the join that added the
+	// guest, and got its place back.
+	Replayed bool
+}
+
+// TicketTransfer moves a guest's ticket from one queue of a business to another.
+type TicketTransfer struct {
+	BusinessID  string
+	FromQueueID string
+	ToQueueID   string
+	UserID      string
+	// Position is the 1-based place in the target queue; 0 is the end.
+	Position int
+	// KeepJoinTime carries the original join time over, so the time already
+	// waited still counts in the target queue.
+	KeepJoinTime bool
+	StaffID      string
 }
 
 func NewQueue(id, businessID string) *Queue {
@@ -44,28 +123,36 @@ func NewQueue(id, businessID string) *Queue {
 }
 
 // Enqueue adds a user to the end of the queue.
-func (q *Queue) Enqueue(userID string) error {
+func (q *Queue) Enqueue(userID string, joinedAt time.Time) error {
 	if err := q.CanJoin(userID); err != nil {
 		return err
 	}
-	q.AddUser(userID)
+	q.AddUser(userID, joinedAt)
 	return nil
 }
 
 func (q *Queue) CanJoin(userID string) error {
+	if q.Closed {
+		return ErrQueueClosed
+	}
 	for _, t := range q.Tickets {
 		if t.UserID == userID {
 			return ErrUserAlreadyInQueue
 		}
 	}
+	if q.Capacity > 0 && q.waiting() >= q.Capacity {
+		return ErrCapacityReached
+	}
 	return nil
 }
 
-func (q *Queue) AddUser(userID string) int {
+// AddUser appends a waiting ticket joined at joinedAt, which workflows take
+// from workflow.Now so it replays, and returns its position.
+func (q *Queue) AddUser(userID string, joinedAt time.Time) int {
 	ticket := Ticket{
 		UserID:   userID,
 		Status:   TicketStatusWaiting,
-		JoinedAt: time.Now(),
+		JoinedAt: joinedAt,
 	}
 	q.Tickets = append(q.Tickets, ticket)
 	return len(q.Tickets)
@@ -82,6 +169,51 @@ func (q *Queue) Dequeue(userID string) error {
 	return ErrUserNotFound
 }
 
+// Remove takes a user's ticket out of the queue and returns it.
+func (q *Queue) Remove(userID string) (Ticket, error) {
+	pos := q.GetPosition(userID)
+	if pos == 0 {
+		return Ticket{}, ErrUserNotFound
+	}
+	ticket := q.Tickets[pos-1]
+	q.Tickets = append(q.Tickets[:pos-1], q.Tickets[pos:]...)
+	return ticket, nil
+}
+
+// InsertAt puts a ticket at the given 1-based position and returns where it
+// landed. Positions outside the queue, including 0, put it at the end, or
+// ahead of the first waiting ticket of lower priority so walk-ins can't starve
+// appointments. Unlike joins, inserts are allowed into closed queues.
+func (q *Queue) InsertAt(ticket Ticket, position int) (int, error) {
+	if q.GetPosition(ticket.UserID) != 0 {
+		return 0, ErrUserAlreadyInQueue
+	}
+	if position < 1 || position > len(q.Tickets) {
+		position = len(q.Tickets) + 1
+		for i, t := range q.Tickets {
+			if t.Status == TicketStatusWaiting && t.Priority < ticket.Priority {
+				position = i + 1
+				break
+			}
+		}
+	}
+	q.Tickets = append(q.Tickets[:position-1], append([]Ticket{ticket}, q.Tickets[position-1:]...)...)
+	return position, nil
+}
+
+// MoveTo moves a user to the given 1-based position, clamped to the queue.
+func (q *Queue) MoveTo(userID string, position int) error {
+	from := q.GetPosition(userID)
+	if from == 0 {
+		return ErrUserNotFound
+	}
+	to := min(max(position, 1), len(q.Tickets))
+	ticket := q.Tickets[from-1]
+	q.Tickets = append(q.Tickets[:from-1], q.Tickets[from:]...)
+	q.Tickets = append(q.Tickets[:to-1], append([]Ticket{ticket}, q.Tickets[to-1:]...)...)
+	return nil
+}
+
 // GetPosition returns the 1-based index of the user in the queue.
 // Returns 0 if not found.
 func (q *Queue) GetPosition(userID string) int {
@@ -93,6 +225,14 @@ func (q *Queue) GetPosition(userID string) int {
 	return 0
 }
 
+// State returns whether the queue takes new joins.
+func (q *Queue) State() QueueState {
+	if q.Closed {
+		return QueueStateClosed
+	}
+	return QueueStateOpen
+}
+
 // Len returns the number of users in the queue.
 func (q *Queue) Len() int {
 	return len(q.Tickets)
@@ -107,7 +247,39 @@ func (q *Queue) ServeNext(counterID string) (*Ticket, error) {
 			return &q.Tickets[i], nil
 		}
 	}
-	return nil, errors.New("queue empty")
+	return nil, ErrQueueEmpty
+}
+
+// CancelWaiting cancels every waiting ticket and returns copies of them.
+func (q *Queue) CancelWaiting() []Ticket {
+	var cancelled []Ticket
+	for i := range q.Tickets {
+		if q.Tickets[i].Status == TicketStatusWaiting {
+			q.Tickets[i].Status = TicketStatusCancelled
+			cancelled = append(cancelled, q.Tickets[i])
+		}
+	}
+	return cancelled
+}
+
+// HasWaiting reports whether any ticket is waiting to be served.
+func (q *Queue) HasWaiting() bool {
+	for _, t := range q.Tickets {
+		if t.Status == TicketStatusWaiting {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Queue) waiting() int {
+	n := 0
+	for _, t := range q.Tickets {
+		if t.Status == TicketStatusWaiting {
+			n++
+		}
+	}
+	return n
 }
 
 // Snapshot returns a copy of the current state
@@ -118,5 +290,7 @@ func (q *Queue) Snapshot() Queue {
 		ID:         q.ID,
 		BusinessID: q.BusinessID,
 		Tickets:    ticketsCopy,
+		Closed:     q.Closed,
+		Capacity:   q.Capacity,
 	}
 }
@@ -2,8 +2,12 @@ package domain
 
 import (
 	"testing"
+	"time"
 )
 
+// joinedAt is the join time of the tickets the tests add.
+var joinedAt = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
 func TestQueue_Enqueue(t *testing.T) {
 	q := NewQueue("q1", "biz1")
 
@@ -11,7 +15,7 @@ func TestQueue_Enqueue(t *testing.T) {
 		t.Errorf("expected businessID biz1, got %s", q.BusinessID)
 	}
 
-	if err := q.Enqueue("u1"); err != nil {
+	if err := q.Enqueue("u1", joinedAt); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
@@ -19,15 +23,15 @@ func TestQueue_Enqueue(t *testing.T) {
 		t.Errorf("expected len 1, got %d", q.Len())
 	}
 
-	if err := q.Enqueue("u1"); err != ErrUserAlreadyInQueue {
+	if err := q.Enqueue("u1", joinedAt); err != ErrUserAlreadyInQueue {
 		t.Errorf("expected ErrUserAlreadyInQueue, got %v", err)
 	}
 }
 
 func TestQueue_Dequeue(t *testing.T) {
 	q := NewQueue("q1", "biz1")
-	q.Enqueue("u1")
-	q.Enqueue("u2")
+	q.Enqueue("u1", joinedAt)
+	q.Enqueue("u2", joinedAt)
 
 	if err := q.Dequeue("u1"); err != nil {
 		t.Errorf("unexpected error: %v", err)
@@ -48,8 +52,8 @@ func TestQueue_Dequeue(t *testing.T) {
 
 func TestQueue_GetPosition(t *testing.T) {
 	q := NewQueue("q1", "biz1")
-	q.Enqueue("u1")
-	q.Enqueue("u2")
+	q.Enqueue("u1", joinedAt)
+	q.Enqueue("u2", joinedAt)
 
 	if pos := q.GetPosition("u1"); pos != 1 {
 		t.Errorf("expected pos 1, got %d", pos)
@@ -61,3 +65,155 @@ func TestQueue_GetPosition(t *testing.T) {
 		t.Errorf("expected pos 0, got %d", pos)
 	}
 }
+
+func TestQueue_MoveTo(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	q.Enqueue("u1", joinedAt)
+	q.Enqueue("u2", joinedAt)
+	q.Enqueue("u3", joinedAt)
+
+	if err := q.MoveTo("u3", 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if pos := q.GetPosition("u3"); pos != 1 {
+		t.Errorf("expected u3 at pos 1, got %d", pos)
+	}
+	if pos := q.GetPosition("u1"); pos != 2 {
+		t.Errorf("expected u1 at pos 2, got %d", pos)
+	}
+
+	// Positions past the end are clamped
+	if err := q.MoveTo("u3", 10); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if pos := q.GetPosition("u3"); pos != 3 {
+		t.Errorf("expected u3 at pos 3, got %d", pos)
+	}
+	if q.Len() != 3 {
+		t.Errorf("expected len 3, got %d", q.Len())
+	}
+
+	if err := q.MoveTo("u4", 1); err != ErrUserNotFound {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestQueue_RemoveAndInsertAt(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	q.Enqueue("u1", joinedAt)
+	q.Enqueue("u2", joinedAt)
+	q.Enqueue("u3", joinedAt)
+
+	ticket, err := q.Remove("u2")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ticket.UserID != "u2" || ticket.Status != TicketStatusWaiting {
+		t.Errorf("expected waiting ticket of u2, got %+v", ticket)
+	}
+	if _, err := q.Remove("u2"); err != ErrUserNotFound {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	// Closed queues still take inserts
+	q.Closed = true
+	if pos, err := q.InsertAt(ticket, 1); err != nil || pos != 1 {
+		t.Errorf("expected u2 inserted at 1, got %d, %v", pos, err)
+	}
+	if pos := q.GetPosition("u1"); pos != 2 {
+		t.Errorf("expected u1 at pos 2, got %d", pos)
+	}
+
+	// Position 0 and positions past the end append
+	if pos, _ := q.InsertAt(Ticket{UserID: "u4"}, 0); pos != 4 {
+		t.Errorf("expected u4 at pos 4, got %d", pos)
+	}
+	if pos, _ := q.InsertAt(Ticket{UserID: "u5"}, 10); pos != 5 {
+		t.Errorf("expected u5 at pos 5, got %d", pos)
+	}
+
+	if _, err := q.InsertAt(Ticket{UserID: "u1"}, 1); err != ErrUserAlreadyInQueue {
+		t.Errorf("expected ErrUserAlreadyInQueue, got %v", err)
+	}
+}
+
+func TestQueue_InsertAt_Priority(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	q.Enqueue("walk-in-1", joinedAt)
+	q.Enqueue("walk-in-2", joinedAt)
+	q.ServeNext("Counter 1")
+
+	// Appointments go ahead of waiting walk-ins, but not of those already called
+	appointment := Ticket{UserID: "appt-1", Status: TicketStatusWaiting, Priority: PriorityAppointment}
+	if pos, err := q.InsertAt(appointment, 0); err != nil || pos != 2 {
+		t.Errorf("expected appt-1 at pos 2, got %d, %v", pos, err)
+	}
+	// and keep the order they checked in in
+	second := Ticket{UserID: "appt-2", Status: TicketStatusWaiting, Priority: PriorityAppointment}
+	if pos, _ := q.InsertAt(second, 0); pos != 3 {
+		t.Errorf("expected appt-2 at pos 3, got %d", pos)
+	}
+	// Walk-ins still queue at the end
+	if pos, _ := q.InsertAt(Ticket{UserID: "walk-in-3", Status: TicketStatusWaiting}, 0); pos != 5 {
+		t.Errorf("expected walk-in-3 at pos 5, got %d", pos)
+	}
+	// An explicit position is kept
+	third := Ticket{UserID: "appt-3", Status: TicketStatusWaiting, Priority: PriorityAppointment}
+	if pos, _ := q.InsertAt(third, 5); pos != 5 {
+		t.Errorf("expected appt-3 at pos 5, got %d", pos)
+	}
+
+	if next, _ := q.ServeNext("Counter 2"); next.UserID != "appt-1" {
+		t.Errorf("expected appt-1 served next, got %s", next.UserID)
+	}
+}
+
+func TestQueue_Closed(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	q.Enqueue("u1", joinedAt)
+	q.Closed = true
+
+	if err := q.Enqueue("u2", joinedAt); err != ErrQueueClosed {
+		t.Errorf("expected ErrQueueClosed, got %v", err)
+	}
+	if !q.Snapshot().Closed {
+		t.Error("expected snapshot to be closed")
+	}
+	if _, err := q.ServeNext("c1"); err != nil {
+		t.Errorf("closed queue should still serve: %v", err)
+	}
+}
+
+func TestQueue_Capacity(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	q.Capacity = 2
+	q.Enqueue("u1", joinedAt)
+	q.Enqueue("u2", joinedAt)
+
+	if err := q.Enqueue("u3", joinedAt); err != ErrCapacityReached {
+		t.Errorf("expected ErrCapacityReached, got %v", err)
+	}
+	// Called tickets no longer wait
+	q.ServeNext("c1")
+	if err := q.Enqueue("u3", joinedAt); err != nil {
+		t.Errorf("expected a place once a guest was called, got %v", err)
+	}
+}
+
+func TestQueue_CancelWaiting(t *testing.T) {
+	q := NewQueue("q1", "biz1")
+	q.Enqueue("u1", joinedAt)
+	q.Enqueue("u2", joinedAt)
+	q.ServeNext("c1")
+
+	cancelled := q.CancelWaiting()
+	if len(cancelled) != 1 || cancelled[0].UserID != "u2" {
+		t.Fatalf("expected only u2 cancelled, got %v", cancelled)
+	}
+	if q.Tickets[0].Status != TicketStatusReady {
+		t.Errorf("expected called ticket untouched, got %s", q.Tickets[0].Status)
+	}
+	if q.HasWaiting() {
+		t.Error("expected nobody waiting")
+	}
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrOutsideGeofence  = errors.New("location is not at the business")
+	ErrTicketNotWaiting = errors.New("ticket is not waiting")
+)
+
+// earthRadiusMeters is the mean radius DistanceMeters measures on.
+const earthRadiusMeters = 6371000
+
+// Location is a point given in decimal degrees.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// DistanceMeters returns the great-circle distance between a and b.
+func DistanceMeters(a, b Location) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(b.Latitude - a.Latitude)
+	dLng := rad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.Latitude))*math.Cos(rad(b.Latitude))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Geofence is the area around a business where guests count as arrived.
+type Geofence struct {
+	Center       Location `json:"center"`
+	RadiusMeters float64  `json:"radius_meters"`
+}
+
+// Contains reports whether l is within the fence.
+func (g Geofence) Contains(l Location) bool {
+	return DistanceMeters(g.Center, l) <= g.RadiusMeters
+}
+
+// ConfirmPolicy is how a queue makes sure guests who joined remotely are
+// coming. The zero policy asks nobody.
+type ConfirmPolicy struct {
+	// Ahead is how close to the front, counted in waiting tickets, remote
+	// guests are asked to confirm they are on their way.
+	Ahead int
+	// Within is how long they have to confirm.
+	Within time.Duration
+	// MoveBack is how many places a guest drops for missing a deadline.
+	MoveBack int
+	// MaxMisses is the miss that expires a guest's ticket: with 2, the
+	// second missed deadline does. 0 and 1 both expire it at the first.
+	MaxMisses int
+	// Geofence, when set, is where a confirmation with a location counts as
+	// arrival.
+	Geofence *Geofence
+}
+
+// Enabled reports whether the policy asks anyone to confirm.
+func (p ConfirmPolicy) Enabled() bool {
+	return p.Ahead > 0 && p.Within > 0
+}
+
+// Arrival reports whether a confirmation from loc places the guest at the
+// business. Without a location or a geofence to check it against, it
+// doesn't; a location outside the geofence is an error.
+func (p ConfirmPolicy) Arrival(loc *Location) (bool, error) {
+	if loc == nil || p.Geofence == nil {
+		return false, nil
+	}
+	if !p.Geofence.Contains(*loc) {
+		return false, ErrOutsideGeofence
+	}
+	return true, nil
+}
+
+// DueForConfirmation returns the remote guests among the first p.Ahead
+// waiting tickets who haven't confirmed and haven't been asked to.
+func (q *Queue) DueForConfirmation(p ConfirmPolicy) []string {
+	if !p.Enabled() {
+		return nil
+	}
+	var due []string
+	waiting := 0
+	for _, t := range q.Tickets {
+		if t.Status != TicketStatusWaiting {
+			continue
+		}
+		if waiting++; waiting > p.Ahead {
+			break
+		}
+		if t.Remote && !t.Confirmed && t.ConfirmBy.IsZero() {
+			due = append(due, t.UserID)
+		}
+	}
+	return due
+}
+
+// RequestConfirmation gives a guest until deadline to confirm.
+func (q *Queue) RequestConfirmation(userID string, deadline time.Time) error {
+	pos := q.GetPosition(userID)
+	if pos == 0 {
+		return ErrUserNotFound
+	}
+	q.Tickets[pos-1].ConfirmBy = deadline
+	return nil
+}
+
+// WithdrawConfirmation takes back the ask with deadline when it never
+// reached the guest, so they are asked again rather than held to it.
+func (q *Queue) WithdrawConfirmation(userID string, deadline time.Time) {
+	if pos := q.GetPosition(userID); pos > 0 && q.Tickets[pos-1].ConfirmBy.Equal(deadline) {
+		q.Tickets[pos-1].ConfirmBy = time.Time{}
+	}
+}
+
+// Confirm records that a waiting guest is on their way, or has arrived.
+func (q *Queue) Confirm(userID string, arrived bool) (Ticket, error) {
+	pos := q.GetPosition(userID)
+	if pos == 0 {
+		return Ticket{}, ErrUserNotFound
+	}
+	t := &q.Tickets[pos-1]
+	if t.Status != TicketStatusWaiting {
+		return Ticket{}, ErrTicketNotWaiting
+	}
+	t.Confirmed = true
+	t.Arrived = t.Arrived || arrived
+	return *t, nil
+}
+
+// MissConfirmation handles a guest letting the deadline they were given
+// pass. Unless they confirmed, were called or left meanwhile, they drop
+// p.MoveBack places and may be asked again, or after p.MaxMisses misses
+// their ticket expires and is removed. It returns their ticket, with the
+// position they dropped to or had when it expired; position is 0 when
+// nothing changed.
+func (q *Queue) MissConfirmation(userID string, deadline time.Time, p ConfirmPolicy) (ticket Ticket, position int, expired bool) {
+	pos := q.GetPosition(userID)
+	if pos == 0 {
+		return Ticket{}, 0, false
+	}
+	t := &q.Tickets[pos-1]
+	if t.Status != TicketStatusWaiting || t.Confirmed || !t.ConfirmBy.Equal(deadline) {
+		return Ticket{}, 0, false
+	}
+	t.Misses++
+	t.ConfirmBy = time.Time{}
+	if t.Misses >= p.MaxMisses {
+		removed, _ := q.Remove(userID)
+		return removed, pos, true
+	}
+	q.MoveTo(userID, pos+p.MoveBack)
+	position = q.GetPosition(userID)
+	return q.Tickets[position-1], position, false
+}
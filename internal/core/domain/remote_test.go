@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfirmPolicy_Arrival(t *testing.T) {
+	shop := Location{Latitude: 52.3676, Longitude: 4.9041}
+	// About 111 m north of the shop
+	nearby := Location{Latitude: 52.3686, Longitude: 4.9041}
+	if d := DistanceMeters(shop, nearby); math.Abs(d-111.2) > 0.5 {
+		t.Errorf("expected about 111 m, got %.1f", d)
+	}
+
+	p := ConfirmPolicy{Geofence: &Geofence{Center: shop, RadiusMeters: 150}}
+	if arrived, err := p.Arrival(&nearby); err != nil || !arrived {
+		t.Errorf("expected arrival inside the fence, got %v, %v", arrived, err)
+	}
+	if arrived, err := p.Arrival(nil); err != nil || arrived {
+		t.Errorf("expected confirmation without a location, got %v, %v", arrived, err)
+	}
+	if _, err := p.Arrival(&Location{Latitude: 52.3, Longitude: 4.9}); !errors.Is(err, ErrOutsideGeofence) {
+		t.Errorf("expected ErrOutsideGeofence, got %v", err)
+	}
+	// Without a geofence, locations are not checked
+	if arrived, err := (ConfirmPolicy{}).Arrival(&nearby); err != nil || arrived {
+		t.Errorf("expected no check without a fence, got %v, %v", arrived, err)
+	}
+}
+
+func TestQueue_Confirmation(t *testing.T) {
+	p := ConfirmPolicy{Ahead: 2, Within: 5 * time.Minute, MoveBack: 2, MaxMisses: 2}
+	q := NewQueue("q1", "biz1")
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		q.AddUser(id, joinedAt)
+		q.Tickets[len(q.Tickets)-1].Remote = id != "u2"
+	}
+
+	// u2 is at the business already; u3 is not near enough to be asked
+	if due := q.DueForConfirmation(p); !reflect.DeepEqual(due, []string{"u1"}) {
+		t.Fatalf("expected u1 due, got %v", due)
+	}
+	if due := q.DueForConfirmation(ConfirmPolicy{}); due != nil {
+		t.Errorf("expected nobody due without a policy, got %v", due)
+	}
+
+	deadline := time.Date(2026, 3, 2, 10, 5, 0, 0, time.UTC)
+	if err := q.RequestConfirmation("u1", deadline); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if due := q.DueForConfirmation(p); len(due) != 0 {
+		t.Errorf("expected nobody due once asked, got %v", due)
+	}
+
+	// A stale deadline changes nothing
+	if _, pos, _ := q.MissConfirmation("u1", deadline.Add(-time.Minute), p); pos != 0 {
+		t.Errorf("expected stale deadline ignored, got position %d", pos)
+	}
+	ticket, pos, expired := q.MissConfirmation("u1", deadline, p)
+	if expired || pos != 3 || ticket.Misses != 1 || !ticket.ConfirmBy.IsZero() {
+		t.Fatalf("expected u1 moved back to 3, got %d %v %+v", pos, expired, ticket)
+	}
+	// u3 moved up into reach
+	if due := q.DueForConfirmation(p); !reflect.DeepEqual(due, []string{"u3"}) {
+		t.Errorf("expected u3 due, got %v", due)
+	}
+
+	q.RequestConfirmation("u1", deadline.Add(time.Hour))
+	if _, pos, expired := q.MissConfirmation("u1", deadline.Add(time.Hour), p); !expired || pos != 3 {
+		t.Errorf("expected u1 expired from 3, got %d %v", pos, expired)
+	}
+	if q.GetPosition("u1") != 0 {
+		t.Errorf("expected u1 removed")
+	}
+
+	confirmed, err := q.Confirm("u3", true)
+	if err != nil || !confirmed.Confirmed || !confirmed.Arrived {
+		t.Errorf("expected u3 confirmed and arrived, got %+v, %v", confirmed, err)
+	}
+	if _, err := q.Confirm("u1", false); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+	q.Tickets[0].Status = TicketStatusReady
+	if _, err := q.Confirm(q.Tickets[0].UserID, false); !errors.Is(err, ErrTicketNotWaiting) {
+		t.Errorf("expected ErrTicketNotWaiting, got %v", err)
+	}
+}
+
+func TestQueue_MissConfirmation_MaxMisses(t *testing.T) {
+	deadline := time.Date(2026, 3, 2, 10, 5, 0, 0, time.UTC)
+	for _, maxMisses := range []int{0, 1} {
+		p := ConfirmPolicy{Ahead: 1, Within: 5 * time.Minute, MoveBack: 2, MaxMisses: maxMisses}
+		q := NewQueue("q1", "biz1")
+		q.AddUser("u1", joinedAt)
+		q.AddUser("u2", joinedAt)
+		q.Tickets[0].Remote = true
+		q.RequestConfirmation("u1", deadline)
+
+		// The first miss already expires the ticket
+		ticket, pos, expired := q.MissConfirmation("u1", deadline, p)
+		if !expired || pos != 1 || ticket.Misses != 1 {
+			t.Errorf("MaxMisses %d: expected u1 expired at 1 after one miss, got expired=%v pos=%d misses=%d", maxMisses, expired, pos, ticket.Misses)
+		}
+		if q.GetPosition("u1") != 0 {
+			t.Errorf("MaxMisses %d: expected u1 removed", maxMisses)
+		}
+	}
+}
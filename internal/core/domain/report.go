@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// QueueReport is the final summary of a queue, persisted when it shuts down.
+type QueueReport struct {
+	BusinessID string    `json:"business_id"`
+	QueueID    string    `json:"queue_id"`
+	RunID      string    `json:"run_id"`
+	Reason     string    `json:"reason"`
+	ClosedAt   time.Time `json:"closed_at"`
+	// Served counts tickets called to a counter.
+	Served int `json:"served"`
+	// Abandoned counts guests who left before being called, plus Cancelled.
+	Abandoned int `json:"abandoned"`
+	// Cancelled counts tickets still waiting when the queue shut down.
+	Cancelled int `json:"cancelled"`
+	// AvgWaitSeconds is the mean time from join to call over served tickets.
+	AvgWaitSeconds float64 `json:"avg_wait_seconds"`
+}
+
+// QueueStats tallies how a queue's tickets ended while it runs.
+type QueueStats struct {
+	Served    int
+	Left      int
+	TotalWait time.Duration
+}
+
+// RecordServed counts a ticket called after waiting for wait.
+func (s *QueueStats) RecordServed(wait time.Duration) {
+	s.Served++
+	s.TotalWait += wait
+}
+
+// RecordLeft counts a guest who left before being called.
+func (s *QueueStats) RecordLeft() {
+	s.Left++
+}
+
+// Report summarises the stats of q, whose cancelled waiting tickets were
+// cancelled when it shut down for reason.
+func (s QueueStats) Report(q *Queue, cancelled int, reason string, closedAt time.Time) QueueReport {
+	report := QueueReport{
+		BusinessID: q.BusinessID,
+		QueueID:    q.ID,
+		Reason:     reason,
+		ClosedAt:   closedAt,
+		Served:     s.Served,
+		Abandoned:  s.Left + cancelled,
+		Cancelled:  cancelled,
+	}
+	if s.Served > 0 {
+		report.AvgWaitSeconds = s.TotalWait.Seconds() / float64(s.Served)
+	}
+	return report
+}
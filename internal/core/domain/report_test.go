@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueueStats_Report(t *testing.T) {
+	var stats QueueStats
+	stats.RecordServed(2 * time.Minute)
+	stats.RecordServed(4 * time.Minute)
+	stats.RecordLeft()
+
+	closedAt := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	report := stats.Report(NewQueue("q1", "biz1"), 2, "closing early", closedAt)
+
+	if report.Served != 2 {
+		t.Errorf("expected 2 served, got %d", report.Served)
+	}
+	if report.Abandoned != 3 || report.Cancelled != 2 {
+		t.Errorf("expected 3 abandoned of which 2 cancelled, got %d and %d", report.Abandoned, report.Cancelled)
+	}
+	if report.AvgWaitSeconds != 180 {
+		t.Errorf("expected 180s average wait, got %v", report.AvgWaitSeconds)
+	}
+	if report.BusinessID != "biz1" || report.QueueID != "q1" || report.Reason != "closing early" || !report.ClosedAt.Equal(closedAt) {
+		t.Errorf("unexpected report identity: %+v", report)
+	}
+
+	if empty := (QueueStats{}).Report(NewQueue("q1", "biz1"), 0, "", closedAt); empty.AvgWaitSeconds != 0 {
+		t.Errorf("expected no average without served tickets, got %v", empty.AvgWaitSeconds)
+	}
+}
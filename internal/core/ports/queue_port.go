@@ -2,13 +2,95 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"red-duck/internal/core/domain"
 )
 
+// MaxIdempotencyKeyLength bounds the idempotency keys adapters accept from clients.
+const MaxIdempotencyKeyLength = 255
+
+// QueueService runs the business queues. The HTTP and gRPC adapters both drive it.
+//
+// Join, leave, call-next and walk-ins take an idempotency key: retrying with
+// the same key returns the original result instead of acting twice. An empty
+// key makes every call a new action. Errors are domain errors where one applies.
 type QueueService interface {
-	CreateQueue(ctx context.Context, businessID, queueID string) error
-	JoinQueue(ctx context.Context, businessID, queueID, userID string) error
-	LeaveQueue(ctx context.Context, businessID, queueID, userID string) error
+	// CreateQueue starts the queue and returns the ID of its run.
+	CreateQueue(ctx context.Context, businessID, queueID string) (runID string, err error)
+	// DeleteQueue shuts the queue down for reason, cancelling the tickets still
+	// waiting, and returns its final report.
+	DeleteQueue(ctx context.Context, businessID, queueID, reason, requestedBy string) (*domain.QueueReport, error)
+	// JoinQueue adds a new guest, with a party of partySize (0 is one), and
+	// returns their generated ID and 1-based position. A join replaying
+	// another's idempotency key comes back Replayed.
+	JoinQueue(ctx context.Context, businessID, queueID string, partySize int, idempotencyKey string) (domain.Joined, error)
+	// LeaveQueue removes a guest and returns how many remain.
+	LeaveQueue(ctx context.Context, businessID, queueID, userID, idempotencyKey string) (remaining int, err error)
+	// ListQueues returns a page of the business's running queues, only those in
+	// state unless it is empty. pageToken is nil for the first page; the
+	// returned token is nil after the last.
+	ListQueues(ctx context.Context, businessID string, state domain.QueueState, pageSize int, pageToken []byte) (queues []domain.QueueSummary, nextPageToken []byte, err error)
+	// GetQueueStatus returns a snapshot of the queue.
 	GetQueueStatus(ctx context.Context, businessID, queueID string) (*domain.Queue, error)
+	// CallNext calls the next waiting guest to a counter on behalf of staffID.
+	// With a capacity, it calls the first waiting party that fits the counter
+	// or table instead, within the queue's limit on passing parties over.
+	CallNext(ctx context.Context, businessID, queueID, counterID string, capacity int, staffID, idempotencyKey string) (*domain.Ticket, error)
+	// MoveTicket moves a guest to another 1-based position, clamped to the
+	// queue, on behalf of staffID and returns where they landed.
+	MoveTicket(ctx context.Context, businessID, queueID, userID string, position int, staffID string) (int, error)
+	// RemoveTicket takes a guest's ticket out of the queue on behalf of staffID
+	// and returns it.
+	RemoveTicket(ctx context.Context, businessID, queueID, userID, staffID, reason string) (*domain.Ticket, error)
+	// ConfirmTicket records that a remote guest asked to confirm is on their
+	// way. With a location inside the business's geofence they count as
+	// arrived; one outside it is rejected.
+	ConfirmTicket(ctx context.Context, businessID, queueID, userID string, location *domain.Location) (*domain.Ticket, error)
+	// AddWalkIn inserts a new guest, with a party of partySize (0 is one), at
+	// the 1-based position, or at the end when it is 0, on behalf of staffID.
+	// Closed queues take walk-ins too. It returns the guest's generated ID and
+	// position.
+	AddWalkIn(ctx context.Context, businessID, queueID string, position, partySize int, staffID, idempotencyKey string) (userID string, landed int, err error)
+	// TransferTicket moves a guest's ticket to another queue of the business
+	// and returns its position there. If the ticket can't be placed in the
+	// target queue it is returned to the source queue.
+	TransferTicket(ctx context.Context, transfer domain.TicketTransfer) (int, error)
+	// WatchQueue calls fn with the queue now and after every change, until ctx
+	// is done or fn returns an error.
+	WatchQueue(ctx context.Context, businessID, queueID string, fn func(*domain.Queue) error) error
+}
+
+// QueueReportRepository keeps the final reports of queues that shut down.
+type QueueReportRepository interface {
+	SaveQueueReport(ctx context.Context, report domain.QueueReport) error
+}
+
+// AuditLogRepository keeps the append-only, hash-chained log of staff changes.
+type AuditLogRepository interface {
+	// AppendAuditEntry chains entry after the business's last entry and
+	// stores it. Appending the same key again returns the stored entry, so
+	// the activity writing it can retry.
+	AppendAuditEntry(ctx context.Context, key string, entry domain.AuditEntry) (domain.AuditEntry, error)
+	// ListAuditEntries returns the business's entries matching q, oldest first.
+	ListAuditEntries(ctx context.Context, q domain.AuditQuery) ([]domain.AuditEntry, error)
+}
+
+// AppointmentService books guests into slots of a queue. On check-in an
+// appointment becomes a ticket in the queue, ahead of the waiting walk-ins.
+//
+// Booking takes an idempotency key like joining does: retrying with the same
+// key returns the same guest's appointment instead of booking another seat.
+type AppointmentService interface {
+	// Book reserves a seat in the slot starting at slotStart for a new guest
+	// and returns their appointment. A booking replaying the idempotency key
+	// of one that already holds a seat comes back replayed.
+	Book(ctx context.Context, businessID, queueID string, slotStart time.Time, idempotencyKey string) (appt *domain.Appointment, replayed bool, err error)
+	// GetAppointment returns an appointment, also after it ended.
+	GetAppointment(ctx context.Context, businessID, queueID, appointmentID string) (*domain.Appointment, error)
+	// CheckIn puts the guest in the queue, on behalf of staffID when it isn't
+	// empty, and returns the appointment with their position.
+	CheckIn(ctx context.Context, businessID, queueID, appointmentID, staffID, idempotencyKey string) (*domain.Appointment, error)
+	// CancelAppointment gives a booked seat back.
+	CancelAppointment(ctx context.Context, businessID, queueID, appointmentID, staffID string) (*domain.Appointment, error)
 }
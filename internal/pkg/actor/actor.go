@@ -0,0 +1,101 @@
+// Package actor carries the staff member behind a request from the HTTP and
+// gRPC edges through Temporal workflows, so workflows can attribute the
+// changes they make.
+package actor
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+// temporalHeader is the Temporal header key the actor travels in.
+const temporalHeader = "actor"
+
+// Actor is who asked for a change, as their staff token names them.
+type Actor struct {
+	UserID     string `json:"user_id"`
+	Role       string `json:"role"`
+	BusinessID string `json:"business_id,omitempty"`
+}
+
+type ctxKey struct{}
+
+// With returns ctx carrying a.
+func With(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, ctxKey{}, a)
+}
+
+// FromContext returns the actor in ctx, or the zero Actor if there is none.
+func FromContext(ctx context.Context) Actor {
+	a, _ := ctx.Value(ctxKey{}).(Actor)
+	return a
+}
+
+// FromWorkflow returns the actor that started the workflow, or that sent the
+// update or signal being handled.
+func FromWorkflow(ctx workflow.Context) Actor {
+	a, _ := ctx.Value(ctxKey{}).(Actor)
+	return a
+}
+
+// NewContextPropagator copies the actor into the Temporal headers of workflow
+// starts, updates, signals and activities, and back out on the other side.
+// Set it in client.Options.ContextPropagators.
+func NewContextPropagator() workflow.ContextPropagator {
+	return propagator{}
+}
+
+type propagator struct{}
+
+func (propagator) Inject(ctx context.Context, hw workflow.HeaderWriter) error {
+	return inject(FromContext(ctx), hw)
+}
+
+func (propagator) InjectFromWorkflow(ctx workflow.Context, hw workflow.HeaderWriter) error {
+	return inject(FromWorkflow(ctx), hw)
+}
+
+func (propagator) Extract(ctx context.Context, hr workflow.HeaderReader) (context.Context, error) {
+	a, err := extract(hr)
+	if err != nil || a == (Actor{}) {
+		return ctx, err
+	}
+	return With(ctx, a), nil
+}
+
+// ExtractToWorkflow always sets the actor, even to the zero Actor: update and
+// signal contexts derive from the workflow's, and must not inherit the actor
+// that started it.
+func (propagator) ExtractToWorkflow(ctx workflow.Context, hr workflow.HeaderReader) (workflow.Context, error) {
+	a, err := extract(hr)
+	if err != nil {
+		return ctx, err
+	}
+	return workflow.WithValue(ctx, ctxKey{}, a), nil
+}
+
+func inject(a Actor, hw workflow.HeaderWriter) error {
+	if a == (Actor{}) {
+		return nil
+	}
+	payload, err := converter.GetDefaultDataConverter().ToPayload(a)
+	if err != nil {
+		return err
+	}
+	hw.Set(temporalHeader, payload)
+	return nil
+}
+
+func extract(hr workflow.HeaderReader) (Actor, error) {
+	payload, ok := hr.Get(temporalHeader)
+	if !ok {
+		return Actor{}, nil
+	}
+	var a Actor
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &a); err != nil {
+		return Actor{}, err
+	}
+	return a, nil
+}
@@ -0,0 +1,68 @@
+package actor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type headerWriter struct {
+	header *commonpb.Header
+}
+
+func (w headerWriter) Set(key string, value *commonpb.Payload) {
+	w.header.Fields[key] = value
+}
+
+func echoWorkflow(ctx workflow.Context) (Actor, error) {
+	return FromWorkflow(ctx), nil
+}
+
+func TestContextPropagator_CarriesActorIntoWorkflow(t *testing.T) {
+	staff := Actor{UserID: "staff-1", Role: "admin", BusinessID: "biz_123"}
+	header := &commonpb.Header{Fields: map[string]*commonpb.Payload{}}
+	require.NoError(t, NewContextPropagator().Inject(With(context.Background(), staff), headerWriter{header}))
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestWorkflowEnvironment()
+	env.SetContextPropagators([]workflow.ContextPropagator{NewContextPropagator()})
+	env.SetHeader(header)
+	env.ExecuteWorkflow(echoWorkflow)
+
+	require.NoError(t, env.GetWorkflowError())
+	var got Actor
+	require.NoError(t, env.GetWorkflowResult(&got))
+	assert.Equal(t, staff, got)
+}
+
+func TestContextPropagator_SkipsAnonymousCallers(t *testing.T) {
+	header := &commonpb.Header{Fields: map[string]*commonpb.Payload{}}
+	require.NoError(t, NewContextPropagator().Inject(context.Background(), headerWriter{header}))
+	assert.Empty(t, header.Fields)
+}
+
+type emptyHeader struct{}
+
+func (emptyHeader) Get(string) (*commonpb.Payload, bool) { return nil, false }
+
+func (emptyHeader) ForEachKey(func(string, *commonpb.Payload) error) error { return nil }
+
+func TestContextPropagator_UpdatesWithoutActorDontInheritStarter(t *testing.T) {
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestWorkflowEnvironment()
+	env.ExecuteWorkflow(func(ctx workflow.Context) (Actor, error) {
+		ctx = workflow.WithValue(ctx, ctxKey{}, Actor{UserID: "starter"})
+		ctx, err := NewContextPropagator().ExtractToWorkflow(ctx, emptyHeader{})
+		return FromWorkflow(ctx), err
+	})
+
+	require.NoError(t, env.GetWorkflowError())
+	var got Actor
+	require.NoError(t, env.GetWorkflowResult(&got))
+	assert.Zero(t, got)
+}
@@ -0,0 +1,170 @@
+// Package lifecycle starts the components of a binary in dependency order and
+// stops them in reverse order on shutdown.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Component is one piece of a binary that is started and stopped with it.
+// Either func may be nil.
+type Component struct {
+	Name string
+	// Start brings the component up and returns once it is running. Errors
+	// after that, e.g. a server that stops serving, are passed to fail and
+	// shut the whole binary down.
+	Start func(ctx context.Context, fail func(error)) error
+	// Stop shuts the component down, giving up when ctx expires.
+	Stop func(ctx context.Context) error
+}
+
+// Manager owns the components of a binary.
+type Manager struct {
+	components  []Component
+	stopTimeout time.Duration
+}
+
+// New returns a Manager that allows stopTimeout for stopping all components.
+func New(stopTimeout time.Duration) *Manager {
+	return &Manager{stopTimeout: stopTimeout}
+}
+
+// Add appends a component. Components are started in the order they are
+// added, so add dependencies first.
+func (m *Manager) Add(c Component) {
+	m.components = append(m.components, c)
+}
+
+// Run starts every component, then blocks until ctx is cancelled (e.g. by
+// SIGTERM) or a component fails. It then stops the started components in
+// reverse order and returns every error encountered, each prefixed with the
+// component's name.
+func (m *Manager) Run(ctx context.Context) error {
+	failures := make(chan error, len(m.components))
+
+	var errs []error
+	started := 0
+	for _, c := range m.components {
+		if c.Start == nil {
+			started++
+			continue
+		}
+		slog.Info("Starting component", "component", c.Name)
+		name := c.Name
+		fail := func(err error) {
+			select {
+			case failures <- fmt.Errorf("%s: %w", name, err):
+			default:
+			}
+		}
+		if err := c.Start(ctx, fail); err != nil {
+			errs = append(errs, fmt.Errorf("%s: start: %w", c.Name, err))
+			break
+		}
+		started++
+	}
+
+	if len(errs) == 0 {
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down")
+		case err := <-failures:
+			slog.Error("Component failed, shutting down", "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.stopTimeout)
+	defer cancel()
+	for i := started - 1; i >= 0; i-- {
+		c := m.components[i]
+		if c.Stop == nil {
+			continue
+		}
+		if err := c.Stop(stopCtx); err != nil {
+			slog.Error("Failed to stop component", "component", c.Name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: stop: %w", c.Name, err))
+			continue
+		}
+		slog.Info("Stopped component", "component", c.Name)
+	}
+	return errors.Join(errs...)
+}
+
+// HTTPServer listens on srv.Addr when started, and on stop waits for in-flight
+// requests to finish.
+func HTTPServer(name string, srv *http.Server) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context, fail func(error)) error {
+			ln, err := net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return err
+			}
+			slog.Info("Starting HTTP server", "component", name, "addr", ln.Addr().String())
+			go func() {
+				if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					fail(err)
+				}
+			}()
+			return nil
+		},
+		Stop: srv.Shutdown,
+	}
+}
+
+// Closer stops a resource that is created up front and only needs closing.
+func Closer(name string, close func()) Component {
+	return Component{
+		Name: name,
+		Stop: func(ctx context.Context) error {
+			close()
+			return nil
+		},
+	}
+}
+
+// GRPCServer listens on addr when started, and on stop waits for in-flight
+// calls to finish. Calls still running when the stop context expires, such as
+// long-lived streams, are cancelled.
+func GRPCServer(name, addr string, srv *grpc.Server) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context, fail func(error)) error {
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			slog.Info("Starting gRPC server", "component", name, "addr", ln.Addr().String())
+			go func() {
+				if err := srv.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+					fail(err)
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				srv.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				srv.Stop()
+				<-stopped
+				return nil
+			}
+		},
+	}
+}
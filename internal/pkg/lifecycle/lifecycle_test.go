@@ -0,0 +1,140 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// recorder builds components that log their start and stop calls.
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) component(name string) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context, fail func(error)) error {
+			r.calls = append(r.calls, "start "+name)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			r.calls = append(r.calls, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func TestRun_StopsInReverseOrderOnCancel(t *testing.T) {
+	rec := &recorder{}
+	m := New(time.Second)
+	m.Add(rec.component("db"))
+	m.Add(Closer("client", func() { rec.calls = append(rec.calls, "close client") }))
+	m.Add(rec.component("http"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, m.Run(ctx))
+
+	assert.Equal(t, []string{"start db", "start http", "stop http", "close client", "stop db"}, rec.calls)
+}
+
+func TestRun_StartFailureStopsOnlyStartedComponents(t *testing.T) {
+	rec := &recorder{}
+	m := New(time.Second)
+	m.Add(rec.component("db"))
+	m.Add(Component{
+		Name:  "worker",
+		Start: func(ctx context.Context, fail func(error)) error { return errors.New("no task queue") },
+		Stop:  func(ctx context.Context) error { t.Fatal("worker was never started"); return nil },
+	})
+	m.Add(rec.component("http"))
+
+	err := m.Run(context.Background())
+	assert.EqualError(t, err, "worker: start: no task queue")
+	assert.Equal(t, []string{"start db", "stop db"}, rec.calls)
+}
+
+func TestRun_RuntimeFailureShutsDownAndReportsErrors(t *testing.T) {
+	m := New(time.Second)
+	m.Add(Component{
+		Name: "nats",
+		Stop: func(ctx context.Context) error { return errors.New("drain timed out") },
+	})
+	m.Add(Component{
+		Name: "worker",
+		Start: func(ctx context.Context, fail func(error)) error {
+			go fail(errors.New("poller crashed"))
+			return nil
+		},
+	})
+
+	err := m.Run(context.Background())
+	assert.ErrorContains(t, err, "worker: poller crashed")
+	assert.ErrorContains(t, err, "nats: stop: drain timed out")
+}
+
+func TestHTTPServer_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	srv := &http.Server{
+		Addr: freeAddr(t),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(100 * time.Millisecond)
+			close(finished)
+		}),
+	}
+	c := HTTPServer("http", srv)
+	require.NoError(t, c.Start(context.Background(), func(err error) { t.Errorf("unexpected failure: %v", err) }))
+
+	go http.Get("http://" + srv.Addr)
+	<-started
+
+	require.NoError(t, c.Stop(context.Background()))
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Stop returned before the in-flight request finished")
+	}
+}
+
+func TestGRPCServer_CancelsStreamsWhenStopTimesOut(t *testing.T) {
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	addr := freeAddr(t)
+	c := GRPCServer("grpc", addr, srv)
+	require.NoError(t, c.Start(context.Background(), func(err error) { t.Errorf("unexpected failure: %v", err) }))
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	// Watch streams until cancelled, so it never drains on its own
+	stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, c.Stop(ctx))
+	_, err = stream.Recv()
+	assert.Error(t, err)
+}
+
+func freeAddr(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	return ln.Addr().String()
+}
@@ -0,0 +1,84 @@
+// Package problem writes RFC 7807 problem details, the error body of every
+// HTTP endpoint. Each problem carries a stable code clients can branch on;
+// the title and detail are for humans and may change.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// Codes of errors that aren't domain errors. Domain errors use their
+// domain.ErrorCode.
+const (
+	CodeInvalidRequest = "invalid_request"
+	CodeUnauthorized   = "unauthorized"
+	CodeForbidden      = "forbidden"
+	CodeNotFound       = "not_found"
+	CodeConflict       = "conflict"
+	CodeUnavailable    = "unavailable"
+	CodeInternal       = "internal"
+)
+
+// Problem is an RFC 7807 problem details object with a code extension member.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code"`
+}
+
+// TypeURI identifies the problem type of code.
+func TypeURI(code string) string {
+	return "urn:red-duck:problem:" + code
+}
+
+// New builds the problem for status and code.
+func New(status int, code, detail string) Problem {
+	return Problem{
+		Type:   TypeURI(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Write answers with a problem whose code is the generic one for status.
+// It replaces http.Error.
+func Write(w http.ResponseWriter, status int, detail string) {
+	WriteCode(w, status, codeForStatus(status), detail)
+}
+
+// WriteCode answers with a problem with an explicit code.
+func WriteCode(w http.ResponseWriter, status int, code, detail string) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", ContentType)
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(New(status, code, detail))
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,51 @@
+package problem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Write(rr, http.StatusBadRequest, "missing queue_id")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, ContentType, rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:red-duck:problem:invalid_request",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "missing queue_id",
+		"code": "invalid_request"
+	}`, rr.Body.String())
+}
+
+func TestWriteCode(t *testing.T) {
+	rr := httptest.NewRecorder()
+	WriteCode(rr, http.StatusConflict, "queue_closed", "")
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.JSONEq(t, `{
+		"type": "urn:red-duck:problem:queue_closed",
+		"title": "Conflict",
+		"status": 409,
+		"code": "queue_closed"
+	}`, rr.Body.String())
+}
+
+func TestGenericCodes(t *testing.T) {
+	for status, code := range map[int]string{
+		http.StatusBadRequest:          CodeInvalidRequest,
+		http.StatusUnauthorized:        CodeUnauthorized,
+		http.StatusForbidden:           CodeForbidden,
+		http.StatusNotFound:            CodeNotFound,
+		http.StatusConflict:            CodeConflict,
+		http.StatusServiceUnavailable:  CodeUnavailable,
+		http.StatusInternalServerError: CodeInternal,
+	} {
+		assert.Equal(t, code, New(status, codeForStatus(status), "").Code)
+	}
+}
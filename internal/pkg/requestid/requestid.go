@@ -0,0 +1,111 @@
+// Package requestid carries a per-request correlation ID from the HTTP edge
+// through Temporal workflows and activities to NATS events.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Header is the HTTP and NATS header the ID travels in.
+const Header = "X-Request-ID"
+
+// temporalHeader is the Temporal header key the ID travels in.
+const temporalHeader = "request-id"
+
+// maxLength bounds IDs accepted from callers so they can't bloat logs and headers.
+const maxLength = 128
+
+type ctxKey struct{}
+
+// With returns ctx carrying id.
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID in ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// FromWorkflow returns the request ID that started the workflow, or that sent
+// the update or signal being handled.
+func FromWorkflow(ctx workflow.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// Middleware reuses the caller's X-Request-ID or generates one, echoes it in
+// the response and stores it in the request context.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if id == "" || len(id) > maxLength {
+			id = uuid.New().String()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(With(r.Context(), id)))
+	})
+}
+
+// NewContextPropagator copies the request ID into the Temporal headers of
+// workflow starts, updates, signals and activities, and back out on the other
+// side. Set it in client.Options.ContextPropagators.
+func NewContextPropagator() workflow.ContextPropagator {
+	return propagator{}
+}
+
+type propagator struct{}
+
+func (propagator) Inject(ctx context.Context, hw workflow.HeaderWriter) error {
+	return inject(FromContext(ctx), hw)
+}
+
+func (propagator) InjectFromWorkflow(ctx workflow.Context, hw workflow.HeaderWriter) error {
+	return inject(FromWorkflow(ctx), hw)
+}
+
+func (propagator) Extract(ctx context.Context, hr workflow.HeaderReader) (context.Context, error) {
+	id, err := extract(hr)
+	if err != nil || id == "" {
+		return ctx, err
+	}
+	return With(ctx, id), nil
+}
+
+func (propagator) ExtractToWorkflow(ctx workflow.Context, hr workflow.HeaderReader) (workflow.Context, error) {
+	id, err := extract(hr)
+	if err != nil || id == "" {
+		return ctx, err
+	}
+	return workflow.WithValue(ctx, ctxKey{}, id), nil
+}
+
+func inject(id string, hw workflow.HeaderWriter) error {
+	if id == "" {
+		return nil
+	}
+	payload, err := converter.GetDefaultDataConverter().ToPayload(id)
+	if err != nil {
+		return err
+	}
+	hw.Set(temporalHeader, payload)
+	return nil
+}
+
+func extract(hr workflow.HeaderReader) (string, error) {
+	payload, ok := hr.Get(temporalHeader)
+	if !ok {
+		return "", nil
+	}
+	var id string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,84 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestMiddleware(t *testing.T) {
+	var seen string
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = FromContext(r.Context())
+	}))
+
+	t.Run("reuses caller ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(Header, "abc-123")
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+
+		assert.Equal(t, "abc-123", seen)
+		assert.Equal(t, "abc-123", rr.Header().Get(Header))
+	})
+
+	t.Run("generates missing or oversized ID", func(t *testing.T) {
+		for _, id := range []string{"", strings.Repeat("x", maxLength+1)} {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set(Header, id)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			assert.Len(t, seen, 36)
+			assert.Equal(t, seen, rr.Header().Get(Header))
+		}
+	})
+}
+
+type headerWriter struct {
+	header *commonpb.Header
+}
+
+func (w headerWriter) Set(key string, value *commonpb.Payload) {
+	w.header.Fields[key] = value
+}
+
+func echoActivity(ctx context.Context) (string, error) {
+	return FromContext(ctx), nil
+}
+
+func echoWorkflow(ctx workflow.Context) ([]string, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Second})
+	var fromActivity string
+	if err := workflow.ExecuteActivity(ctx, echoActivity).Get(ctx, &fromActivity); err != nil {
+		return nil, err
+	}
+	return []string{FromWorkflow(ctx), fromActivity}, nil
+}
+
+func TestContextPropagator_CarriesIDIntoWorkflowAndActivities(t *testing.T) {
+	header := &commonpb.Header{Fields: map[string]*commonpb.Payload{}}
+	require.NoError(t, NewContextPropagator().Inject(With(context.Background(), "req-42"), headerWriter{header}))
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestWorkflowEnvironment()
+	env.SetContextPropagators([]workflow.ContextPropagator{NewContextPropagator()})
+	env.SetHeader(header)
+	env.RegisterActivity(echoActivity)
+	env.ExecuteWorkflow(echoWorkflow)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var ids []string
+	require.NoError(t, env.GetWorkflowResult(&ids))
+	assert.Equal(t, []string{"req-42", "req-42"}, ids)
+}
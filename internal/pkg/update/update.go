@@ -1,7 +1,16 @@
+// Package update provides typed contracts for workflow updates, queries and
+// signals. A workflow package declares each contract once, and both the
+// workflow and its callers use it, so names and payload types can't drift.
 package update
 
-// TypedUpdate represents a typed update handle.
-// This is a helper to satisfy the requirement of using update.New[Req, Res].
+import (
+	"context"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TypedUpdate is an update taking Req and returning Res.
 type TypedUpdate[Req any, Res any] struct {
 	name string
 }
@@ -15,3 +24,109 @@ func New[Req any, Res any](name string) *TypedUpdate[Req, Res] {
 func (t *TypedUpdate[Req, Res]) Name() string {
 	return t.name
 }
+
+// Execute sends the update to the running workflow and waits for its result.
+func (t *TypedUpdate[Req, Res]) Execute(ctx context.Context, c client.Client, workflowID string, req Req) (Res, error) {
+	return t.ExecuteWithID(ctx, c, workflowID, "", req)
+}
+
+// ExecuteWithID is Execute with a caller-chosen update ID. Temporal
+// deduplicates updates by ID, so a retry with the same ID gets the first
+// result back. An empty ID lets Temporal generate one.
+func (t *TypedUpdate[Req, Res]) ExecuteWithID(ctx context.Context, c client.Client, workflowID, updateID string, req Req) (Res, error) {
+	var res Res
+	handle, err := c.UpdateWorkflow(ctx, client.UpdateWorkflowOptions{
+		WorkflowID:   workflowID,
+		UpdateID:     updateID,
+		WaitForStage: client.WorkflowUpdateStageCompleted,
+		UpdateName:   t.name,
+		Args:         []interface{}{req},
+	})
+	if err != nil {
+		return res, err
+	}
+	err = handle.Get(ctx, &res)
+	return res, err
+}
+
+// SetHandler registers fn as the workflow's handler for the update. validator
+// may be nil; when set, it rejects updates before they are written to history.
+func (t *TypedUpdate[Req, Res]) SetHandler(ctx workflow.Context, fn func(workflow.Context, Req) (Res, error), validator func(workflow.Context, Req) error) error {
+	var opts workflow.UpdateHandlerOptions
+	if validator != nil {
+		opts.Validator = validator
+	}
+	return workflow.SetUpdateHandlerWithOptions(ctx, t.name, fn, opts)
+}
+
+// TypedQuery is a query without arguments returning Res.
+type TypedQuery[Res any] struct {
+	name string
+}
+
+// NewQuery creates a new TypedQuery.
+func NewQuery[Res any](name string) *TypedQuery[Res] {
+	return &TypedQuery[Res]{name: name}
+}
+
+// Name returns the name of the query.
+func (q *TypedQuery[Res]) Name() string {
+	return q.name
+}
+
+// Execute queries the running workflow.
+func (q *TypedQuery[Res]) Execute(ctx context.Context, c client.Client, workflowID string) (Res, error) {
+	var res Res
+	value, err := c.QueryWorkflow(ctx, workflowID, "", q.name)
+	if err != nil {
+		return res, err
+	}
+	err = value.Get(&res)
+	return res, err
+}
+
+// SetHandler registers fn as the workflow's handler for the query.
+func (q *TypedQuery[Res]) SetHandler(ctx workflow.Context, fn func() (Res, error)) error {
+	return workflow.SetQueryHandler(ctx, q.name, fn)
+}
+
+// TypedSignal is a signal carrying T.
+type TypedSignal[T any] struct {
+	name string
+}
+
+// NewSignal creates a new TypedSignal.
+func NewSignal[T any](name string) *TypedSignal[T] {
+	return &TypedSignal[T]{name: name}
+}
+
+// Name returns the name of the signal.
+func (s *TypedSignal[T]) Name() string {
+	return s.name
+}
+
+// Send signals the running workflow.
+func (s *TypedSignal[T]) Send(ctx context.Context, c client.Client, workflowID string, v T) error {
+	return c.SignalWorkflow(ctx, workflowID, "", s.name, v)
+}
+
+// Channel returns the workflow's channel for the signal.
+func (s *TypedSignal[T]) Channel(ctx workflow.Context) workflow.ReceiveChannel {
+	return workflow.GetSignalChannel(ctx, s.name)
+}
+
+// Receive blocks until the signal arrives and returns its value.
+func (s *TypedSignal[T]) Receive(ctx workflow.Context) T {
+	var v T
+	s.Channel(ctx).Receive(ctx, &v)
+	return v
+}
+
+// AddToSelector makes selector call fn with the value of each signal it selects.
+func (s *TypedSignal[T]) AddToSelector(ctx workflow.Context, selector workflow.Selector, fn func(T)) workflow.Selector {
+	return selector.AddReceive(s.Channel(ctx), func(c workflow.ReceiveChannel, more bool) {
+		var v T
+		c.Receive(ctx, &v)
+		fn(v)
+	})
+}
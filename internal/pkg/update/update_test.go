@@ -0,0 +1,135 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+var (
+	add   = New[int, int]("Add")
+	total = NewQuery[int]("Total")
+	stop  = NewSignal[string]("Stop")
+)
+
+func counterWorkflow(ctx workflow.Context) (string, error) {
+	sum := 0
+	err := add.SetHandler(ctx,
+		func(ctx workflow.Context, n int) (int, error) {
+			sum += n
+			return sum, nil
+		},
+		func(ctx workflow.Context, n int) error {
+			if n < 0 {
+				return errors.New("negative")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := total.SetHandler(ctx, func() (int, error) { return sum, nil }); err != nil {
+		return "", err
+	}
+	return stop.Receive(ctx), nil
+}
+
+func TestWorkflowSide(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	var added int
+	var rejected error
+	env.RegisterDelayedCallback(func() {
+		env.UpdateWorkflow(add.Name(), "add-1", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { t.Errorf("rejected: %v", err) },
+			OnAccept:   func() {},
+			OnComplete: func(res interface{}, err error) { added = res.(int) },
+		}, 2)
+		env.UpdateWorkflow(add.Name(), "add-2", &testsuite.TestUpdateCallback{
+			OnReject:   func(err error) { rejected = err },
+			OnAccept:   func() {},
+			OnComplete: func(interface{}, error) {},
+		}, -1)
+	}, time.Millisecond)
+	env.RegisterDelayedCallback(func() {
+		v, err := env.QueryWorkflow(total.Name())
+		require.NoError(t, err)
+		var sum int
+		require.NoError(t, v.Get(&sum))
+		assert.Equal(t, 2, sum)
+		env.SignalWorkflow(stop.Name(), "done")
+	}, 2*time.Millisecond)
+
+	env.ExecuteWorkflow(counterWorkflow)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result string
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.Equal(t, "done", result)
+	assert.Equal(t, 2, added)
+	assert.ErrorContains(t, rejected, "negative")
+}
+
+func TestClientSide(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Update", func(t *testing.T) {
+		c := new(mocks.Client)
+		handle := new(mocks.WorkflowUpdateHandle)
+		handle.On("Get", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*int) = 5
+		})
+		c.On("UpdateWorkflow", mock.Anything, client.UpdateWorkflowOptions{
+			WorkflowID:   "wf-1",
+			UpdateID:     "add-1",
+			WaitForStage: client.WorkflowUpdateStageCompleted,
+			UpdateName:   "Add",
+			Args:         []interface{}{3},
+		}).Return(handle, nil)
+
+		sum, err := add.ExecuteWithID(ctx, c, "wf-1", "add-1", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, sum)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("UpdateWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+		_, err := add.Execute(ctx, c, "wf-1", 3)
+		assert.EqualError(t, err, "not found")
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		c := new(mocks.Client)
+		value := new(mocks.Value)
+		value.On("Get", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(0).(*int) = 7
+		})
+		c.On("QueryWorkflow", mock.Anything, "wf-1", "", "Total").Return(value, nil)
+
+		sum, err := total.Execute(ctx, c, "wf-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 7, sum)
+	})
+
+	t.Run("Signal", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("SignalWorkflow", mock.Anything, "wf-1", "", "Stop", "now").Return(nil)
+
+		assert.NoError(t, stop.Send(ctx, c, "wf-1", "now"))
+		c.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,49 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/update"
+)
+
+const (
+	UpdateCheckIn           = "CheckIn"
+	UpdateCancelAppointment = "CancelAppointment"
+	QueryGetAppointment     = "GetAppointment"
+)
+
+// AppointmentWorkflowID is the ID of the AppointmentWorkflow holding a seat.
+// A seat is held by at most one appointment at a time.
+func AppointmentWorkflowID(businessID, queueID, appointmentID string) string {
+	return fmt.Sprintf("appointment:%s:%s:%s", businessID, queueID, appointmentID)
+}
+
+// Contracts of AppointmentWorkflow.
+var (
+	// CheckInUpdate puts the guest in the queue and returns the checked-in appointment.
+	CheckInUpdate = update.New[CheckInRequest, domain.Appointment](UpdateCheckIn)
+	// CancelAppointmentUpdate gives the seat back and returns the cancelled appointment.
+	CancelAppointmentUpdate = update.New[CancelAppointmentRequest, domain.Appointment](UpdateCancelAppointment)
+	// GetAppointmentQuery returns the appointment.
+	GetAppointmentQuery = update.NewQuery[domain.Appointment](QueryGetAppointment)
+)
+
+// AppointmentParams starts an AppointmentWorkflow. The guest is reminded at
+// each of RemindAt while the appointment is booked.
+type AppointmentParams struct {
+	Appointment domain.Appointment
+	RemindAt    []time.Time
+}
+
+// CheckInRequest checks a guest in; StaffID is empty when they do it themselves.
+type CheckInRequest struct {
+	StaffID string
+}
+
+// CancelAppointmentRequest cancels an appointment; StaffID is empty when the
+// guest does it themselves.
+type CancelAppointmentRequest struct {
+	StaffID string
+}
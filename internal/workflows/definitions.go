@@ -1,5 +1,12 @@
 package workflows
 
+import (
+	"fmt"
+
+	"red-duck/internal/core/domain"
+	"red-duck/internal/pkg/update"
+)
+
 const (
 	TaskQueue = "QUEUE_TASK_QUEUE"
 
@@ -8,11 +15,86 @@ const (
 	SignalLeaveQueue = "LeaveQueue"
 	SignalCallNext   = "CallNext"
 	SignalExit       = "Exit" // Added for clean shutdown
+	SignalShutdown   = "Shutdown"
+
+	// Updates
+	UpdateJoinQueue    = "JoinQueue"
+	UpdateLeaveQueue   = "LeaveQueue"
+	UpdateCallNext     = "CallNext"
+	UpdateSetOpen      = "SetOpen"
+	UpdateMoveTicket   = "MoveTicket"
+	UpdateRemoveTicket = "RemoveTicket"
+	UpdateInsertTicket = "InsertTicket"
+	UpdateConfirm      = "ConfirmTicket"
 
 	// Queries
-	QueryGetState = "GetState"
+	QueryGetState  = "GetState"
+	QueryGetStatus = "GetStatus"
+)
+
+// QueueWorkflowID is the ID of the BusinessQueueWorkflow running a queue.
+func QueueWorkflowID(businessID, queueID string) string {
+	return fmt.Sprintf("%s:%s", businessID, queueID)
+}
+
+// Contracts of BusinessQueueWorkflow, used by the workflow and its callers alike.
+var (
+	// JoinQueueUpdate adds a user and returns their 1-based position.
+	JoinQueueUpdate = update.New[domain.JoinRequest, JoinResult](UpdateJoinQueue)
+	// LeaveQueueUpdate removes a user and returns how many remain.
+	LeaveQueueUpdate = update.New[domain.JoinRequest, int](UpdateLeaveQueue)
+	// CallNextUpdate calls the next waiting user to a counter and returns their ticket.
+	CallNextUpdate = update.New[CallNextSignal, domain.Ticket](UpdateCallNext)
+	// SetOpenUpdate opens (true) or closes (false) the queue to new joins and
+	// returns the resulting snapshot.
+	SetOpenUpdate = update.New[bool, domain.Queue](UpdateSetOpen)
+	// MoveTicketUpdate moves a ticket to another position and returns the resulting snapshot.
+	MoveTicketUpdate = update.New[MoveTicketRequest, domain.Queue](UpdateMoveTicket)
+	// RemoveTicketUpdate takes a ticket out of the queue on behalf of staff and
+	// returns it with the position it had.
+	RemoveTicketUpdate = update.New[RemoveTicketRequest, RemovedTicket](UpdateRemoveTicket)
+	// InsertTicketUpdate puts a ticket into the queue on behalf of staff and
+	// returns its 1-based position.
+	InsertTicketUpdate = update.New[InsertTicketRequest, int](UpdateInsertTicket)
+	// ConfirmTicketUpdate confirms a remote guest is coming, or has arrived,
+	// and returns their ticket.
+	ConfirmTicketUpdate = update.New[ConfirmTicketRequest, domain.Ticket](UpdateConfirm)
+	// GetStatusQuery returns a snapshot of the queue.
+	GetStatusQuery = update.NewQuery[domain.Queue](QueryGetStatus)
+	// ShutdownSignal closes a queue out; the workflow returns its domain.QueueReport.
+	// QueueWorkflow shares it.
+	ShutdownSignal = update.NewSignal[ShutdownRequest](SignalShutdown)
+	// ExitSignal is ShutdownSignal with just a reason, kept for older callers.
+	ExitSignal = update.NewSignal[string](SignalExit)
+)
+
+// Contracts of QueueWorkflow.
+var (
+	QueueJoinSignal     = update.NewSignal[JoinQueueSignal](SignalJoinQueue)
+	QueueLeaveSignal    = update.NewSignal[LeaveQueueSignal](SignalLeaveQueue)
+	QueueCallNextSignal = update.NewSignal[CallNextSignal](SignalCallNext)
+	GetStateQuery       = update.NewQuery[domain.Queue](QueryGetState)
 )
 
+// QueueOptions configure a BusinessQueueWorkflow when it starts. Queues
+// started without them run with the zero options.
+type QueueOptions struct {
+	Confirm domain.ConfirmPolicy
+	// MaxSkips is how often a waiting party can be passed over by calls for
+	// smaller tables before it holds the line; domain.DefaultMaxSkips if zero.
+	MaxSkips int
+	// Capacity is how many tickets may wait at once before joins are
+	// refused; zero is no limit.
+	Capacity int
+}
+
+// ConfirmTicketRequest confirms UserID is on their way. With a Location
+// inside the queue's geofence they have arrived.
+type ConfirmTicketRequest struct {
+	UserID   string
+	Location *domain.Location
+}
+
 type JoinQueueSignal struct {
 	UserID string
 }
@@ -21,13 +103,64 @@ type LeaveQueueSignal struct {
 	UserID string
 }
 
+// JoinResult is what a JoinQueue update answers. Temporal answers a
+// replayed update with the first result, so Nonce is that of the join that
+// added the guest. WaitMinutes is their estimated wait when they joined,
+// weighing the parties ahead by size.
+type JoinResult struct {
+	Position    int    `json:"position"`
+	WaitMinutes int    `json:"wait_minutes"`
+	Nonce       string `json:"nonce,omitempty"`
+}
+
 type CallNextSignal struct {
 	CounterID string
+	// Capacity is how many guests the counter or table takes. The call
+	// serves the first waiting party that fits; 0 serves the next waiting
+	// ticket whatever its size.
+	Capacity int
+}
+
+// MoveTicketRequest moves UserID to the 1-based Position, clamped to the queue.
+type MoveTicketRequest struct {
+	UserID   string
+	Position int
+	// StaffID is who moved the ticket, recorded in the audit trail.
+	StaffID string
+}
+
+// RemoveTicketRequest takes UserID's ticket out of the queue. Unlike a guest
+// leaving, the removal is not counted as abandoning the queue.
+type RemoveTicketRequest struct {
+	UserID  string
+	StaffID string
+	Reason  string
+}
+
+// RemovedTicket is a ticket taken out of a queue and the 1-based position it had.
+type RemovedTicket struct {
+	Ticket   domain.Ticket
+	Position int
+}
+
+// InsertTicketRequest puts Ticket at the 1-based Position, or at the end when
+// Position is 0. A zero JoinedAt becomes the time of the insert, and an empty
+// Status WAITING.
+type InsertTicketRequest struct {
+	Ticket   domain.Ticket
+	Position int
+	StaffID  string
+	Reason   string
+	// Joined tickets are new to the queue, like walk-ins, rather than moved
+	// into it, and are published as queue.joined as well.
+	Joined bool
 }
 
 type CallNextParams struct {
 	BusinessID string
+	QueueID    string
 	UserID     string
 	CounterID  string
 	Status     string
+	PartySize  int
 }
@@ -0,0 +1,36 @@
+package workflows
+
+import (
+	"errors"
+
+	"go.temporal.io/sdk/temporal"
+
+	"red-duck/internal/core/domain"
+)
+
+// ApplicationError converts a domain error into a non-retryable
+// ApplicationError typed with its code, so it survives the trip through
+// Temporal to the client. Other errors are returned unchanged. Return it from
+// update handlers and validators.
+func ApplicationError(err error) error {
+	code := domain.CodeOf(err)
+	if code == "" {
+		return err
+	}
+	return temporal.NewApplicationErrorWithOptions(err.Error(), string(code), temporal.ApplicationErrorOptions{
+		NonRetryable: true,
+	})
+}
+
+// DomainError recovers the domain error carried by an ApplicationError in
+// err's chain. It returns err unchanged when there is none.
+func DomainError(err error) error {
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) {
+		return err
+	}
+	if domainErr := domain.ErrorForCode(domain.ErrorCode(appErr.Type())); domainErr != nil {
+		return domainErr
+	}
+	return err
+}
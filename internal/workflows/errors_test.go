@@ -0,0 +1,44 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/temporal"
+
+	"red-duck/internal/core/domain"
+)
+
+func TestDomainErrorRoundTrip(t *testing.T) {
+	fc := temporal.GetDefaultFailureConverter()
+
+	for _, want := range []error{
+		domain.ErrUserAlreadyInQueue,
+		domain.ErrUserNotFound,
+		domain.ErrQueueEmpty,
+		domain.ErrQueueClosed,
+		domain.ErrCapacityReached,
+		domain.ErrMissingUserID,
+	} {
+		// As the workflow returns it, and the client decodes it
+		sent := ApplicationError(fmt.Errorf("join: %w", want))
+		received := fc.FailureToError(fc.ErrorToFailure(sent))
+
+		var appErr *temporal.ApplicationError
+		assert.True(t, errors.As(received, &appErr))
+		assert.Equal(t, string(domain.CodeOf(want)), appErr.Type())
+		assert.ErrorIs(t, DomainError(received), want)
+	}
+}
+
+func TestApplicationError_LeavesOtherErrors(t *testing.T) {
+	other := errors.New("activity failed")
+	assert.Same(t, other, ApplicationError(other))
+	assert.Same(t, other, DomainError(other))
+
+	appErr := temporal.NewApplicationError("boom", "SomethingElse")
+	assert.Same(t, appErr, DomainError(appErr))
+
+}
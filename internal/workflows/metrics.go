@@ -0,0 +1,34 @@
+package workflows
+
+import (
+	"red-duck/internal/core/domain"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	MetricQueueLength        = "redduck_queue_length"
+	MetricQueueOldestWaiting = "redduck_queue_oldest_waiting_ticket_timestamp_seconds"
+)
+
+// RecordQueueMetrics publishes the business-level gauges of a queue. The age of
+// the oldest waiting ticket is exported as its join timestamp, so dashboards
+// compute it as time() - redduck_queue_oldest_waiting_ticket_timestamp_seconds
+// and it keeps growing between state changes. Zero means nobody is waiting.
+func RecordQueueMetrics(ctx workflow.Context, q *domain.Queue) {
+	handler := workflow.GetMetricsHandler(ctx).WithTags(map[string]string{
+		"business_id": q.BusinessID,
+		"queue_id":    q.ID,
+	})
+
+	var oldest float64
+	for _, t := range q.Tickets {
+		if t.Status == domain.TicketStatusWaiting {
+			oldest = float64(t.JoinedAt.Unix())
+			break
+		}
+	}
+
+	handler.Gauge(MetricQueueLength).Update(float64(q.Len()))
+	handler.Gauge(MetricQueueOldestWaiting).Update(oldest)
+}
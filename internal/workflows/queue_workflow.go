@@ -14,7 +14,9 @@ type QueueWorkflowInput struct {
 	QueueID    string
 }
 
-func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
+// QueueWorkflow is the signal-driven queue that BusinessQueueWorkflow replaced.
+// Like it, it returns its final report once shut down.
+func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) (domain.QueueReport, error) {
 	logger := workflow.GetLogger(ctx)
 
 	// Fallback for missing input (if upgrading from old workflow version without input)
@@ -26,6 +28,7 @@ func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
 	// Note: The Workflow ID is likely "BusinessID:QueueID", but the domain entity
 	// stores the raw IDs.
 	state := domain.NewQueue(input.QueueID, input.BusinessID)
+	var stats domain.QueueStats
 
 	// If input was empty (e.g. migration), we might default.
 	if state.ID == "" {
@@ -41,21 +44,18 @@ func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
 	}
 
 	// Set Query Handler
-	err := workflow.SetQueryHandler(ctx, QueryGetState, func() (domain.Queue, error) {
+	err := GetStateQuery.SetHandler(ctx, func() (domain.Queue, error) {
 		return state.Snapshot(), nil
 	})
 	if err != nil {
-		return err
+		return domain.QueueReport{}, err
 	}
 
 	// Setup Selector
 	selector := workflow.NewSelector(ctx)
 
-	joinCh := workflow.GetSignalChannel(ctx, SignalJoinQueue)
-	selector.AddReceive(joinCh, func(c workflow.ReceiveChannel, more bool) {
-		var signal JoinQueueSignal
-		c.Receive(ctx, &signal)
-		err := state.Enqueue(signal.UserID)
+	QueueJoinSignal.AddToSelector(ctx, selector, func(signal JoinQueueSignal) {
+		err := state.Enqueue(signal.UserID, workflow.Now(ctx))
 		if err != nil {
 			logger.Error("Failed to enqueue user", "UserID", signal.UserID, "Error", err)
 		} else {
@@ -63,10 +63,11 @@ func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
 		}
 	})
 
-	leaveCh := workflow.GetSignalChannel(ctx, SignalLeaveQueue)
-	selector.AddReceive(leaveCh, func(c workflow.ReceiveChannel, more bool) {
-		var signal LeaveQueueSignal
-		c.Receive(ctx, &signal)
+	QueueLeaveSignal.AddToSelector(ctx, selector, func(signal LeaveQueueSignal) {
+		pos := state.GetPosition(signal.UserID)
+		if pos > 0 && state.Tickets[pos-1].Status == domain.TicketStatusWaiting {
+			stats.RecordLeft()
+		}
 		err := state.Dequeue(signal.UserID)
 		if err != nil {
 			logger.Error("Failed to dequeue user", "UserID", signal.UserID, "Error", err)
@@ -75,23 +76,18 @@ func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
 		}
 	})
 
-	exitCh := workflow.GetSignalChannel(ctx, SignalExit)
-	var exit bool
-	selector.AddReceive(exitCh, func(c workflow.ReceiveChannel, more bool) {
-		c.Receive(ctx, nil)
-		exit = true
+	var shutdown *ShutdownRequest
+	AddShutdownToSelector(ctx, selector, func(req ShutdownRequest) {
+		shutdown = &req
 	})
 
-	callNextCh := workflow.GetSignalChannel(ctx, SignalCallNext)
-	selector.AddReceive(callNextCh, func(c workflow.ReceiveChannel, more bool) {
-		var signal CallNextSignal
-		c.Receive(ctx, &signal)
-
+	QueueCallNextSignal.AddToSelector(ctx, selector, func(signal CallNextSignal) {
 		ticket, err := state.ServeNext(signal.CounterID)
 		if err != nil {
 			logger.Info("Queue Empty", "CounterID", signal.CounterID)
 		} else {
 			logger.Info("Calling next user", "UserID", ticket.UserID, "CounterID", signal.CounterID)
+			stats.RecordServed(workflow.Now(ctx).Sub(ticket.JoinedAt))
 
 			// Execute Activity to notify external systems (NATS)
 			ao := workflow.ActivityOptions{
@@ -101,9 +97,11 @@ func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
 
 			params := CallNextParams{
 				BusinessID: state.BusinessID,
+				QueueID:    state.ID,
 				UserID:     ticket.UserID,
 				CounterID:  signal.CounterID,
 				Status:     string(ticket.Status),
+				PartySize:  ticket.Guests(),
 			}
 
 			// We use string name "CallNext" because we can't easily import activities struct due to potential cycles
@@ -117,10 +115,14 @@ func QueueWorkflow(ctx workflow.Context, input QueueWorkflowInput) error {
 	})
 
 	// Main Loop
+	RecordQueueMetrics(ctx, state)
 	for {
 		selector.Select(ctx)
-		if exit {
-			return nil
+		RecordQueueMetrics(ctx, state)
+		if shutdown != nil {
+			report, err := CloseOut(ctx, state, stats, *shutdown)
+			RecordQueueMetrics(ctx, state)
+			return report, err
 		}
 	}
 }
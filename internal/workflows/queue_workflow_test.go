@@ -1,12 +1,18 @@
 package workflows
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/testsuite"
 
+	"red-duck/internal/adapters/metrics"
 	"red-duck/internal/core/domain"
 )
 
@@ -18,6 +24,15 @@ type UnitTestSuite struct {
 
 func (s *UnitTestSuite) SetupTest() {
 	s.env = s.NewTestWorkflowEnvironment()
+	registerCloseOutActivities(s.env)
+}
+
+// registerCloseOutActivities stands in for the worker's close-out activities.
+func registerCloseOutActivities(env *testsuite.TestWorkflowEnvironment) {
+	env.RegisterActivityWithOptions(func(context.Context, CancelTicketsParams) error { return nil },
+		activity.RegisterOptions{Name: ActivityCancelTickets})
+	env.RegisterActivityWithOptions(func(context.Context, domain.QueueReport) error { return nil },
+		activity.RegisterOptions{Name: ActivitySaveQueueReport})
 }
 
 func (s *UnitTestSuite) AfterTest(suiteName, testName string) {
@@ -77,8 +92,75 @@ func (s *UnitTestSuite) TestQueueWorkflow() {
 	s.env.ExecuteWorkflow(QueueWorkflow, input)
 
 	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	// u1 left and u2 was still waiting at exit
+	var report domain.QueueReport
+	s.NoError(s.env.GetWorkflowResult(&report))
+	s.Equal(2, report.Abandoned)
+	s.Equal(1, report.Cancelled)
 }
 
 func TestUnitTestSuite(t *testing.T) {
 	suite.Run(t, new(UnitTestSuite))
 }
+
+func TestQueueWorkflow_RecordsQueueMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	var ts testsuite.WorkflowTestSuite
+	ts.SetMetricsHandler(metrics.NewTemporalHandler(reg))
+	env := ts.NewTestWorkflowEnvironment()
+	registerCloseOutActivities(env)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(SignalJoinQueue, JoinQueueSignal{UserID: "u1"})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(SignalJoinQueue, JoinQueueSignal{UserID: "u2"})
+	}, time.Second*2)
+	env.RegisterDelayedCallback(func() {
+		length := gaugeValue(t, reg, MetricQueueLength)
+		assert.Equal(t, 2.0, length)
+		res, err := env.QueryWorkflow(QueryGetState)
+		require.NoError(t, err)
+		var state domain.Queue
+		require.NoError(t, res.Get(&state))
+		oldest := gaugeValue(t, reg, MetricQueueOldestWaiting)
+		assert.Equal(t, float64(state.Tickets[0].JoinedAt.Unix()), oldest)
+	}, time.Second*3)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(SignalLeaveQueue, LeaveQueueSignal{UserID: "u1"})
+		env.SignalWorkflow(SignalLeaveQueue, LeaveQueueSignal{UserID: "u2"})
+	}, time.Second*4)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(SignalExit, nil)
+	}, time.Second*5)
+
+	env.ExecuteWorkflow(QueueWorkflow, QueueWorkflowInput{BusinessID: "biz1", QueueID: "q1"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, 0.0, gaugeValue(t, reg, MetricQueueLength))
+	assert.Equal(t, 0.0, gaugeValue(t, reg, MetricQueueOldestWaiting))
+}
+
+// gaugeValue returns the value of the biz1/q1 series of a queue gauge.
+func gaugeValue(t *testing.T, reg *prometheus.Registry, name string) float64 {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["business_id"] == "biz1" && labels["queue_id"] == "q1" {
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	t.Fatalf("gauge %s not recorded", name)
+	return 0
+}
@@ -0,0 +1,72 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"red-duck/internal/core/domain"
+)
+
+// Search attributes of BusinessQueueWorkflow, so visibility queries can list
+// a business's queues without knowing their IDs.
+var (
+	BusinessIDAttribute  = temporal.NewSearchAttributeKeyKeyword("BusinessID")
+	QueueIDAttribute     = temporal.NewSearchAttributeKeyKeyword("QueueID")
+	QueueStateAttribute  = temporal.NewSearchAttributeKeyKeyword("QueueState")
+	QueueLengthAttribute = temporal.NewSearchAttributeKeyInt64("QueueLength")
+)
+
+// queueSearchAttributes are the attributes EnsureSearchAttributes registers.
+var queueSearchAttributes = map[string]enums.IndexedValueType{
+	BusinessIDAttribute.GetName():  enums.INDEXED_VALUE_TYPE_KEYWORD,
+	QueueIDAttribute.GetName():     enums.INDEXED_VALUE_TYPE_KEYWORD,
+	QueueStateAttribute.GetName():  enums.INDEXED_VALUE_TYPE_KEYWORD,
+	QueueLengthAttribute.GetName(): enums.INDEXED_VALUE_TYPE_INT,
+}
+
+// EnsureSearchAttributes registers the queue search attributes in namespace.
+// Attributes that already exist are left untouched.
+func EnsureSearchAttributes(ctx context.Context, c client.Client, namespace string) error {
+	existing, err := c.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list search attributes: %w", err)
+	}
+
+	missing := make(map[string]enums.IndexedValueType)
+	for name, typ := range queueSearchAttributes {
+		if _, ok := existing.GetCustomAttributes()[name]; !ok {
+			missing[name] = typ
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	_, err = c.OperatorService().AddSearchAttributes(ctx, &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespace,
+		SearchAttributes: missing,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add search attributes: %w", err)
+	}
+	return nil
+}
+
+// UpsertQueueSearchAttributes publishes the queue's identity, state and
+// length to visibility.
+func UpsertQueueSearchAttributes(ctx workflow.Context, q *domain.Queue) error {
+	return workflow.UpsertTypedSearchAttributes(ctx,
+		BusinessIDAttribute.ValueSet(q.BusinessID),
+		QueueIDAttribute.ValueSet(q.ID),
+		QueueStateAttribute.ValueSet(string(q.State())),
+		QueueLengthAttribute.ValueSet(int64(q.Len())),
+	)
+}
@@ -0,0 +1,77 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"red-duck/internal/core/domain"
+)
+
+// Activities the close-out runs, registered by the worker's QueueActivities.
+const (
+	ActivityCancelTickets   = "CancelTickets"
+	ActivitySaveQueueReport = "SaveQueueReport"
+)
+
+// ShutdownRequest asks a queue to close out and finish.
+type ShutdownRequest struct {
+	Reason string
+	// RequestedBy is the staff member who asked, empty for the system.
+	RequestedBy string
+	// Role is RequestedBy's role, for the audit log.
+	Role string
+}
+
+// CancelTicketsParams notifies the holders of Tickets that their queue shut down.
+type CancelTicketsParams struct {
+	BusinessID string
+	QueueID    string
+	Reason     string
+	Tickets    []domain.Ticket
+}
+
+// AddShutdownToSelector makes selector call fn when the queue is asked to shut
+// down, by ShutdownSignal or the older ExitSignal, whose value is its reason.
+func AddShutdownToSelector(ctx workflow.Context, selector workflow.Selector, fn func(ShutdownRequest)) workflow.Selector {
+	ShutdownSignal.AddToSelector(ctx, selector, fn)
+	return ExitSignal.AddToSelector(ctx, selector, func(reason string) {
+		fn(ShutdownRequest{Reason: reason})
+	})
+}
+
+// CloseOut ends a queue: it stops new joins, cancels the waiting tickets and
+// notifies their holders, then persists and returns the final report. The
+// caller returns the report as the workflow result.
+func CloseOut(ctx workflow.Context, q *domain.Queue, stats domain.QueueStats, req ShutdownRequest) (domain.QueueReport, error) {
+	logger := workflow.GetLogger(ctx)
+	q.Closed = true
+	cancelled := q.CancelWaiting()
+
+	// The close-out must not be lost, so its activities retry until they succeed
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	if len(cancelled) > 0 {
+		params := CancelTicketsParams{
+			BusinessID: q.BusinessID,
+			QueueID:    q.ID,
+			Reason:     req.Reason,
+			Tickets:    cancelled,
+		}
+		if err := workflow.ExecuteActivity(ctx, ActivityCancelTickets, params).Get(ctx, nil); err != nil {
+			return domain.QueueReport{}, err
+		}
+	}
+
+	report := stats.Report(q, len(cancelled), req.Reason, workflow.Now(ctx))
+	report.RunID = workflow.GetInfo(ctx).WorkflowExecution.RunID
+	if err := workflow.ExecuteActivity(ctx, ActivitySaveQueueReport, report).Get(ctx, nil); err != nil {
+		return domain.QueueReport{}, err
+	}
+
+	logger.Info("Queue closed out", "Reason", req.Reason, "RequestedBy", req.RequestedBy,
+		"Served", report.Served, "Abandoned", report.Abandoned, "Cancelled", report.Cancelled)
+	return report, nil
+}
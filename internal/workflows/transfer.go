@@ -0,0 +1,9 @@
+package workflows
+
+import "fmt"
+
+// TransferWorkflowID is the ID of the TransferTicketWorkflow moving a guest
+// out of a queue. A guest is in at most one transfer from a queue at a time.
+func TransferWorkflowID(businessID, queueID, userID string) string {
+	return fmt.Sprintf("transfer:%s:%s:%s", businessID, queueID, userID)
+}